@@ -17,6 +17,10 @@ type Column struct {
 	Unique    bool
 	Validated bool
 
+	// DefaultIsExpression is set when Default is evaluated by the server,
+	// like now() or a + b, rather than being a literal like 0 or 'x'
+	DefaultIsExpression bool
+
 	// Postgres only extension bits
 	// ArrType is the underlying data type of the Postgres
 	// ARRAY type. See here:
@@ -34,6 +38,52 @@ type Column struct {
 	// Used to indicate that the value
 	// for this column is auto generated by database on insert (i.e. - timestamp (old) or rowversion (new))
 	AutoGenerated bool
+
+	// Clickhouse only bits
+	// Comment of the column, directives like @sqlboiler:bool are read from it
+	// and it's generated as the doc comment of the struct field
+	Comment string
+	// DefaultKind is either DEFAULT, MATERIALIZED or ALIAS when the column
+	// has a default expression. The last two can't be inserted or updated,
+	// so they're also marked as AutoGenerated.
+	DefaultKind string
+	// EnumValues are the labels and values of an Enum8/Enum16 column
+	EnumValues []EnumValue
+	// DecimalPrecision and DecimalScale of a Decimal(P,S) column
+	DecimalPrecision int
+	DecimalScale     int
+	// FixedStringLength is the number of bytes N of a FixedString(N) column
+	FixedStringLength int
+	// DateTimePrecision is the number of subsecond digits of a DateTime64(P)
+	// column and Timezone its optional timezone argument
+	DateTimePrecision int
+	Timezone          string
+	// TupleElements are the elements of a Tuple(...) column, or the fields
+	// of a Nested(...) column, in order
+	TupleElements []TupleElement
+	// Codec are the compression codecs declared by the column, ex: Delta, ZSTD
+	Codec string
+	// AggregateState is set on AggregateFunction(...) columns, their value
+	// is an opaque aggregation state that can't be selected as a plain value
+	AggregateState bool
+	// UnknownType is set when the driver doesn't know the database type of
+	// the column, Type is then its fallback, []byte unless configured
+	UnknownType bool
+}
+
+// EnumValue is a single member of an enum column that carries
+// explicit values, ex: Enum8('a' = 1, 'b' = 2)
+type EnumValue struct {
+	Label string
+	Value int
+}
+
+// TupleElement is a single element of a tuple column, the name is only
+// set for named tuples, ex: Tuple(a String, b UInt8)
+type TupleElement struct {
+	Name   string
+	DBType string
+	Type   string
 }
 
 // ColumnNames of the columns.
@@ -57,12 +107,14 @@ func ColumnDBTypes(cols []Column) map[string]string {
 	return types
 }
 
-// FilterColumnsByAuto generates the list of columns that have autogenerated values
+// FilterColumnsByAuto generates the list of columns that have autogenerated values.
+// Aggregation states are counted in, they can't be written as plain values.
 func FilterColumnsByAuto(auto bool, columns []Column) []Column {
 	var cols []Column
 
 	for _, c := range columns {
-		if (auto && c.AutoGenerated) || (!auto && !c.AutoGenerated) {
+		generated := c.AutoGenerated || c.AggregateState
+		if (auto && generated) || (!auto && !generated) {
 			cols = append(cols, c)
 		}
 	}
@@ -83,6 +135,16 @@ func FilterColumnsByDefault(defaults bool, columns []Column) []Column {
 	return cols
 }
 
+// EnumLabels returns the labels of the enum values.
+func EnumLabels(values []EnumValue) []string {
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = v.Label
+	}
+
+	return labels
+}
+
 // FilterColumnsByEnum generates the list of columns that are enum values.
 func FilterColumnsByEnum(columns []Column) []Column {
 	var cols []Column
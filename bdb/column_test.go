@@ -67,6 +67,26 @@ func TestFilterColumnsByDefault(t *testing.T) {
 	}
 }
 
+func TestFilterColumnsByAuto(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "col1"},
+		{Name: "col2", AutoGenerated: true},
+		{Name: "col3", AggregateState: true},
+	}
+
+	res := FilterColumnsByAuto(false, cols)
+	if len(res) != 1 || res[0].Name != `col1` {
+		t.Errorf("Invalid result: %#v", res)
+	}
+
+	res = FilterColumnsByAuto(true, cols)
+	if len(res) != 2 || res[0].Name != `col2` || res[1].Name != `col3` {
+		t.Errorf("Invalid result: %#v", res)
+	}
+}
+
 func TestFilterColumnsByEnum(t *testing.T) {
 	t.Parallel()
 
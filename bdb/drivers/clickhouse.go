@@ -1,12 +1,20 @@
 package drivers
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
+	"github.com/BurntSushi/toml"
 	// clickhouse driver
 	_ "github.com/kshvakov/clickhouse"
 	"github.com/pkg/errors"
@@ -24,19 +32,153 @@ var UInt8AsBool bool
 type ClickhouseDriver struct {
 	connStr string
 	dbConn  *sql.DB
+
+	// providedConn is set when dbConn was given by the caller, it's then
+	// neither opened nor closed by the driver
+	providedConn bool
+
+	// sqlDriverName is the database/sql driver used to open connections
+	sqlDriverName string
+
+	// hosts are the primary host followed by the alt hosts, along with
+	// their connection strings, Ping falls back on them in order
+	hosts        []string
+	hostConnStrs []string
+
+	relationsFile string
+	relations     map[string][]bdb.ForeignKey
+
+	enumAsInt bool
+
+	// unknownType is the Go type of the unknown database types, or
+	// clickhouseUnknownTypeError to fail on them
+	unknownType string
+
+	logger func(query string, args ...interface{})
+
+	// version is the server version recorded by Ping, it's zero until then
+	// and the queries of the latest versions are used
+	version clickhouseVersion
+
+	// configErr is a config error NewClickhouseDriver can't return, Open
+	// returns it instead
+	configErr error
+
+	ignoreTablePrefixes []string
+
+	// systemDatabases are never introspected
+	systemDatabases []string
+
+	// caseInsensitiveTables matches the whitelist and blacklist entries
+	// regardless of case
+	caseInsensitiveTables bool
+
+	// cluster has the tables of all its replicas listed when set
+	cluster string
+
+	// databases are generated together when set, their tables are named
+	// database_table to avoid collisions
+	databases []string
+
+	// views are the names of the tables with a view engine, and tables the
+	// databases and names of the tables, filled by TableNames
+	views  map[string]bool
+	tables map[string]clickhouseTable
+
+	// bulk has TableNames load the columns, the engines and the indexes of
+	// all the tables at once, they're then read from columnRows, tableRows
+	// and indexes
+	bulk       bool
+	columnRows map[clickhouseTable][]clickhouseColumnRow
+	tableRows  map[clickhouseTable]clickhouseTableRow
+	indexes    map[clickhouseTable][]bdb.Index
+
+	// ddls caches the DDL of the tables TableTTL and TableProjections both
+	// parse, tables are introspected concurrently
+	ddlMu sync.Mutex
+	ddls  map[clickhouseTable]string
+}
+
+// clickhouseColumnRow is a row of system.columns
+type clickhouseColumnRow struct {
+	name, fullType, defaultKind, defaultValue, comment string
+	position                                           uint64
+}
+
+// clickhouseTableRow is a row of system.tables
+type clickhouseTableRow struct {
+	engineFull       string
+	totalRows        *uint64
+	createTableQuery string
+}
+
+// clickhouseTable is the location of a generated table in the database.
+type clickhouseTable struct {
+	database, name string
 }
 
 // ClickhouseDriverConfig is config for clickhouse
 type ClickhouseDriverConfig struct {
+	// DSN is a full tcp://host:port?... connection string used as is instead
+	// of the fields below, which must be either unset or agree with it
+	DSN string
+
 	Username, Password, Database, Host string
 	Port                               int
 	ReadTimeout, WriteTimeout          int
+	DialTimeout                        int // seconds, driver default when 0
 	Nagle                              bool
 	AltHosts                           []string
 	ConnectionOpenStrategy             string
 	BlockSize                          int
 	Debug                              bool
 	Secure, SkipVerify                 bool
+	Compress                           bool
+
+	// Databases are introspected together in a single run instead of
+	// Database when set, table names are then prefixed with their database.
+	Databases []string
+
+	// IgnoreTablePrefixes are the prefixes of table names that are never
+	// generated, defaults to clickhouseIgnoreTablePrefixes.
+	IgnoreTablePrefixes []string
+
+	// SystemDatabases are the databases whose tables are never generated,
+	// even when listed in Databases, defaults to clickhouseSystemDatabases.
+	SystemDatabases []string
+
+	// CaseInsensitiveTables matches the whitelist and blacklist entries with
+	// the table names regardless of case, they must match exactly otherwise.
+	CaseInsensitiveTables bool
+
+	// Cluster lists the tables of all the replicas of the cluster through
+	// clusterAllReplicas instead of those of the node connected to, a table
+	// found on several replicas is generated once.
+	Cluster string
+
+	// BulkIntrospection loads the columns and the engines of all the tables
+	// in two queries instead of querying them table by table, which saves
+	// round trips on large schemas.
+	BulkIntrospection bool
+
+	// Settings are passed verbatim as extra query string parameters of the
+	// DSN, the fields above take precedence over them.
+	Settings map[string]string
+
+	// RelationsFile is an optional path to a JSON or TOML file describing
+	// the relationships between tables, since Clickhouse has no foreign keys.
+	RelationsFile string
+
+	// EnumAsInt maps Enum8/Enum16 columns to int8/int16 instead of string.
+	EnumAsInt bool
+
+	// UnknownType is the Go type of the columns of a database type the
+	// driver doesn't know, either []byte, the default, string or interface{}.
+	// With "error" the introspection fails on them instead.
+	UnknownType string
+
+	// Logger is called with every introspection query before it's run.
+	Logger func(query string, args ...interface{})
 }
 
 // NewClickhouseDriver takes the database connection details as parameters and
@@ -44,13 +186,167 @@ type ClickhouseDriverConfig struct {
 // call ClickhouseDriver.Open() and ClickhouseDriver.Close() to open and close
 // the database connection once an object has been obtained.
 func NewClickhouseDriver(config ClickhouseDriverConfig) *ClickhouseDriver {
+	if len(config.DSN) != 0 {
+		return newClickhouseDriver(config, clickhouseDSNConn(config))
+	}
+	return newClickhouseDriver(config, clickhouseConfigConn(config))
+}
+
+// clickhouseConn are the connection parameters of a driver, either built
+// from the fields of the config or parsed from its DSN
+type clickhouseConn struct {
+	connStr      string
+	hosts        []string
+	hostConnStrs []string
+
+	// err is the config error Open returns
+	err error
+}
+
+// clickhouseConfigConn builds the connection strings of the host of config
+// and of its alternative hosts.
+func clickhouseConfigConn(config ClickhouseDriverConfig) clickhouseConn {
+	connStr := ClickhouseBuildQueryString(config)
+	conn := clickhouseConn{
+		connStr:      connStr,
+		hosts:        []string{fmt.Sprintf("%s:%d", config.Host, config.Port)},
+		hostConnStrs: []string{connStr},
+	}
+
+	for _, host := range config.AltHosts {
+		conn.hosts = append(conn.hosts, host)
+		conn.hostConnStrs = append(conn.hostConnStrs, clickhouseAltHostQueryString(config, host))
+	}
+
+	return conn
+}
+
+// clickhouseDSNConn connects with config.DSN, the conflicts between the DSN
+// and the other fields are returned by Open.
+func clickhouseDSNConn(config ClickhouseDriverConfig) clickhouseConn {
+	conn := clickhouseConn{connStr: config.DSN}
+
+	// The DSN may hold the password, it's kept out of the errors
+	dsn, err := url.Parse(config.DSN)
+	if uerr, ok := err.(*url.Error); ok {
+		err = uerr.Err
+	}
+	if err == nil {
+		err = clickhouseDSNConflicts(dsn, config)
+	}
+	if err != nil {
+		conn.err = errors.Wrap(err, "invalid clickhouse dsn")
+		return conn
+	}
+
+	conn.hosts = []string{dsn.Host}
+	conn.hostConnStrs = []string{config.DSN}
+	return conn
+}
+
+// newClickhouseDriver returns a driver connecting with conn, configured
+// and defaulted from the other fields of config.
+func newClickhouseDriver(config ClickhouseDriverConfig, conn clickhouseConn) *ClickhouseDriver {
 	driver := ClickhouseDriver{
-		connStr: ClickhouseBuildQueryString(config),
+		connStr:       conn.connStr,
+		hosts:         conn.hosts,
+		hostConnStrs:  conn.hostConnStrs,
+		configErr:     conn.err,
+		sqlDriverName: "clickhouse",
+		relationsFile: config.RelationsFile,
+		enumAsInt:     config.EnumAsInt,
+		unknownType:   config.UnknownType,
+		logger:        config.Logger,
+		databases:     config.Databases,
+
+		ignoreTablePrefixes:   config.IgnoreTablePrefixes,
+		systemDatabases:       config.SystemDatabases,
+		caseInsensitiveTables: config.CaseInsensitiveTables,
+		cluster:               config.Cluster,
+		bulk:                  config.BulkIntrospection,
+	}
+
+	if len(driver.ignoreTablePrefixes) == 0 {
+		driver.ignoreTablePrefixes = clickhouseIgnoreTablePrefixes
+	}
+	if driver.configErr == nil && !clickhouseValidUnknownType(driver.unknownType) {
+		driver.configErr = errors.Errorf("invalid clickhouse unknown type %q", driver.unknownType)
+	}
+	if len(driver.systemDatabases) == 0 {
+		driver.systemDatabases = clickhouseSystemDatabases
 	}
 
 	return &driver
 }
 
+// clickhouseDSNConflicts returns an error when a field of the config sets
+// a connection parameter the DSN doesn't have or sets to something else,
+// since the DSN is used as is and the field would be silently ignored. The
+// port is only checked along with the host, and the boolean options are
+// always taken from the DSN, their zero values being meaningful.
+func clickhouseDSNConflicts(dsn *url.URL, config ClickhouseDriverConfig) error {
+	if dsn.Scheme != "tcp" || len(dsn.Host) == 0 {
+		return errors.Errorf("want a tcp://host:port dsn, got scheme %q and host %q", dsn.Scheme, dsn.Host)
+	}
+
+	if len(config.Host) != 0 {
+		if host := fmt.Sprintf("%s:%d", config.Host, config.Port); host != dsn.Host {
+			return errors.Errorf("host %s conflicts with %s", host, dsn.Host)
+		}
+	}
+
+	q := dsn.Query()
+	params := []struct {
+		name, value string
+	}{
+		{"username", config.Username},
+		{"password", config.Password},
+		{"database", config.Database},
+		{"read_timeout", clickhouseItoa(config.ReadTimeout)},
+		{"write_timeout", clickhouseItoa(config.WriteTimeout)},
+		{"timeout", clickhouseItoa(config.DialTimeout)},
+		{"alt_hosts", strings.Join(config.AltHosts, ",")},
+		{"connection_open_strategy", config.ConnectionOpenStrategy},
+		{"block_size", clickhouseItoa(config.BlockSize)},
+	}
+	for key, value := range config.Settings {
+		params = append(params, struct{ name, value string }{key, value})
+	}
+
+	for _, p := range params {
+		if len(p.value) == 0 || q.Get(p.name) == p.value {
+			continue
+		}
+		if p.name == "password" {
+			return errors.New("password conflicts with the dsn")
+		}
+		return errors.Errorf("%s %s conflicts with %q", p.name, p.value, q.Get(p.name))
+	}
+
+	return nil
+}
+
+// clickhouseItoa formats a numeric config field, 0 meaning unset
+func clickhouseItoa(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+// NewClickhouseDriverFromDB returns a ClickhouseDriver introspecting the
+// database through db, an already opened connection pool. Open and Close
+// leave db alone, closing it is up to the caller.
+func NewClickhouseDriverFromDB(db *sql.DB) *ClickhouseDriver {
+	return &ClickhouseDriver{
+		dbConn:       db,
+		providedConn: true,
+
+		ignoreTablePrefixes: clickhouseIgnoreTablePrefixes,
+		systemDatabases:     clickhouseSystemDatabases,
+	}
+}
+
 // ClickhouseBuildQueryString builds a query string for Clickhouse.
 func ClickhouseBuildQueryString(config ClickhouseDriverConfig) string {
 	dsn := url.URL{}
@@ -74,6 +370,9 @@ func ClickhouseBuildQueryString(config ClickhouseDriverConfig) string {
 	if config.WriteTimeout != 0 {
 		q.Set("write_timeout", strconv.Itoa(config.WriteTimeout))
 	}
+	if config.DialTimeout != 0 {
+		q.Set("timeout", strconv.Itoa(config.DialTimeout))
+	}
 
 	q.Set("no_delay", strconv.FormatBool(!config.Nagle))
 
@@ -91,27 +390,208 @@ func ClickhouseBuildQueryString(config ClickhouseDriverConfig) string {
 
 	q.Set("debug", strconv.FormatBool(config.Debug))
 
+	if config.Secure {
+		q.Set("secure", "true")
+	}
+	if config.SkipVerify {
+		q.Set("skip_verify", "true")
+	}
+	if config.Compress {
+		q.Set("compress", "true")
+	}
+
+	for key, value := range config.Settings {
+		if _, ok := q[key]; ok {
+			continue
+		}
+		q.Set(key, value)
+	}
+
 	dsn.RawQuery = q.Encode()
 
 	return dsn.String()
 }
 
-// Open opens the database connection using the connection string
+// clickhouseAltHostQueryString builds the query string connecting to
+// a single alt host, given as host:port.
+func clickhouseAltHostQueryString(config ClickhouseDriverConfig, host string) string {
+	config.AltHosts = nil
+
+	dsn, _ := url.Parse(ClickhouseBuildQueryString(config))
+	dsn.Host = host
+
+	return dsn.String()
+}
+
+// Open opens the database connection using the connection string, unless
+// the driver was given a connection
 func (m *ClickhouseDriver) Open() error {
+	if m.configErr != nil {
+		return m.configErr
+	}
+
 	var err error
-	m.dbConn, err = sql.Open("clickhouse", m.connStr)
-	if err != nil {
-		return err
+	if !m.providedConn {
+		m.dbConn, err = sql.Open(m.sqlDriverName, m.connStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.relationsFile != "" {
+		m.relations, err = clickhouseLoadRelations(m.relationsFile)
+		if err != nil {
+			return errors.Wrapf(err, "unable to load relations file %s", m.relationsFile)
+		}
 	}
 
 	return nil
 }
 
-// Close closes the database connection
+// Close closes the database connection, a connection given to the driver
+// is left open
 func (m *ClickhouseDriver) Close() {
+	if m.providedConn {
+		return
+	}
 	m.dbConn.Close()
 }
 
+// Ping checks the database answers a lightweight query, it must be called
+// after Open. When the primary host fails each of the alt hosts is tried in
+// order, and the connection to the first one answering is kept. The host
+// used is returned, it's empty for a connection given to the driver. The
+// version of the server is recorded along the way, the introspection
+// queries are picked according to it.
+func (m *ClickhouseDriver) Ping() (string, error) {
+	version, err := clickhousePing(m.dbConn)
+	if m.providedConn {
+		m.version = version
+		return "", err
+	}
+	if err == nil {
+		m.version = version
+		return m.hosts[0], nil
+	}
+
+	failures := []string{fmt.Sprintf("%s: %v", m.hosts[0], err)}
+	for i := 1; i < len(m.hosts); i++ {
+		db, err := sql.Open(m.sqlDriverName, m.hostConnStrs[i])
+		if err == nil {
+			if version, err = clickhousePing(db); err != nil {
+				db.Close()
+			}
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", m.hosts[i], err))
+			continue
+		}
+
+		m.dbConn.Close()
+		m.dbConn, m.connStr = db, m.hostConnStrs[i]
+		m.version = version
+		return m.hosts[i], nil
+	}
+
+	return "", errors.Errorf("unable to reach any host (%s)", strings.Join(failures, "; "))
+}
+
+func clickhousePing(db *sql.DB) (clickhouseVersion, error) {
+	var version string
+	if err := db.QueryRow("SELECT version()").Scan(&version); err != nil {
+		return clickhouseVersion{}, clickhouseError(err)
+	}
+
+	return clickhouseParseVersion(version)
+}
+
+// ServerVersion returns the version of the server recorded by Ping, empty
+// before it
+func (m *ClickhouseDriver) ServerVersion() string {
+	return m.version.String()
+}
+
+// clickhouseVersion is a server version, ex: 21.8.3 for 21.8.3.44, the
+// build number is left out. Old releases were numbered 1.1.54xxx.
+type clickhouseVersion struct {
+	Major, Minor, Patch int
+}
+
+// clickhouseParseVersion parses the result of select version(), anything
+// after the third number is ignored.
+func clickhouseParseVersion(version string) (clickhouseVersion, error) {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 4)
+	if len(parts) < 3 {
+		return clickhouseVersion{}, errors.Errorf("bad clickhouse version: %q", version)
+	}
+
+	var v clickhouseVersion
+	for i, dst := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		part := parts[i]
+		// The patch may have a suffix when there's no build number
+		if i == 2 {
+			part = strings.TrimRightFunc(part, func(r rune) bool { return !unicode.IsDigit(r) })
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return clickhouseVersion{}, errors.Wrapf(err, "bad clickhouse version: %q", version)
+		}
+		*dst = n
+	}
+
+	return v, nil
+}
+
+// known tells apart a recorded version from the zero version
+func (v clickhouseVersion) known() bool {
+	return v != clickhouseVersion{}
+}
+
+// before reports whether v is known and older than major.minor
+func (v clickhouseVersion) before(major, minor int) bool {
+	if !v.known() {
+		return false
+	}
+	return v.Major < major || v.Major == major && v.Minor < minor
+}
+
+func (v clickhouseVersion) String() string {
+	if !v.known() {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// query runs an introspection query, logging it first
+func (m *ClickhouseDriver) query(query string, args ...interface{}) (*sql.Rows, error) {
+	if m.logger != nil {
+		m.logger(query, args...)
+	}
+	rows, err := m.dbConn.Query(query, args...)
+	return rows, clickhouseError(err)
+}
+
+// queryRow runs an introspection query returning a single row, logging
+// it first
+func (m *ClickhouseDriver) queryRow(query string, args ...interface{}) clickhouseRow {
+	if m.logger != nil {
+		m.logger(query, args...)
+	}
+	return clickhouseRow{Row: m.dbConn.QueryRow(query, args...)}
+}
+
+// clickhouseRow is a row whose Scan errors get their cause like the ones
+// of query, sql.ErrNoRows is returned as is.
+type clickhouseRow struct {
+	*sql.Row
+}
+
+// Scan copies the columns of the row into dest
+func (r clickhouseRow) Scan(dest ...interface{}) error {
+	return clickhouseError(r.Row.Scan(dest...))
+}
+
 // UseLastInsertID returns false to indicate Clickhouse doesnt support last insert id
 func (m *ClickhouseDriver) UseLastInsertID() bool {
 	return false
@@ -128,209 +608,1637 @@ func (m *ClickhouseDriver) UseTopClause() bool {
 func (m *ClickhouseDriver) TableNames(database string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := fmt.Sprintf(`select name from system.tables where database = ? and database <> 'system'`)
-	args := []interface{}{database}
+	databases := m.databases
+	nameExpr := "concat(database, '_', name)"
+	if len(databases) == 0 {
+		databases = []string{database}
+		nameExpr = "name"
+	}
+	if m.caseInsensitiveTables {
+		nameExpr = fmt.Sprintf("lower(%s)", nameExpr)
+	}
+
+	from, args := m.systemTable("system.tables")
+	query := fmt.Sprintf(`select database, name, engine from %s where database in (%s)`, from, strings.Repeat(",?", len(databases))[1:])
+	for _, d := range databases {
+		args = append(args, d)
+	}
+	if len(m.systemDatabases) > 0 {
+		query += fmt.Sprintf(" and database not in (%s)", strings.Repeat(",?", len(m.systemDatabases))[1:])
+		for _, d := range m.systemDatabases {
+			args = append(args, d)
+		}
+	}
 	if len(whitelist) > 0 {
-		query += fmt.Sprintf(" and name in (%s);", strings.Repeat(",?", len(whitelist))[1:])
+		query += fmt.Sprintf(" and %s in (%s)", nameExpr, strings.Repeat(",?", len(whitelist))[1:])
 		for _, w := range whitelist {
-			args = append(args, w)
+			args = append(args, m.tableNameArg(w))
 		}
 	} else if len(blacklist) > 0 {
-		query += fmt.Sprintf(" and name not in (%s);", strings.Repeat(",?", len(blacklist))[1:])
+		query += fmt.Sprintf(" and %s not in (%s)", nameExpr, strings.Repeat(",?", len(blacklist))[1:])
 		for _, b := range blacklist {
-			args = append(args, b)
+			args = append(args, m.tableNameArg(b))
 		}
 	}
+	query += " order by database, name;"
 
-	rows, err := m.dbConn.Query(query, args...)
+	rows, err := m.query(query, args...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	m.views = map[string]bool{}
+	m.tables = map[string]clickhouseTable{}
+
 	defer rows.Close()
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var tableDatabase, table, engine string
+		if err := rows.Scan(&tableDatabase, &table, &engine); err != nil {
 			return nil, err
 		}
+		if m.ignoredTable(table) {
+			continue
+		}
+
+		name := table
+		if len(m.databases) != 0 {
+			name = tableDatabase + "_" + table
+		}
+		// Every replica of the cluster has its own row for the table
+		if _, ok := m.tables[name]; ok {
+			continue
+		}
+
+		m.tables[name] = clickhouseTable{database: tableDatabase, name: table}
+		if clickhouseIsViewEngine(engine) {
+			m.views[name] = true
+		}
 		names = append(names, name)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.bulk {
+		if err = m.loadBulk(databases); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the generated output stable whatever order the rows came in
+	sort.Strings(names)
+
 	return names, nil
 }
 
-// Columns takes a table name and attempts to retrieve the table information
-// from the database system.columns. It retrieves the column names
-// and column types and returns those as a []Column after TranslateColumnType()
-// converts the SQL types to Go types, for example: "varchar" to "string"
-func (m *ClickhouseDriver) Columns(database, tableName string) ([]bdb.Column, error) {
-	var columns []bdb.Column
-
-	rows, err := m.dbConn.Query(`
-	select name, type, default_expression
-		from system.columns
-	where table = ? and database = ?;
-	`, tableName, database)
+// loadBulk fetches the columns, the engines and the indexes of all the
+// tables of the databases, Columns, PrimaryKeyInfo, TableRowEstimate and
+// IndexInfo then look them up instead of querying each table.
+func (m *ClickhouseDriver) loadBulk(databases []string) error {
+	placeholders := strings.Repeat(",?", len(databases))[1:]
+	var databaseArgs []interface{}
+	for _, d := range databases {
+		databaseArgs = append(databaseArgs, d)
+	}
 
+	from, args := m.systemTable("system.columns")
+	rows, err := m.query(fmt.Sprintf(m.bulkColumnsQuery(), from, placeholders), append(args, databaseArgs...)...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
+	m.columnRows = map[clickhouseTable][]clickhouseColumnRow{}
+	seen := map[clickhouseTable]map[string]bool{}
 	for rows.Next() {
-		var colName, fullColType string
-		var defaultValue string
-		if err := rows.Scan(&colName, &fullColType, &defaultValue); err != nil {
-			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		var t clickhouseTable
+		var c clickhouseColumnRow
+		if err := rows.Scan(&t.database, &t.name, &c.name, &c.fullType, &c.defaultKind, &c.defaultValue, &c.comment, &c.position); err != nil {
+			return errors.Wrap(err, "unable to scan the columns")
 		}
-
-		colType := fullColType
-		idx := strings.Index(fullColType, "(")
-		if idx > 0 {
-			colType = fullColType[:idx]
+		// Every replica of the cluster has its own row for the column
+		if seen[t][c.name] {
+			continue
 		}
+		if seen[t] == nil {
+			seen[t] = map[string]bool{}
+		}
+		seen[t][c.name] = true
+		m.columnRows[t] = append(m.columnRows[t], c)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
 
-		column := bdb.Column{
-			Name:       colName,
-			FullDBType: fullColType,
-			DBType:     colType,
-			Default:    defaultValue,
+	from, args = m.systemTable("system.tables")
+	tableRows, err := m.query(fmt.Sprintf(m.bulkTablesQuery(), from, placeholders), append(args, databaseArgs...)...)
+	if err != nil {
+		return err
+	}
+	defer tableRows.Close()
+
+	m.tableRows = map[clickhouseTable]clickhouseTableRow{}
+	for tableRows.Next() {
+		var t clickhouseTable
+		var r clickhouseTableRow
+		if err := tableRows.Scan(&t.database, &t.name, &r.engineFull, &r.totalRows, &r.createTableQuery); err != nil {
+			return errors.Wrap(err, "unable to scan the tables")
 		}
+		m.tableRows[t] = r
+	}
+	if err = tableRows.Err(); err != nil {
+		return err
+	}
 
-		columns = append(columns, column)
+	if m.version.before(21, 6) {
+		return nil
 	}
 
-	return columns, nil
-}
+	from, args = m.systemTable("system.data_skipping_indices")
+	indexRows, err := m.query(fmt.Sprintf(`select database, table, name, type, expr, granularity from %s where database in (%s);`, from, placeholders), append(args, databaseArgs...)...)
+	if err != nil {
+		return err
+	}
+	defer indexRows.Close()
+
+	m.indexes = map[clickhouseTable][]bdb.Index{}
+	seen = map[clickhouseTable]map[string]bool{}
+	for indexRows.Next() {
+		var t clickhouseTable
+		var index bdb.Index
+		if err := indexRows.Scan(&t.database, &t.name, &index.Name, &index.Type, &index.Expression, &index.Granularity); err != nil {
+			return errors.Wrap(err, "unable to scan the indexes")
+		}
+		if seen[t][index.Name] {
+			continue
+		}
+		if seen[t] == nil {
+			seen[t] = map[string]bool{}
+		}
+		seen[t][index.Name] = true
+		m.indexes[t] = append(m.indexes[t], index)
+	}
 
-// PrimaryKeyInfo looks up the primary key for a table.
-func (m *ClickhouseDriver) PrimaryKeyInfo(database, table string) (*bdb.PrimaryKey, error) {
-	pkey := &bdb.PrimaryKey{}
-	var err error
+	return indexRows.Err()
+}
 
-	query := `
-	select name, engine_full
-	from system.tables
-	where name = ? and database = ?;`
+// systemTable returns the system table the introspection queries read from
+// along with its leading query arguments, it's read from all the replicas
+// of the cluster when one is set so the tables of any replica are found.
+func (m *ClickhouseDriver) systemTable(name string) (string, []interface{}) {
+	if len(m.cluster) == 0 {
+		return name, nil
+	}
 
-	var engineFull string
+	return fmt.Sprintf("clusterAllReplicas(?, %s)", name), []interface{}{m.cluster}
+}
 
-	row := m.dbConn.QueryRow(query, table, database)
-	if err = row.Scan(&pkey.Name, &engineFull); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
+// tableNameArg is the query argument a whitelist or blacklist entry is
+// compared with, lowered to match the lowered names when case insensitive.
+func (m *ClickhouseDriver) tableNameArg(name string) string {
+	if m.caseInsensitiveTables {
+		return strings.ToLower(name)
 	}
 
-	engine, err := m.parseEngine(engineFull)
-	if err != nil {
-		return nil, errors.Wrapf(err, "bad engine=`%s`", engineFull)
-	}
+	return name
+}
 
-	pkey.Columns = engine.PrimaryKey
+// table resolves a name returned by TableNames to the database and the
+// name of the table in it.
+func (m *ClickhouseDriver) table(database, name string) (string, string) {
+	if t, ok := m.tables[name]; ok {
+		return t.database, t.name
+	}
 
-	return pkey, nil
+	return database, name
 }
 
-func (m *ClickhouseDriver) parseEngine(str string) (*clickhouseEngine, error) {
-	idx := strings.Index(str, "(")
-	if idx == -1 {
-		return nil, errors.New("open bracket not found")
+// SchemaName returns the qualified name of the table when several databases
+// are generated together, ex: `analytics`.`events`.
+func (m *ClickhouseDriver) SchemaName(database, tableName string) (string, error) {
+	if len(m.databases) == 0 {
+		return "", nil
 	}
 
-	engine := clickhouseEngine{}
-	engine.Name = str[:idx]
+	database, tableName = m.table(database, tableName)
+	return fmt.Sprintf("%c%s%c.%c%s%c", m.LeftQuote(), database, m.RightQuote(), m.LeftQuote(), tableName, m.RightQuote()), nil
+}
 
-	params := strings.Trim(str[idx:], "() ")
+// IsView checks whether the table was created with a view engine,
+// TableNames must be called beforehand.
+func (m *ClickhouseDriver) IsView(database, tableName string) (bool, error) {
+	return m.views[tableName], nil
+}
 
-	idx = strings.Index(params, ",")
-	if idx == -1 {
-		return nil, errors.New("partitioning key not found")
+// TableRowEstimate returns the number of rows system.tables reports for the
+// table, it's zero for the engines that don't track it, and before 20.4
+// which had no total_rows column.
+func (m *ClickhouseDriver) TableRowEstimate(database, tableName string) (uint64, error) {
+	if m.version.before(20, 4) {
+		return 0, nil
 	}
 
-	engine.PartitioningKey = params[:idx]
-	params = strings.TrimLeft(params[idx:], ", ")
+	database, tableName = m.table(database, tableName)
 
-	idx = strings.LastIndex(params, ",")
-	if idx == -1 {
-		return nil, errors.New("granularity key not found")
+	if r, ok := m.tableRows[clickhouseTable{database: database, name: tableName}]; ok {
+		if r.totalRows == nil {
+			return 0, nil
+		}
+		return *r.totalRows, nil
 	}
 
-	granularity, err := strconv.Atoi(strings.Trim(params[idx:], ", "))
-	if err != nil {
-		return nil, errors.Wrap(err, "parsing granularity failed")
+	var total *uint64
+	from, args := m.systemTable("system.tables")
+	row := m.queryRow(fmt.Sprintf(`select total_rows from %s where name = ? and database = ? limit 1;`, from), append(args, tableName, database)...)
+	if err := row.Scan(&total); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if total == nil {
+		return 0, nil
 	}
 
-	engine.Granularity = granularity
-
-	primary := strings.Trim(params[:idx], "() ")
-
-	primaryKey := strings.Split(primary, ",")
+	return *total, nil
+}
 
-	for i, col := range primaryKey {
-		primaryKey[i] = strings.TrimSpace(col)
+// TableTTL returns the TTL expression of the table read from its DDL, or
+// an empty string when it has none.
+func (m *ClickhouseDriver) TableTTL(database, tableName string) (string, error) {
+	ddl, err := m.createTableQuery(database, tableName)
+	if err != nil {
+		return "", err
 	}
 
-	engine.PrimaryKey = primaryKey
+	return clickhouseParseTTL(ddl), nil
+}
 
-	return &engine, nil
+// clickhouseParseTTL returns the expression of the table TTL clause of a
+// CREATE TABLE query, ex: date + toIntervalMonth(1) DELETE. The TTLs of
+// the columns are within the parentheses of the columns and left out.
+func clickhouseParseTTL(ddl string) string {
+	return clickhouseSplitClauses(strings.Join(strings.Fields(ddl), " "))["TTL"]
 }
 
-type clickhouseEngine struct {
-	Name            string
-	PartitioningKey string
-	// SamplingKey     string
-	PrimaryKey  []string
-	Granularity int
+// TableProjections returns the projections of the table read from its DDL.
+func (m *ClickhouseDriver) TableProjections(database, tableName string) ([]bdb.Projection, error) {
+	ddl, err := m.createTableQuery(database, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return clickhouseParseProjections(ddl), nil
+}
+
+// clickhouseParseProjections returns the projections declared among the
+// columns of a CREATE TABLE query, ex: PROJECTION by_day (SELECT day,
+// count() GROUP BY day).
+func clickhouseParseProjections(ddl string) []bdb.Projection {
+	ddl = strings.Join(strings.Fields(ddl), " ")
+
+	open := strings.IndexByte(ddl, '(')
+	if !strings.HasPrefix(ddl, "CREATE TABLE ") || open < 0 {
+		return nil
+	}
+	end := clickhouseMatchParen(ddl[open:])
+	if end < 0 {
+		return nil
+	}
+
+	var projections []bdb.Projection
+	for _, def := range clickhouseSplitArgs(ddl[open+1 : open+end]) {
+		if !strings.HasPrefix(def, "PROJECTION ") {
+			continue
+		}
+
+		def = strings.TrimSpace(strings.TrimPrefix(def, "PROJECTION "))
+		space := strings.IndexByte(def, ' ')
+		if space < 0 {
+			continue
+		}
+
+		query := strings.TrimSpace(def[space+1:])
+		if strings.HasPrefix(query, "(") && clickhouseMatchParen(query) == len(query)-1 {
+			query = strings.TrimSpace(query[1 : len(query)-1])
+		}
+
+		projections = append(projections, bdb.Projection{
+			Name:  strings.Trim(def[:space], "`"),
+			Query: query,
+		})
+	}
+
+	return projections
+}
+
+// createTableQuery returns the DDL of the table, or an empty string when
+// there's no such table. It's queried once per table.
+func (m *ClickhouseDriver) createTableQuery(database, tableName string) (string, error) {
+	database, tableName = m.table(database, tableName)
+
+	t := clickhouseTable{database: database, name: tableName}
+	if r, ok := m.tableRows[t]; ok {
+		return r.createTableQuery, nil
+	}
+
+	m.ddlMu.Lock()
+	ddl, ok := m.ddls[t]
+	m.ddlMu.Unlock()
+	if ok {
+		return ddl, nil
+	}
+
+	from, args := m.systemTable("system.tables")
+	row := m.queryRow(fmt.Sprintf(`select create_table_query from %s where name = ? and database = ? limit 1;`, from), append(args, tableName, database)...)
+	if err := row.Scan(&ddl); err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	m.ddlMu.Lock()
+	if m.ddls == nil {
+		m.ddls = map[clickhouseTable]string{}
+	}
+	m.ddls[t] = ddl
+	m.ddlMu.Unlock()
+
+	return ddl, nil
+}
+
+// engineFull returns the engine of the table with its parameters, it's
+// sql.ErrNoRows when there's no such table.
+func (m *ClickhouseDriver) engineFull(database, table string) (string, error) {
+	if r, ok := m.tableRows[clickhouseTable{database: database, name: table}]; ok {
+		return r.engineFull, nil
+	}
+
+	from, args := m.systemTable("system.tables")
+	query := fmt.Sprintf(`
+	select name, engine_full
+	from %s
+	where name = ? and database = ?
+	limit 1;`, from)
+
+	var name, engineFull string
+	if err := m.queryRow(query, append(args, table, database)...).Scan(&name, &engineFull); err != nil {
+		return "", err
+	}
+
+	return engineFull, nil
+}
+
+// IndexInfo returns the data skipping indexes of the table, there are none
+// before 21.6 which had no system.data_skipping_indices table.
+func (m *ClickhouseDriver) IndexInfo(database, tableName string) ([]bdb.Index, error) {
+	if m.version.before(21, 6) {
+		return nil, nil
+	}
+
+	database, tableName = m.table(database, tableName)
+
+	// Tables without indexes aren't in the bulk loaded ones
+	if m.indexes != nil {
+		return m.indexes[clickhouseTable{database: database, name: tableName}], nil
+	}
+
+	from, args := m.systemTable("system.data_skipping_indices")
+	rows, err := m.query(fmt.Sprintf(`select name, type, expr, granularity from %s where table = ? and database = ?;`, from), append(args, tableName, database)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []bdb.Index
+	seen := map[string]bool{}
+	for rows.Next() {
+		var index bdb.Index
+		if err := rows.Scan(&index.Name, &index.Type, &index.Expression, &index.Granularity); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan indexes of table %s", tableName)
+		}
+		// Every replica of the cluster has its own row for the index
+		if seen[index.Name] {
+			continue
+		}
+		seen[index.Name] = true
+		indexes = append(indexes, index)
+	}
+
+	return indexes, rows.Err()
+}
+
+// clickhouseIsViewEngine checks if the engine is one of a read-only view.
+func clickhouseIsViewEngine(engine string) bool {
+	switch engine {
+	case "View", "MaterializedView":
+		return true
+	}
+
+	return false
+}
+
+// clickhouseIgnoreTablePrefixes are the default prefixes of internal tables,
+// like the ones backing materialized views.
+var clickhouseIgnoreTablePrefixes = []string{".inner."}
+
+// clickhouseSystemDatabases are the default databases holding the server's
+// own tables.
+var clickhouseSystemDatabases = []string{"system"}
+
+// ignoredTable checks if the table name starts with one of the ignored
+// prefixes.
+func (m *ClickhouseDriver) ignoredTable(name string) bool {
+	for _, prefix := range m.ignoreTablePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from the database system.columns. It retrieves the column names
+// and column types and returns those as a []Column after TranslateColumnType()
+// converts the SQL types to Go types, for example: "varchar" to "string"
+func (m *ClickhouseDriver) Columns(database, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	database, tableName = m.table(database, tableName)
+
+	columnRows, ok := m.columnRows[clickhouseTable{database: database, name: tableName}]
+	if !ok {
+		var err error
+		if columnRows, err = m.columnRowsOf(database, tableName); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(columnRows) == 0 {
+		return nil, errors.Wrapf(ErrNoColumns, "table %s.%s", database, tableName)
+	}
+
+	var positions []uint64
+	for _, r := range columnRows {
+		fullColType, codec := clickhouseSplitCodec(r.fullType)
+
+		if m.unknownType == clickhouseUnknownTypeError {
+			if _, known := m.translateType(fullColType); !known {
+				return nil, errors.Wrapf(ErrUnknownType, "column %s.%s of type %s", tableName, r.name, fullColType)
+			}
+		}
+
+		colType := fullColType
+		idx := strings.Index(fullColType, "(")
+		if idx > 0 {
+			colType = fullColType[:idx]
+		}
+
+		column := bdb.Column{
+			Name:       r.name,
+			FullDBType: fullColType,
+			DBType:     colType,
+			Codec:      codec,
+			Default:    clickhouseDefault(r.defaultKind, r.defaultValue),
+			Comment:    r.comment,
+
+			DefaultKind:   r.defaultKind,
+			AutoGenerated: r.defaultKind == "MATERIALIZED" || r.defaultKind == "ALIAS",
+		}
+
+		columns = append(columns, column)
+		positions = append(positions, r.position)
+	}
+
+	// The struct fields follow the physical order of the columns, which
+	// positional inserts rely on
+	sort.Stable(clickhouseColumnsByPosition{columns: columns, positions: positions})
+
+	return columns, nil
+}
+
+// columnRowsOf queries the columns of a single table
+func (m *ClickhouseDriver) columnRowsOf(database, tableName string) ([]clickhouseColumnRow, error) {
+	from, args := m.systemTable("system.columns")
+	rows, err := m.query(fmt.Sprintf(m.columnsQuery(), from), append(args, tableName, database)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columnRows []clickhouseColumnRow
+	seen := map[string]bool{}
+	for rows.Next() {
+		var c clickhouseColumnRow
+		if err := rows.Scan(&c.name, &c.fullType, &c.defaultKind, &c.defaultValue, &c.comment, &c.position); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+		// Every replica of the cluster has its own row for the column
+		if seen[c.name] {
+			continue
+		}
+		seen[c.name] = true
+		columnRows = append(columnRows, c)
+	}
+
+	return columnRows, nil
+}
+
+// clickhouseColumnsByPosition sorts columns along with their positions.
+type clickhouseColumnsByPosition struct {
+	columns   []bdb.Column
+	positions []uint64
+}
+
+func (c clickhouseColumnsByPosition) Len() int { return len(c.columns) }
+func (c clickhouseColumnsByPosition) Less(i, j int) bool {
+	return c.positions[i] < c.positions[j]
+}
+func (c clickhouseColumnsByPosition) Swap(i, j int) {
+	c.columns[i], c.columns[j] = c.columns[j], c.columns[i]
+	c.positions[i], c.positions[j] = c.positions[j], c.positions[i]
+}
+
+// Before 18.1 system.columns had neither the comment column, nor the
+// default_kind one which was named default_type, nor the position one, the
+// columns are then listed in their physical order already.
+const (
+	clickhouseColumnsQuery = `
+	select name, type, default_kind, default_expression, comment, position
+		from %s
+	where table = ? and database = ?
+	order by position;
+	`
+	clickhouseLegacyColumnsQuery = `
+	select name, type, default_type, default_expression, '', 0
+		from %s
+	where table = ? and database = ?;
+	`
+)
+
+// columnsQuery returns the columns query the server understands
+func (m *ClickhouseDriver) columnsQuery() string {
+	if m.version.before(18, 1) {
+		return clickhouseLegacyColumnsQuery
+	}
+	return clickhouseColumnsQuery
+}
+
+// The bulk queries fetch the columns and the tables of several databases at
+// once, the total_rows column only exists since 20.4.
+const (
+	clickhouseBulkColumnsQuery = `
+	select database, table, name, type, default_kind, default_expression, comment, position
+		from %s
+	where database in (%s)
+	order by database, table, position;
+	`
+	clickhouseLegacyBulkColumnsQuery = `
+	select database, table, name, type, default_type, default_expression, '', 0
+		from %s
+	where database in (%s);
+	`
+	clickhouseBulkTablesQuery = `
+	select database, name, engine_full, total_rows, create_table_query
+		from %s
+	where database in (%s);
+	`
+	clickhouseLegacyBulkTablesQuery = `
+	select database, name, engine_full, null, create_table_query
+		from %s
+	where database in (%s);
+	`
+)
+
+// bulkColumnsQuery returns the bulk columns query the server understands
+func (m *ClickhouseDriver) bulkColumnsQuery() string {
+	if m.version.before(18, 1) {
+		return clickhouseLegacyBulkColumnsQuery
+	}
+	return clickhouseBulkColumnsQuery
+}
+
+// bulkTablesQuery returns the bulk tables query the server understands
+func (m *ClickhouseDriver) bulkTablesQuery() string {
+	if m.version.before(20, 4) {
+		return clickhouseLegacyBulkTablesQuery
+	}
+	return clickhouseBulkTablesQuery
+}
+
+// clickhouseSplitCodec splits a CODEC(...) suffix off a column type, like
+// Int64 CODEC(Delta, ZSTD). It returns the type and the codecs, which are
+// empty when the type doesn't declare any.
+func clickhouseSplitCodec(fullType string) (string, string) {
+	fullType = strings.TrimSpace(fullType)
+
+	depth := 0
+	for i := 0; i < len(fullType); i++ {
+		switch fullType[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ':
+			if depth != 0 {
+				continue
+			}
+			codec, ok := clickhouseUnwrapType(fullType[i+1:], "CODEC")
+			if ok {
+				return strings.TrimSpace(fullType[:i]), codec
+			}
+		}
+	}
+
+	return fullType, ""
+}
+
+// clickhouseDefault returns the default expression of a column, or an empty
+// string when it has none so the generator can tell them apart. The kind is
+// empty for columns without any default, whatever the expression says.
+func clickhouseDefault(kind, expression string) string {
+	if len(kind) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(expression)
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (m *ClickhouseDriver) PrimaryKeyInfo(database, table string) (*bdb.PrimaryKey, error) {
+	pkey := &bdb.PrimaryKey{}
+	var err error
+
+	database, table = m.table(database, table)
+
+	engineFull, err := m.engineFull(database, table)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	pkey.Name = table
+
+	// A Distributed table has the keys of the local table it spreads over
+	if clickhouseEngineName(engineFull) == "Distributed" {
+		dist, err := clickhouseParseDistributed(engineFull)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad engine=`%s`", engineFull)
+		}
+		if len(dist.Database) == 0 {
+			dist.Database = database
+		}
+
+		if engineFull, err = m.engineFull(dist.Database, dist.Table); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.Wrapf(ErrTableNotFound, "local table %s.%s of distributed table %s", dist.Database, dist.Table, table)
+			}
+			return nil, err
+		}
+	}
+
+	// Only the MergeTree family has keys, Log, Memory or Null tables don't
+	if !clickhouseIsMergeTree(clickhouseEngineName(engineFull)) {
+		return nil, nil
+	}
+
+	engine, err := m.parseEngine(engineFull)
+	if err != nil {
+		return nil, errors.Wrapf(err, "bad engine=`%s`", engineFull)
+	}
+
+	pkey.Columns = engine.PrimaryKey
+	pkey.PartitionKey = engine.PartitioningKey
+	pkey.SortingKey = engine.SortingKey
+
+	return pkey, nil
+}
+
+// parseEngine parses system.tables.engine_full of the MergeTree family of
+// engines. Both the legacy positional syntax, ex: MergeTree(date, (a, b), 8192)
+// and the clause syntax, ex: MergeTree PARTITION BY date ORDER BY (a, b)
+// are supported, the latter taking its granularity from the SETTINGS clause.
+// Replicated engines have their zookeeper path and replica name skipped.
+func (m *ClickhouseDriver) parseEngine(str string) (*clickhouseEngine, error) {
+	str = strings.TrimSpace(str)
+
+	engine := clickhouseEngine{}
+
+	engine.Name = clickhouseEngineName(str)
+	rest := str[len(engine.Name):]
+
+	var args []string
+	if strings.HasPrefix(rest, "(") {
+		end := clickhouseMatchParen(rest)
+		if end == -1 {
+			return nil, errors.Wrap(ErrEngineParse, "close bracket not found")
+		}
+
+		args = clickhouseSplitArgs(rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	if strings.HasPrefix(engine.Name, "Replicated") {
+		if len(args) < 2 {
+			return nil, errors.Wrap(ErrEngineParse, "replication path and replica name not found")
+		}
+		args = args[2:]
+	}
+
+	if clauses := clickhouseSplitClauses(rest); len(clauses) != 0 {
+		engine.PartitioningKey = clauses["PARTITION BY"]
+
+		orderBy, ok := clauses["ORDER BY"]
+		if !ok {
+			return nil, errors.Wrap(ErrEngineParse, "order by clause not found")
+		}
+
+		// The primary key defaults to the sorting key when not given
+		// explicitly, otherwise it has to be a prefix of it.
+		primaryKey, ok := clauses["PRIMARY KEY"]
+		if !ok {
+			primaryKey = orderBy
+		}
+		engine.PrimaryKey = clickhouseSplitKey(primaryKey)
+		engine.SortingKey = clickhouseSplitKey(orderBy)
+
+		granularity, err := clickhouseSettingsGranularity(clauses["SETTINGS"])
+		if err != nil {
+			return nil, withCause(err, ErrEngineParse)
+		}
+		engine.Granularity = granularity
+
+		return &engine, nil
+	}
+
+	// Legacy syntax: (date, [sampling,] primary key, granularity, engine params...)
+	if len(args) == 0 {
+		return nil, errors.Wrap(ErrEngineParse, "partitioning key not found")
+	}
+	engine.PartitioningKey = args[0]
+
+	granularityIdx := -1
+	for i := 2; i < len(args) && i <= 3; i++ {
+		if _, err := strconv.Atoi(args[i]); err == nil {
+			granularityIdx = i
+			break
+		}
+	}
+	if granularityIdx == -1 {
+		return nil, errors.Wrap(ErrEngineParse, "granularity key not found")
+	}
+
+	engine.Granularity, _ = strconv.Atoi(args[granularityIdx])
+	engine.PrimaryKey = clickhouseSplitKey(args[granularityIdx-1])
+	engine.SortingKey = engine.PrimaryKey
+	if granularityIdx == 3 {
+		engine.SamplingKey = args[1]
+	}
+
+	return &engine, nil
+}
+
+// clickhouseEngineName returns the name of the engine of engine_full, ex:
+// ReplicatedMergeTree for ReplicatedMergeTree('/path', '{replica}') ORDER BY id
+func clickhouseEngineName(engineFull string) string {
+	engineFull = strings.TrimSpace(engineFull)
+
+	idx := strings.IndexAny(engineFull, "( ")
+	if idx == -1 {
+		return engineFull
+	}
+	return engineFull[:idx]
+}
+
+// clickhouseIsMergeTree checks whether the engine is one of the MergeTree
+// family, ex: MergeTree, ReplacingMergeTree or ReplicatedSummingMergeTree,
+// the only engines with a primary key.
+func clickhouseIsMergeTree(name string) bool {
+	return strings.HasSuffix(name, "MergeTree")
+}
+
+// clickhouseDistributed is the local table a Distributed table spreads over
+// the shards of a cluster, Database is empty for currentDatabase().
+type clickhouseDistributed struct {
+	Cluster     string
+	Database    string
+	Table       string
+	ShardingKey string
+}
+
+// clickhouseParseDistributed parses the arguments of the Distributed engine,
+// Distributed(cluster, database, table[, sharding_key[, policy_name]]), which
+// are either quoted or plain identifiers. A SETTINGS clause may follow.
+func clickhouseParseDistributed(engineFull string) (*clickhouseDistributed, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(engineFull), "Distributed")
+	end := clickhouseMatchParen(rest)
+	if !strings.HasPrefix(rest, "(") || end == -1 {
+		return nil, errors.Wrap(ErrEngineParse, "distributed arguments not found")
+	}
+
+	args := clickhouseSplitArgs(rest[1:end])
+	if len(args) < 3 {
+		return nil, errors.Wrap(ErrEngineParse, "cluster, database and table not found")
+	}
+
+	unquote := func(arg string) string {
+		return strings.Trim(strings.TrimSpace(arg), "'`\"")
+	}
+
+	dist := &clickhouseDistributed{
+		Cluster:  unquote(args[0]),
+		Database: unquote(args[1]),
+		Table:    unquote(args[2]),
+	}
+	if dist.Database == "currentDatabase()" {
+		dist.Database = ""
+	}
+	if len(args) > 3 {
+		dist.ShardingKey = strings.TrimSpace(args[3])
+	}
+
+	return dist, nil
+}
+
+// clickhouseDefaultGranularity is the index_granularity of MergeTree tables
+// that don't set it.
+const clickhouseDefaultGranularity = 8192
+
+// clickhouseSettingsGranularity reads index_granularity out of the SETTINGS
+// clause of an engine, ex: index_granularity = 8192, storage_policy = 'ssd'
+func clickhouseSettingsGranularity(settings string) (int, error) {
+	for _, setting := range clickhouseSplitArgs(settings) {
+		eq := strings.IndexByte(setting, '=')
+		if eq == -1 || strings.TrimSpace(setting[:eq]) != "index_granularity" {
+			continue
+		}
+
+		granularity, err := strconv.Atoi(strings.TrimSpace(setting[eq+1:]))
+		if err != nil {
+			return 0, errors.Wrapf(err, "bad index_granularity setting `%s`", setting)
+		}
+		return granularity, nil
+	}
+
+	return clickhouseDefaultGranularity, nil
+}
+
+type clickhouseEngine struct {
+	Name            string
+	PartitioningKey string
+	SamplingKey     string
+	PrimaryKey      []string
+	SortingKey      []string
+	Granularity     int
+}
+
+// clickhouseEngineClauses are the clauses that may follow the engine name
+// and arguments in engine_full.
+var clickhouseEngineClauses = []string{
+	"PARTITION BY",
+	"ORDER BY",
+	"PRIMARY KEY",
+	"SAMPLE BY",
+	"TTL",
+	"SETTINGS",
+}
+
+// clickhouseSplitClauses splits the clauses of an engine definition into
+// a map of clause keyword to expression.
+func clickhouseSplitClauses(str string) map[string]string {
+	clauses := map[string]string{}
+
+	var current string
+	var start, depth int
+	var quoted bool
+	for i := 0; i < len(str); i++ {
+		switch ch := str[i]; {
+		case quoted:
+			if ch == '\\' {
+				i++
+			} else if ch == '\'' {
+				quoted = false
+			}
+		case ch == '\'':
+			quoted = true
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case depth == 0 && (i == 0 || str[i-1] == ' '):
+			for _, keyword := range clickhouseEngineClauses {
+				if !strings.HasPrefix(str[i:], keyword+" ") {
+					continue
+				}
+
+				if len(current) != 0 {
+					clauses[current] = strings.TrimSpace(str[start:i])
+				}
+				current, start = keyword, i+len(keyword)
+				i += len(keyword) - 1
+				break
+			}
+		}
+	}
+
+	if len(current) != 0 {
+		clauses[current] = strings.TrimSpace(str[start:])
+	}
+
+	return clauses
+}
+
+// clickhouseMatchParen returns the index of the paren closing the one
+// that str starts with, or -1 if it's never closed.
+func clickhouseMatchParen(str string) int {
+	var depth int
+	var quoted bool
+	for i := 0; i < len(str); i++ {
+		switch ch := str[i]; {
+		case quoted:
+			if ch == '\\' {
+				i++
+			} else if ch == '\'' {
+				quoted = false
+			}
+		case ch == '\'':
+			quoted = true
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// clickhouseSplitArgs splits a comma separated argument list, ignoring the
+// commas nested in parens or quotes.
+func clickhouseSplitArgs(str string) []string {
+	var args []string
+
+	var start, depth int
+	var quoted bool
+	for i := 0; i < len(str); i++ {
+		switch ch := str[i]; {
+		case quoted:
+			if ch == '\\' {
+				i++
+			} else if ch == '\'' {
+				quoted = false
+			}
+		case ch == '\'':
+			quoted = true
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(str[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(str[start:]); len(last) != 0 || len(args) != 0 {
+		args = append(args, last)
+	}
+
+	return args
+}
+
+// clickhouseSplitKey splits a key expression into its columns, the key can
+// be a single column or a tuple: (a, b)
+func clickhouseSplitKey(key string) []string {
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, "(") && clickhouseMatchParen(key) == len(key)-1 {
+		key = key[1 : len(key)-1]
+	}
+
+	return clickhouseSplitArgs(key)
 }
 
 // ForeignKeyInfo retrieves the foreign keys for a given table name.
+// Clickhouse has no foreign key constraints, so they're only returned
+// when a relations file was given in the config.
+//
+// The tables of the relations are either qualified by their database, ex:
+// analytics.events, or in the database of the table using them. They're
+// returned under the names TableNames gave them.
 func (m *ClickhouseDriver) ForeignKeyInfo(schema, table string) ([]bdb.ForeignKey, error) {
-	return nil, nil
+	database, name := m.table(schema, table)
+
+	var fkeys []bdb.ForeignKey
+	for _, relation := range [][]bdb.ForeignKey{m.relations[database+"."+name], m.relations[name]} {
+		for _, fkey := range relation {
+			foreignDatabase, foreignName := database, fkey.ForeignTable
+			if dot := strings.IndexByte(foreignName, '.'); dot >= 0 {
+				foreignDatabase, foreignName = foreignName[:dot], foreignName[dot+1:]
+			}
+
+			fkey.Table = m.tableName(database, name)
+			fkey.ForeignTable = m.tableName(foreignDatabase, foreignName)
+			fkeys = append(fkeys, fkey)
+		}
+	}
+
+	return fkeys, nil
+}
+
+// tableName is the reverse of table, it returns the name TableNames gives
+// the table of the database.
+func (m *ClickhouseDriver) tableName(database, name string) string {
+	if len(m.databases) != 0 {
+		return database + "_" + name
+	}
+
+	return name
+}
+
+// clickhouseLoadRelations reads a relations file mapping "table.column" to
+// "foreign_table.foreign_column" and returns the foreign keys by table name.
+// The tables may be qualified by their database, ex: analytics.events.user_id.
+// The format is chosen by the file extension, either .json or .toml.
+func clickhouseLoadRelations(path string) (map[string][]bdb.ForeignKey, error) {
+	raw := map[string]string{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(b, &raw); err != nil {
+			return nil, err
+		}
+	case ".toml":
+		if _, err := toml.DecodeFile(path, &raw); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("unsupported relations file format: %s", path)
+	}
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	relations := map[string][]bdb.ForeignKey{}
+	for _, local := range keys {
+		foreign := raw[local]
+
+		localDot := strings.LastIndexByte(local, '.')
+		foreignDot := strings.LastIndexByte(foreign, '.')
+		if !clickhouseIsRelationColumn(local) || !clickhouseIsRelationColumn(foreign) {
+			return nil, errors.Errorf("relation must be in the form table.column: %s = %s", local, foreign)
+		}
+
+		table := local[:localDot]
+		fkey := bdb.ForeignKey{
+			Table:         table,
+			Name:          fmt.Sprintf("%s_%s_fkey", strings.Replace(table, ".", "_", -1), local[localDot+1:]),
+			Column:        local[localDot+1:],
+			ForeignTable:  foreign[:foreignDot],
+			ForeignColumn: foreign[foreignDot+1:],
+		}
+
+		relations[fkey.Table] = append(relations[fkey.Table], fkey)
+	}
+
+	return relations, nil
+}
+
+// clickhouseIsRelationColumn checks that a relation names a column in the form
+// table.column or database.table.column
+func clickhouseIsRelationColumn(column string) bool {
+	parts := strings.Split(column, ".")
+	if len(parts) != 2 && len(parts) != 3 {
+		return false
+	}
+	for _, part := range parts {
+		if len(part) == 0 {
+			return false
+		}
+	}
+
+	return true
 }
 
 // TranslateColumnType converts clickhouse database types to Go types, for example
-// "String" to "string" and "Int64" to "int64". It returns this parsed data
-// as a Column object.
+// "String" to "string" and "Int64" to "int64". Types wrapped in Nullable(...)
+// mark the column as nullable and translate to their null package counterparts.
+// It returns this parsed data as a Column object.
 func (m *ClickhouseDriver) TranslateColumnType(c bdb.Column) bdb.Column {
-	switch c.DBType {
+	fullType := c.FullDBType
+	if len(fullType) == 0 {
+		fullType = c.DBType
+	}
+
+	// A SimpleAggregateFunction is stored as its value type, while an
+	// AggregateFunction holds an opaque state that has to be finalized
+	// (ex: with finalizeAggregation or the -Merge combinator) to be read
+	if valueType, ok := clickhouseSimpleAggregateType(fullType); ok {
+		fullType = valueType
+	}
+	if _, ok := clickhouseUnwrapType(fullType, "AggregateFunction"); ok {
+		c.AggregateState = true
+	}
+
+	// LowCardinality is only a storage encoding, the column behaves as
+	// its inner type
+	if lowCardinality, ok := clickhouseUnwrapType(fullType, "LowCardinality"); ok {
+		fullType = lowCardinality
+	}
+
+	inner, nullable := clickhouseUnwrapType(fullType, "Nullable")
+	c.Nullable = nullable
+	goType, known := m.translateType(fullType)
+	c.Type, c.UnknownType = goType, !known
+
+	// The driver scans arrays as slices, those it has Scan and Value
+	// implementations for get them
+	if arrayType, ok := clickhouseArrayTypes[c.Type]; ok {
+		c.Type = arrayType
+	}
+
+	// A UInt8 column can be turned into a bool on its own with a directive
+	// in its comment, regardless of the global flag
+	if inner == "UInt8" && strings.Contains(c.Comment, clickhouseBoolDirective) {
+		c.Type = "bool"
+		if nullable {
+			c.Type = "null.Bool"
+		}
+	}
+
+	// Nothing columns, from NULL literals in views, can't hold a value
+	// so they're never inserted or updated
+	if inner == "Nothing" {
+		c.AutoGenerated = true
+	}
+
+	if strings.HasPrefix(inner, "Enum") {
+		// A malformed definition just means no constants will be generated
+		c.EnumValues, _ = clickhouseParseEnum(inner)
+	}
+	if strings.HasPrefix(inner, "FixedString") {
+		c.FixedStringLength, _ = clickhouseParseFixedString(inner)
+	}
+	if strings.HasPrefix(inner, "Decimal") {
+		c.DecimalPrecision, c.DecimalScale, _ = clickhouseParseDecimal(inner)
+	}
+	if strings.HasPrefix(inner, "DateTime") {
+		c.DateTimePrecision, c.Timezone, _ = clickhouseParseDateTime(inner)
+	}
+	if strings.HasPrefix(inner, "Tuple") || strings.HasPrefix(inner, "Nested") {
+		c.TupleElements, _ = m.parseTuple(inner)
+	}
+
+	return c
+}
+
+// clickhouseBoolDirective marks a UInt8 column as a bool in its comment.
+const clickhouseBoolDirective = "@sqlboiler:bool"
+
+// clickhouseNullTypes maps the Go types of non-nullable columns to the
+// types used for their Nullable(...) counterparts.
+var clickhouseNullTypes = map[string]string{
+	"bool":      "null.Bool",
+	"uint8":     "null.Uint8",
+	"uint16":    "null.Uint16",
+	"uint32":    "null.Uint32",
+	"uint64":    "null.Uint64",
+	"int8":      "null.Int8",
+	"int16":     "null.Int16",
+	"int32":     "null.Int32",
+	"int64":     "null.Int64",
+	"float32":   "null.Float32",
+	"float64":   "null.Float64",
+	"time.Time": "null.Time",
+	"string":    "null.String",
+	"[]byte":    "null.Bytes",
+
+	"types.FixedString": "types.NullFixedString",
+	"types.UUID":        "types.NullUUID",
+	"types.IP":          "types.NullIP",
+	"types.BigInt":      "types.NullBigInt",
+	"types.Decimal":     "types.NullDecimal",
+}
+
+// clickhouseArrayTypes maps the Go types of array columns to the types
+// implementing sql.Scanner and driver.Valuer for them.
+var clickhouseArrayTypes = map[string]string{
+	"[]string":  "types.ClickhouseStringArray",
+	"[]int64":   "types.ClickhouseInt64Array",
+	"[]float64": "types.ClickhouseFloat64Array",
+}
+
+// goType converts a clickhouse type to a Go type, recursing into
+// Nullable(T), Array(T), LowCardinality(T), SimpleAggregateFunction(f, T),
+// Nested(...) and Map(K, V) so that Array(Array(Int8)) becomes [][]int8.
+// AggregateFunction states are left as []byte.
+func (m *ClickhouseDriver) goType(fullType string) string {
+	goType, _ := m.translateType(fullType)
+	return goType
+}
+
+// translateType is goType reporting whether every type of fullType is
+// known, the unknown ones are given the Go type set by UnknownType.
+func (m *ClickhouseDriver) translateType(fullType string) (string, bool) {
+	if inner, ok := clickhouseUnwrapType(fullType, "Nullable"); ok {
+		goType, known := m.translateType(inner)
+		if nullType, ok := clickhouseNullTypes[goType]; ok {
+			return nullType, known
+		}
+		return goType, known
+	}
+
+	if inner, ok := clickhouseUnwrapType(fullType, "Array"); ok {
+		goType, known := m.translateType(inner)
+		return "[]" + goType, known
+	}
+
+	if valueType, ok := clickhouseSimpleAggregateType(fullType); ok {
+		return m.translateType(valueType)
+	}
+
+	if inner, ok := clickhouseUnwrapType(fullType, "LowCardinality"); ok {
+		return m.translateType(inner)
+	}
+
+	// The elements of tuples are scanned as a slice by the driver, their
+	// types are kept on the column by TranslateColumnType
+	if _, ok := clickhouseUnwrapType(fullType, "Tuple"); ok {
+		return "[]interface{}", true
+	}
+
+	// A Nested column not flattened by the server is an array of named
+	// tuples, its fields are kept on the column like the tuple elements
+	if _, ok := clickhouseUnwrapType(fullType, "Nested"); ok {
+		return "[][]interface{}", true
+	}
+
+	if inner, ok := clickhouseUnwrapType(fullType, "Map"); ok {
+		// Slices can't be map keys, such maps are left as raw bytes
+		if args := clickhouseSplitArgs(inner); len(args) == 2 {
+			key, keyKnown := m.translateType(args[0])
+			if !strings.HasPrefix(key, "[]") {
+				value, valueKnown := m.translateType(args[1])
+				return fmt.Sprintf("map[%s]%s", key, value), keyKnown && valueKnown
+			}
+		}
+		return "[]byte", true
+	}
+
+	// The opaque state of an AggregateFunction is read as raw bytes on
+	// purpose, whatever the fallback of the unknown types
+	if _, ok := clickhouseUnwrapType(fullType, "AggregateFunction"); ok {
+		return "[]byte", true
+	}
+
+	dbType := strings.TrimSpace(fullType)
+	if idx := strings.IndexByte(dbType, '('); idx > 0 {
+		dbType = strings.TrimSpace(dbType[:idx])
+	}
+
+	switch dbType {
+	case "Bool", "Boolean":
+		return "bool", true
 	case "UInt8":
 		if TinyintAsBool {
-			c.Type = "bool"
-		} else {
-			c.Type = "uint8"
+			return "bool", true
 		}
+		return "uint8", true
 	case "UInt16":
-		c.Type = "uint16"
+		return "uint16", true
 	case "UInt32":
-		c.Type = "uint32"
+		return "uint32", true
 	case "UInt64":
-		c.Type = "uint64"
+		return "uint64", true
 	case "Int8":
-		c.Type = "int8"
+		return "int8", true
 	case "Int16":
-		c.Type = "int16"
+		return "int16", true
 	case "Int32":
-		c.Type = "int32"
+		return "int32", true
 	case "Int64":
-		c.Type = "int64"
+		return "int64", true
+	case "Int128", "UInt128", "Int256", "UInt256":
+		return "types.BigInt", true
 	case "Float32":
-		c.Type = "float32"
+		return "float32", true
 	case "Float64":
-		c.Type = "float64"
-	case "Date", "DateTime":
-		c.Type = "time.Time"
+		return "float64", true
+	case "Date", "Date32", "DateTime", "DateTime64":
+		return "time.Time", true
 	case "FixedString":
-		c.Type = "types.FixedString"
+		return "types.FixedString", true
 	case "String":
-		c.Type = "string"
+		return "string", true
+	case "UUID":
+		return "types.UUID", true
+	case "IPv4", "IPv6":
+		return "types.IP", true
+	case "JSON":
+		return "types.JSON", true
+	case "Object":
+		if clickhouseIsObjectJSON(fullType) {
+			return "types.JSON", true
+		}
+		return "[]byte", true
+	case "Decimal", "Decimal32", "Decimal64", "Decimal128", "Decimal256":
+		return "types.Decimal", true
+	// Geo types are built from points, each level of nesting being an
+	// array of the previous one
+	case "Point":
+		return "types.Point", true
+	case "Ring", "LineString":
+		return "types.Ring", true
+	case "Polygon", "MultiLineString":
+		return "types.Polygon", true
+	case "MultiPolygon":
+		return "types.MultiPolygon", true
+	// Nothing is the type of NULL literals, its values are always nil
+	case "Nothing":
+		return "interface{}", true
+	case "Enum8":
+		if m.enumAsInt {
+			return "int8", true
+		}
+		return "string", true
+	case "Enum16":
+		if m.enumAsInt {
+			return "int16", true
+		}
+		return "string", true
 	default:
-		c.Type = "[]byte"
+		return m.unknownGoType(), false
 	}
+}
 
-	return c
+// clickhouseUnknownTypeError is the UnknownType failing the introspection
+// of the columns of unknown types
+const clickhouseUnknownTypeError = "error"
+
+// clickhouseValidUnknownType reports whether t is a valid UnknownType
+func clickhouseValidUnknownType(t string) bool {
+	switch t {
+	case "", "[]byte", "string", "interface{}", clickhouseUnknownTypeError:
+		return true
+	}
+	return false
+}
+
+// unknownGoType returns the Go type of the database types the driver
+// doesn't know
+func (m *ClickhouseDriver) unknownGoType() string {
+	if len(m.unknownType) == 0 || m.unknownType == clickhouseUnknownTypeError {
+		return "[]byte"
+	}
+	return m.unknownType
+}
+
+// clickhouseDecimalPrecisions are the precisions implied by the sized
+// decimal types, which only take the scale as an argument.
+var clickhouseDecimalPrecisions = map[string]int{
+	"Decimal32":  9,
+	"Decimal64":  18,
+	"Decimal128": 38,
+	"Decimal256": 76,
+}
+
+// clickhouseParseDecimal returns the precision and scale of either the
+// Decimal(P, S) or the sized DecimalN(S) forms.
+func clickhouseParseDecimal(fullType string) (precision, scale int, err error) {
+	idx := strings.IndexByte(fullType, '(')
+	if idx < 0 || !strings.HasSuffix(fullType, ")") {
+		return 0, 0, errors.Errorf("decimal arguments not found: %s", fullType)
+	}
+
+	name := strings.TrimSpace(fullType[:idx])
+	args := strings.Split(fullType[idx+1:len(fullType)-1], ",")
+
+	if name == "Decimal" {
+		if len(args) != 2 {
+			return 0, 0, errors.Errorf("decimal requires precision and scale: %s", fullType)
+		}
+		if precision, err = strconv.Atoi(strings.TrimSpace(args[0])); err != nil {
+			return 0, 0, errors.Wrapf(err, "bad decimal precision: %s", fullType)
+		}
+		if scale, err = strconv.Atoi(strings.TrimSpace(args[1])); err != nil {
+			return 0, 0, errors.Wrapf(err, "bad decimal scale: %s", fullType)
+		}
+		return precision, scale, nil
+	}
+
+	precision, ok := clickhouseDecimalPrecisions[name]
+	if !ok {
+		return 0, 0, errors.Errorf("unknown decimal type: %s", fullType)
+	}
+	if len(args) != 1 {
+		return 0, 0, errors.Errorf("%s requires a scale: %s", name, fullType)
+	}
+	if scale, err = strconv.Atoi(strings.TrimSpace(args[0])); err != nil {
+		return 0, 0, errors.Wrapf(err, "bad decimal scale: %s", fullType)
+	}
+
+	return precision, scale, nil
+}
+
+// clickhouseParseFixedString returns the length N of a FixedString(N) type.
+func clickhouseParseFixedString(fullType string) (int, error) {
+	def, ok := clickhouseUnwrapType(fullType, "FixedString")
+	if !ok {
+		return 0, errors.Errorf("fixed string length not found: %s", fullType)
+	}
+
+	length, err := strconv.Atoi(def)
+	if err != nil {
+		return 0, errors.Wrapf(err, "bad fixed string length: %s", fullType)
+	}
+	if length <= 0 {
+		return 0, errors.Errorf("fixed string length must be positive: %s", fullType)
+	}
+
+	return length, nil
+}
+
+// clickhouseParseDateTime returns the precision and timezone of either the
+// DateTime([timezone]) or the DateTime64(P, [timezone]) forms.
+func clickhouseParseDateTime(fullType string) (precision int, timezone string, err error) {
+	name := fullType
+	var args []string
+	if idx := strings.IndexByte(fullType, '('); idx >= 0 {
+		if !strings.HasSuffix(fullType, ")") {
+			return 0, "", errors.Errorf("close bracket not found: %s", fullType)
+		}
+		name = strings.TrimSpace(fullType[:idx])
+		args = clickhouseSplitArgs(fullType[idx+1 : len(fullType)-1])
+	}
+
+	switch name {
+	case "DateTime":
+	case "DateTime64":
+		if len(args) == 0 {
+			return 0, "", errors.Errorf("datetime64 requires a precision: %s", fullType)
+		}
+		if precision, err = strconv.Atoi(args[0]); err != nil {
+			return 0, "", errors.Wrapf(err, "bad datetime64 precision: %s", fullType)
+		}
+		args = args[1:]
+	default:
+		return 0, "", errors.Errorf("unknown datetime type: %s", fullType)
+	}
+
+	if len(args) > 1 {
+		return 0, "", errors.Errorf("too many datetime arguments: %s", fullType)
+	}
+	if len(args) == 1 {
+		timezone = strings.Trim(args[0], "'")
+	}
+
+	return precision, timezone, nil
+}
+
+// parseTuple returns the elements of a tuple definition, either unnamed like
+// Tuple(String, UInt8) or named like Tuple(a String, b UInt8). The fields of
+// a Nested(a String, b UInt8) definition are parsed the same way.
+func (m *ClickhouseDriver) parseTuple(fullType string) ([]bdb.TupleElement, error) {
+	def, ok := clickhouseUnwrapType(fullType, "Tuple")
+	if !ok {
+		if def, ok = clickhouseUnwrapType(fullType, "Nested"); !ok {
+			return nil, errors.Errorf("tuple elements not found: %s", fullType)
+		}
+	}
+
+	args := clickhouseSplitArgs(def)
+	if len(args) == 0 {
+		return nil, errors.Errorf("empty tuple: %s", fullType)
+	}
+
+	elements := make([]bdb.TupleElement, len(args))
+	for i, arg := range args {
+		// A name is either quoted or followed by a space before any paren
+		// of the type, unlike the arguments of types such as Decimal(18, 4)
+		if strings.HasPrefix(arg, "`") {
+			end := strings.IndexByte(arg[1:], '`')
+			if end < 0 {
+				return nil, errors.Errorf("unterminated tuple element name: %s", fullType)
+			}
+			elements[i].Name = arg[1 : end+1]
+			arg = strings.TrimSpace(arg[end+2:])
+		} else if idx := strings.IndexByte(arg, ' '); idx > 0 && !strings.ContainsAny(arg[:idx], "(") {
+			elements[i].Name = arg[:idx]
+			arg = strings.TrimSpace(arg[idx+1:])
+		}
+
+		elements[i].DBType = arg
+		elements[i].Type = m.goType(arg)
+	}
+
+	return elements, nil
+}
+
+// clickhouseParseEnum parses the labels and values out of an enum definition
+// like: Enum8('a' = 1, 'b' = -2). Labels may contain backslash escapes.
+func clickhouseParseEnum(fullType string) ([]bdb.EnumValue, error) {
+	def, ok := clickhouseUnwrapType(fullType, "Enum8")
+	if !ok {
+		if def, ok = clickhouseUnwrapType(fullType, "Enum16"); !ok {
+			return nil, errors.Errorf("not an enum type: %s", fullType)
+		}
+	}
+
+	var values []bdb.EnumValue
+	for len(def) != 0 {
+		if def[0] != '\'' {
+			return nil, errors.Errorf("expected enum label to be quoted: %s", def)
+		}
+
+		label := &bytes.Buffer{}
+		i, closed := 1, false
+		for ; i < len(def); i++ {
+			if def[i] == '\\' && i+1 < len(def) {
+				i++
+			} else if def[i] == '\'' {
+				closed = true
+				break
+			}
+			label.WriteByte(def[i])
+		}
+		if !closed {
+			return nil, errors.Errorf("unterminated enum label: %s", def)
+		}
+
+		def = strings.TrimSpace(def[i+1:])
+		if !strings.HasPrefix(def, "=") {
+			return nil, errors.Errorf("expected enum value for label %q", label.String())
+		}
+		def = strings.TrimSpace(def[1:])
+
+		end := strings.IndexByte(def, ',')
+		if end < 0 {
+			end = len(def)
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(def[:end]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad enum value for label %q", label.String())
+		}
+
+		values = append(values, bdb.EnumValue{Label: label.String(), Value: value})
+		def = strings.TrimSpace(strings.TrimPrefix(def[end:], ","))
+	}
+
+	return values, nil
+}
+
+// clickhouseUnwrapType returns the inner type of a wrapper type such as
+// Nullable(T), and whether fullType was wrapped by the wrapper at all.
+func clickhouseUnwrapType(fullType, wrapper string) (string, bool) {
+	fullType = strings.TrimSpace(fullType)
+	if !strings.HasPrefix(fullType, wrapper+"(") || !strings.HasSuffix(fullType, ")") {
+		return fullType, false
+	}
+
+	return strings.TrimSpace(fullType[len(wrapper)+1 : len(fullType)-1]), true
+}
+
+// clickhouseIsObjectJSON reports whether fullType is the Object('json') form
+// of the JSON type, its argument being quoted either way or not at all.
+func clickhouseIsObjectJSON(fullType string) bool {
+	arg, ok := clickhouseUnwrapType(fullType, "Object")
+	if !ok {
+		return false
+	}
+
+	if len(arg) >= 2 && strings.ContainsRune("'\"`", rune(arg[0])) && arg[len(arg)-1] == arg[0] {
+		arg = strings.TrimSpace(arg[1 : len(arg)-1])
+	}
+
+	return strings.EqualFold(arg, "json")
+}
+
+// clickhouseSimpleAggregateType returns the value type T of a
+// SimpleAggregateFunction(f, T) type.
+func clickhouseSimpleAggregateType(fullType string) (string, bool) {
+	inner, ok := clickhouseUnwrapType(fullType, "SimpleAggregateFunction")
+	if !ok {
+		return fullType, false
+	}
+
+	args := clickhouseSplitArgs(inner)
+	if len(args) != 2 {
+		return fullType, false
+	}
+
+	return args[1], true
 }
 
 // RightQuote is the quoting character for the right side of the identifier
@@ -0,0 +1,2832 @@
+package drivers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestClickhouseForeignKeyInfo(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range []string{"_fixtures/clickhouse_relations.json", "_fixtures/clickhouse_relations.toml"} {
+		relations, err := clickhouseLoadRelations(file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		m := &ClickhouseDriver{relations: relations}
+
+		fkeys, err := m.ForeignKeyInfo("db", "transfers")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expect := []bdb.ForeignKey{
+			{Table: "transfers", Name: "transfers_from_user_id_fkey", Column: "from_user_id", ForeignTable: "users", ForeignColumn: "id"},
+			{Table: "transfers", Name: "transfers_to_user_id_fkey", Column: "to_user_id", ForeignTable: "users", ForeignColumn: "id"},
+		}
+		if !reflect.DeepEqual(fkeys, expect) {
+			t.Errorf("%s: want:\n%#v\ngot:\n%#v", file, expect, fkeys)
+		}
+
+		fkeys, err = m.ForeignKeyInfo("db", "videos")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fkeys) != 1 || fkeys[0].Column != "user_id" || fkeys[0].ForeignTable != "users" {
+			t.Errorf("%s: wrong videos fkeys: %#v", file, fkeys)
+		}
+
+		fkeys, err = m.ForeignKeyInfo("db", "users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fkeys != nil {
+			t.Errorf("%s: expected no fkeys for users, got: %#v", file, fkeys)
+		}
+	}
+}
+
+func TestClickhouseForeignKeyInfoNoRelations(t *testing.T) {
+	t.Parallel()
+
+	m := &ClickhouseDriver{}
+
+	fkeys, err := m.ForeignKeyInfo("db", "transfers")
+	if err != nil {
+		t.Error(err)
+	}
+	if fkeys != nil {
+		t.Errorf("expected nil fkeys, got: %#v", fkeys)
+	}
+}
+
+func TestClickhouseForeignKeyInfoDatabases(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "relations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "relations.json")
+	relationsJSON := `{
+		"analytics.events.user_id": "default.users.id",
+		"events.session_id": "sessions.id"
+	}`
+	if err := ioutil.WriteFile(file, []byte(relationsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	relations, err := clickhouseLoadRelations(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &ClickhouseDriver{
+		relations: relations,
+		databases: []string{"analytics", "default"},
+		tables: map[string]clickhouseTable{
+			"analytics_events": {database: "analytics", name: "events"},
+			"default_events":   {database: "default", name: "events"},
+		},
+	}
+
+	fkeys, err := m.ForeignKeyInfo("default", "analytics_events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []bdb.ForeignKey{
+		{Table: "analytics_events", Name: "analytics_events_user_id_fkey", Column: "user_id", ForeignTable: "default_users", ForeignColumn: "id"},
+		{Table: "analytics_events", Name: "events_session_id_fkey", Column: "session_id", ForeignTable: "analytics_sessions", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fkeys, expect) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", expect, fkeys)
+	}
+
+	// The unqualified relation applies to the events of every database
+	fkeys, err = m.ForeignKeyInfo("default", "default_events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fkeys) != 1 || fkeys[0].Table != "default_events" || fkeys[0].ForeignTable != "default_sessions" {
+		t.Errorf("wrong default_events fkeys: %#v", fkeys)
+	}
+
+	for _, bad := range []string{`{"events": "users.id"}`, `{"events.user_id": "a.b.c.d"}`, `{".user_id": "users.id"}`} {
+		if err := ioutil.WriteFile(file, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := clickhouseLoadRelations(file); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestClickhouseLoadRelationsBadFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, err := clickhouseLoadRelations("relations.yaml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+func TestClickhouseTranslateColumnType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+	}{
+		{"UInt8", "uint8", false},
+		{"UInt16", "uint16", false},
+		{"UInt32", "uint32", false},
+		{"UInt64", "uint64", false},
+		{"Int8", "int8", false},
+		{"Int16", "int16", false},
+		{"Int32", "int32", false},
+		{"Int64", "int64", false},
+		{"Float32", "float32", false},
+		{"Float64", "float64", false},
+		{"Date", "time.Time", false},
+		{"DateTime", "time.Time", false},
+		{"FixedString(16)", "types.FixedString", false},
+		{"String", "string", false},
+		{"UUID", "types.UUID", false},
+		{"IPv4", "types.IP", false},
+		{"IPv6", "types.IP", false},
+		{"Unknown", "[]byte", false},
+
+		{"Nullable(UInt8)", "null.Uint8", true},
+		{"Nullable(UInt16)", "null.Uint16", true},
+		{"Nullable(UInt32)", "null.Uint32", true},
+		{"Nullable(UInt64)", "null.Uint64", true},
+		{"Nullable(Int8)", "null.Int8", true},
+		{"Nullable(Int16)", "null.Int16", true},
+		{"Nullable(Int32)", "null.Int32", true},
+		{"Nullable(Int64)", "null.Int64", true},
+		{"Nullable(Float32)", "null.Float32", true},
+		{"Nullable(Float64)", "null.Float64", true},
+		{"Nullable(Date)", "null.Time", true},
+		{"Nullable(DateTime)", "null.Time", true},
+		{"Nullable(FixedString(16))", "types.NullFixedString", true},
+		{"Nullable(String)", "null.String", true},
+		{"Nullable( String )", "null.String", true},
+		{"Nullable(UUID)", "types.NullUUID", true},
+		{"Nullable(IPv4)", "types.NullIP", true},
+		{"Nullable(IPv6)", "types.NullIP", true},
+		{"Nullable(Unknown)", "null.Bytes", true},
+
+		{"Array(String)", "types.ClickhouseStringArray", false},
+		{"Array(Int64)", "types.ClickhouseInt64Array", false},
+		{"Array(Float64)", "types.ClickhouseFloat64Array", false},
+		{"Array(Array(String))", "[][]string", false},
+		{"Array(UInt32)", "[]uint32", false},
+		{"Array(DateTime)", "[]time.Time", false},
+		{"Array(FixedString(4))", "[]types.FixedString", false},
+		{"Array(Array(Int8))", "[][]int8", false},
+		{"Array(Nullable(String))", "[]null.String", false},
+		{"Array(Array(Nullable(Int64)))", "[][]null.Int64", false},
+		{"Array( Nullable( Float64 ) )", "[]null.Float64", false},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		c := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+
+		if c.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, c.Type)
+		}
+		if c.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, c.Nullable)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeEnum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		EnumAsInt  bool
+		Type       string
+	}{
+		{"Enum8('a' = 1, 'b' = 2)", false, "string"},
+		{"Enum16('a' = 1, 'b' = 2)", false, "string"},
+		{"Nullable(Enum8('a' = 1, 'b' = 2))", false, "null.String"},
+		{"Enum8('a' = 1, 'b' = 2)", true, "int8"},
+		{"Enum16('a' = 1, 'b' = 2)", true, "int16"},
+		{"Nullable(Enum16('a' = 1, 'b' = 2))", true, "null.Int16"},
+	}
+
+	for i, test := range tests {
+		m := &ClickhouseDriver{enumAsInt: test.EnumAsInt}
+		c := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+
+		if c.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, c.Type)
+		}
+
+		expect := []bdb.EnumValue{{Label: "a", Value: 1}, {Label: "b", Value: 2}}
+		if !reflect.DeepEqual(c.EnumValues, expect) {
+			t.Errorf("%d) %s: wrong enum values: %#v", i, test.FullDBType, c.EnumValues)
+		}
+	}
+}
+
+func TestClickhouseParseEnum(t *testing.T) {
+	t.Parallel()
+
+	values, err := clickhouseParseEnum(`Enum8('new' = -128, 'it\'s' = 0, 'a,b' = 1, 'back\\slash' = 2,'tight'=127)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []bdb.EnumValue{
+		{Label: "new", Value: -128},
+		{Label: "it's", Value: 0},
+		{Label: "a,b", Value: 1},
+		{Label: `back\slash`, Value: 2},
+		{Label: "tight", Value: 127},
+	}
+	if !reflect.DeepEqual(values, expect) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", expect, values)
+	}
+
+	values, err = clickhouseParseEnum(`Enum16('one' = 1000)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0].Label != "one" || values[0].Value != 1000 {
+		t.Errorf("wrong values: %#v", values)
+	}
+
+	bad := []string{
+		`String`,
+		`Enum8(a = 1)`,
+		`Enum8('a = 1)`,
+		`Enum8('a' 1)`,
+		`Enum8('a' = x)`,
+	}
+	for _, b := range bad {
+		if _, err := clickhouseParseEnum(b); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Precision  int
+		Scale      int
+	}{
+		{"Decimal(18,4)", "types.Decimal", 18, 4},
+		{"Decimal( 9, 2 )", "types.Decimal", 9, 2},
+		{"Decimal32(4)", "types.Decimal", 9, 4},
+		{"Decimal64(8)", "types.Decimal", 18, 8},
+		{"Decimal128(10)", "types.Decimal", 38, 10},
+		{"Decimal256(20)", "types.Decimal", 76, 20},
+		{"Nullable(Decimal(10,3))", "types.NullDecimal", 10, 3},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		c := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+
+		if c.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, c.Type)
+		}
+		if c.DecimalPrecision != test.Precision || c.DecimalScale != test.Scale {
+			t.Errorf("%d) %s: want (%d, %d), got (%d, %d)", i, test.FullDBType,
+				test.Precision, test.Scale, c.DecimalPrecision, c.DecimalScale)
+		}
+	}
+
+	bad := []string{"Decimal", "Decimal(18)", "Decimal32(4,2)", "Decimal(a,b)", "Decimal512(2)"}
+	for _, b := range bad {
+		if _, _, err := clickhouseParseDecimal(b); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhouseParseEngine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Engine      string
+		Name        string
+		Partition   string
+		PrimaryKey  []string
+		Granularity int
+	}{
+		{
+			Engine:      "MergeTree(date, (a, b), 8192)",
+			Name:        "MergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree(EventDate, intHash32(UserID), (CounterID, EventDate, intHash32(UserID)), 8192)",
+			Name:        "MergeTree",
+			Partition:   "EventDate",
+			PrimaryKey:  []string{"CounterID", "EventDate", "intHash32(UserID)"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "ReplicatedMergeTree('/clickhouse/tables/{shard}/hits', '{replica}', date, (a, b), 8192)",
+			Name:        "ReplicatedMergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "ReplacingMergeTree(date, (id, name), 8192, version)",
+			Name:        "ReplacingMergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"id", "name"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "SummingMergeTree(date, (a, b), 8192, (shows, clicks))",
+			Name:        "SummingMergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "CollapsingMergeTree(date, id, 8192, sign)",
+			Name:        "CollapsingMergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"id"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "ReplicatedSummingMergeTree('/clickhouse/tables/{shard}/t', '{replica}', date, (a, b), 8192, (c))",
+			Name:        "ReplicatedSummingMergeTree",
+			Partition:   "date",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree PARTITION BY toYYYYMM(date) ORDER BY (a, b) SETTINGS index_granularity = 8192",
+			Name:        "MergeTree",
+			Partition:   "toYYYYMM(date)",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree ORDER BY id SETTINGS index_granularity = 8192",
+			Name:        "MergeTree",
+			PrimaryKey:  []string{"id"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "ReplicatedMergeTree('/clickhouse/tables/{shard}/t', '{replica}') PARTITION BY (date, kind) ORDER BY (id, intHash32(user_id)) SAMPLE BY intHash32(user_id) SETTINGS index_granularity = 8192",
+			Name:        "ReplicatedMergeTree",
+			Partition:   "(date, kind)",
+			PrimaryKey:  []string{"id", "intHash32(user_id)"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree ORDER BY (a, b, c) PRIMARY KEY a SETTINGS index_granularity = 8192",
+			Name:        "MergeTree",
+			PrimaryKey:  []string{"a"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree PARTITION BY toYYYYMM(date) PRIMARY KEY (a, b) ORDER BY (a, b, c) SETTINGS index_granularity = 8192",
+			Name:        "MergeTree",
+			Partition:   "toYYYYMM(date)",
+			PrimaryKey:  []string{"a", "b"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree ORDER BY (id, created_at) PRIMARY KEY (id) TTL created_at + toIntervalDay(30)",
+			Name:        "MergeTree",
+			PrimaryKey:  []string{"id"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "ReplacingMergeTree(version) PARTITION BY toYYYYMM(date) ORDER BY (id, 'x,y') SETTINGS index_granularity = 8192",
+			Name:        "ReplacingMergeTree",
+			Partition:   "toYYYYMM(date)",
+			PrimaryKey:  []string{"id", "'x,y'"},
+			Granularity: 8192,
+		},
+		{
+			Engine:      "MergeTree ORDER BY id SETTINGS storage_policy = 'ssd,hdd', index_granularity = 1024",
+			Name:        "MergeTree",
+			PrimaryKey:  []string{"id"},
+			Granularity: 1024,
+		},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		engine, err := m.parseEngine(test.Engine)
+		if err != nil {
+			t.Errorf("%d) %s: %v", i, test.Engine, err)
+			continue
+		}
+
+		if engine.Name != test.Name {
+			t.Errorf("%d) want name %s, got %s", i, test.Name, engine.Name)
+		}
+		if engine.PartitioningKey != test.Partition {
+			t.Errorf("%d) want partition %s, got %s", i, test.Partition, engine.PartitioningKey)
+		}
+		if !reflect.DeepEqual(engine.PrimaryKey, test.PrimaryKey) {
+			t.Errorf("%d) want primary key %#v, got %#v", i, test.PrimaryKey, engine.PrimaryKey)
+		}
+		if engine.Granularity != test.Granularity {
+			t.Errorf("%d) want granularity %d, got %d", i, test.Granularity, engine.Granularity)
+		}
+	}
+
+	bad := []string{
+		"MergeTree(date, (a, b)",
+		"MergeTree(date, (a, b), x)",
+		"ReplicatedMergeTree('/path')",
+		"MergeTree PARTITION BY date SETTINGS index_granularity = 8192",
+		"MergeTree ORDER BY id SETTINGS index_granularity = x",
+	}
+	for _, b := range bad {
+		if _, err := m.parseEngine(b); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhousePrimaryKeyInfoKeyless(t *testing.T) {
+	t.Parallel()
+
+	engines := []string{"Log", "TinyLog", "StripeLog", "Memory", "Null", "Set", "Buffer(default, events, 16, 10, 100, 10000, 1000000, 10000000, 100000000)"}
+
+	for _, engine := range engines {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mock.ExpectQuery(`select name, engine_full`).
+			WithArgs("events", "default").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+				AddRow("events", engine))
+
+		m := &ClickhouseDriver{dbConn: db}
+		pkey, err := m.PrimaryKeyInfo("default", "events")
+		if err != nil {
+			t.Errorf("%s: %v", engine, err)
+		}
+		if pkey != nil {
+			t.Errorf("%s: want no primary key, got %#v", engine, pkey)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClickhouseMaterializedView(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables`).
+		WithArgs("default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", ".inner.daily_visits", "SummingMergeTree").
+			AddRow("default", "daily_visits", "MaterializedView"))
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment, position`).
+		WithArgs("daily_visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("day", "Date", "", "", "", 1).
+			AddRow("visits", "UInt64", "", "", "", 2))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("daily_visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("daily_visits", ""))
+	mock.ExpectQuery(`select total_rows from system.tables`).
+		WithArgs("daily_visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(nil))
+	mock.ExpectQuery(`from system.data_skipping_indices`).
+		WithArgs("daily_visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "expr", "granularity"}))
+	mock.ExpectQuery(`select create_table_query`).
+		WithArgs("daily_visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	tables, err := bdb.Tables(m, "default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("want only the view, got %#v", tables)
+	}
+
+	view := tables[0]
+	if view.Name != "daily_visits" || !view.IsView {
+		t.Errorf("want a read-only daily_visits table, got %#v", view)
+	}
+	if view.PKey != nil {
+		t.Errorf("want no primary key, got %#v", view.PKey)
+	}
+
+	var names, types []string
+	for _, c := range view.Columns {
+		names = append(names, c.Name)
+		types = append(types, c.Type)
+	}
+	if want := []string{"day", "visits"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want columns %v, got %v", want, names)
+	}
+	if want := []string{"time.Time", "uint64"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("want types %v, got %v", want, types)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTablesNullable(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables`).
+		WithArgs("default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "visits", "MergeTree"))
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment, position`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("referrer", "Nullable(String)", "", "", "", 2).
+			AddRow("country", "LowCardinality(Nullable(String))", "", "", "", 3).
+			AddRow("tags", "Array(Nullable(String))", "", "", "", 4).
+			AddRow("duration", "Float64", "", "", "", 5))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("visits", "MergeTree ORDER BY id"))
+	mock.ExpectQuery(`select total_rows from system.tables`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(nil))
+	mock.ExpectQuery(`from system.data_skipping_indices`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "expr", "granularity"}))
+	mock.ExpectQuery(`select create_table_query`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	tables, err := bdb.Tables(m, "default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("want the visits table, got %#v", tables)
+	}
+
+	nullable := map[string]bool{}
+	types := map[string]string{}
+	for _, c := range tables[0].Columns {
+		nullable[c.Name] = c.Nullable
+		types[c.Name] = c.Type
+	}
+	wantNullable := map[string]bool{"id": false, "referrer": true, "country": true, "tags": false, "duration": false}
+	if !reflect.DeepEqual(nullable, wantNullable) {
+		t.Errorf("want nullable %v, got %v", wantNullable, nullable)
+	}
+	wantTypes := map[string]string{"id": "uint64", "referrer": "null.String", "country": "null.String", "tags": "[]null.String", "duration": "float64"}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Errorf("want types %v, got %v", wantTypes, types)
+	}
+
+	// A flag set beforehand doesn't survive on a column that can't be NULL
+	if c := m.TranslateColumnType(bdb.Column{DBType: "Int32", Nullable: true}); c.Nullable || c.Type != "int32" {
+		t.Errorf("want a not null int32, got %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseBulkIntrospection(t *testing.T) {
+	t.Parallel()
+
+	type column struct {
+		table, name, typ string
+		position         uint64
+	}
+	columns := []column{
+		{"events", "id", "UInt64", 1},
+		{"events", "name", "LowCardinality(String)", 2},
+		{"events_dist", "id", "UInt64", 1},
+		{"events_dist", "name", "LowCardinality(String)", 2},
+		{"logs", "message", "String", 1},
+		{"users", "id", "UInt32", 1},
+		{"users", "email", "Nullable(String)", 2},
+	}
+	engines := map[string]string{
+		"events":      "MergeTree ORDER BY id TTL toDate(id) + toIntervalDay(30)",
+		"events_dist": "Distributed('cluster', 'default', 'events', rand())",
+		"logs":        "Log",
+		"users":       "ReplacingMergeTree ORDER BY id",
+	}
+	totalRows := map[string]interface{}{"events": uint64(1000), "events_dist": nil, "logs": nil, "users": uint64(12)}
+	ddl := func(name string) string {
+		if name == "events" {
+			return "CREATE TABLE default.events (`id` UInt64, PROJECTION by_id (SELECT id ORDER BY id)) ENGINE = " + engines[name]
+		}
+		return "CREATE TABLE default." + name + " (`id` UInt64) ENGINE = " + engines[name]
+	}
+	names := []string{"events", "events_dist", "logs", "users"}
+
+	expectTableNames := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows([]string{"database", "name", "engine"})
+		for _, name := range names {
+			rows.AddRow("default", name, clickhouseEngineName(engines[name]))
+		}
+		mock.ExpectQuery(`select database, name, engine from system.tables`).
+			WithArgs("default", "system").
+			WillReturnRows(rows)
+	}
+
+	// Table by table, every table takes its own columns, engine, rows and
+	// indexes queries, the distributed one an extra one for its local table
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTableNames(mock)
+	for _, name := range names {
+		rows := sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"})
+		for _, c := range columns {
+			if c.table == name {
+				rows.AddRow(c.name, c.typ, "", "", "", c.position)
+			}
+		}
+		mock.ExpectQuery(`from system.columns`).WithArgs(name, "default").WillReturnRows(rows)
+		mock.ExpectQuery(`select name, engine_full`).WithArgs(name, "default").
+			WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).AddRow(name, engines[name]))
+		if name == "events_dist" {
+			mock.ExpectQuery(`select name, engine_full`).WithArgs("events", "default").
+				WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).AddRow("events", engines["events"]))
+		}
+		mock.ExpectQuery(`select total_rows`).WithArgs(name, "default").
+			WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(totalRows[name]))
+		indexRows := sqlmock.NewRows([]string{"name", "type", "expr", "granularity"})
+		if name == "events" {
+			indexRows.AddRow("name_idx", "bloom_filter", "name", uint64(4))
+		}
+		mock.ExpectQuery(`from system.data_skipping_indices`).WithArgs(name, "default").WillReturnRows(indexRows)
+		mock.ExpectQuery(`select create_table_query`).WithArgs(name, "default").
+			WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}).AddRow(ddl(name)))
+	}
+
+	perTable := NewClickhouseDriver(ClickhouseDriverConfig{})
+	perTable.dbConn = db
+	want, err := bdb.Tables(perTable, "default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	// In bulk the columns, engines, rows and indexes of all the tables take
+	// three queries, shuffled rows are fine
+	db, mock, err = sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTableNames(mock)
+	columnRows := sqlmock.NewRows([]string{"database", "table", "name", "type", "default_kind", "default_expression", "comment", "position"})
+	for i := len(columns) - 1; i >= 0; i-- {
+		c := columns[i]
+		columnRows.AddRow("default", c.table, c.name, c.typ, "", "", "", c.position)
+	}
+	mock.ExpectQuery(`select database, table, name, type, default_kind, default_expression, comment, position\s+from system.columns\s+where database in \(\?\)`).
+		WithArgs("default").
+		WillReturnRows(columnRows)
+	tableRows := sqlmock.NewRows([]string{"database", "name", "engine_full", "total_rows", "create_table_query"})
+	for _, name := range names {
+		tableRows.AddRow("default", name, engines[name], totalRows[name], ddl(name))
+	}
+	mock.ExpectQuery(`select database, name, engine_full, total_rows, create_table_query\s+from system.tables\s+where database in \(\?\)`).
+		WithArgs("default").
+		WillReturnRows(tableRows)
+	mock.ExpectQuery(`select database, table, name, type, expr, granularity from system.data_skipping_indices where database in \(\?\)`).
+		WithArgs("default").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "table", "name", "type", "expr", "granularity"}).
+			AddRow("default", "events", "name_idx", "bloom_filter", "name", uint64(4)))
+
+	bulk := NewClickhouseDriver(ClickhouseDriverConfig{BulkIntrospection: true})
+	bulk.dbConn = db
+	got, err := bdb.Tables(bulk, "default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want the bulk introspection to match the per table one\nwant: %#v\ngot:  %#v", want, got)
+	}
+	if len(want) != 4 || want[0].RowEstimate != 1000 || len(want[0].Indexes) != 1 || len(want[0].TTL) == 0 || len(want[0].Projections) != 1 || want[1].PKey == nil || want[2].PKey != nil {
+		t.Errorf("unexpected fixture tables: %#v", want)
+	}
+}
+
+func TestClickhouseIndexInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, expr, granularity from system.data_skipping_indices where table = \? and database = \?`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "expr", "granularity"}).
+			AddRow("user_idx", "bloom_filter(0.01)", "user_id", uint64(4)).
+			AddRow("duration_idx", "minmax", "duration * 1000", uint64(1)))
+	mock.ExpectQuery(`from system.data_skipping_indices`).
+		WithArgs("sessions", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "expr", "granularity"}))
+
+	m := &ClickhouseDriver{dbConn: db}
+
+	indexes, err := m.IndexInfo("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bdb.Index{
+		{Name: "user_idx", Type: "bloom_filter(0.01)", Expression: "user_id", Granularity: 4},
+		{Name: "duration_idx", Type: "minmax", Expression: "duration * 1000", Granularity: 1},
+	}
+	if !reflect.DeepEqual(indexes, want) {
+		t.Errorf("want %#v, got %#v", want, indexes)
+	}
+
+	if indexes, err := m.IndexInfo("default", "sessions"); err != nil || len(indexes) != 0 {
+		t.Errorf("want no indexes, got %#v, %v", indexes, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	// Older servers have no system.data_skipping_indices to query
+	m = &ClickhouseDriver{version: clickhouseVersion{Major: 21, Minor: 3}}
+	if indexes, err := m.IndexInfo("default", "events"); err != nil || indexes != nil {
+		t.Errorf("want no indexes before 21.6, got %#v, %v", indexes, err)
+	}
+}
+
+func TestClickhouseTableRowEstimate(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select total_rows from system.tables where name = \? and database = \?`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(uint64(1234567)))
+	mock.ExpectQuery(`select total_rows from system.tables where name = \? and database = \?`).
+		WithArgs("events_log", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(nil))
+
+	m := &ClickhouseDriver{dbConn: db}
+
+	if rows, err := m.TableRowEstimate("default", "events"); err != nil || rows != 1234567 {
+		t.Errorf("want 1234567 rows, got %d, %v", rows, err)
+	}
+	if rows, err := m.TableRowEstimate("default", "events_log"); err != nil || rows != 0 {
+		t.Errorf("want no estimate for a NULL total_rows, got %d, %v", rows, err)
+	}
+
+	m.version = clickhouseVersion{Major: 19, Minor: 17, Patch: 4}
+	if rows, err := m.TableRowEstimate("default", "events"); err != nil || rows != 0 {
+		t.Errorf("want no estimate before 20.4, got %d, %v", rows, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseParseDistributed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Engine string
+		Want   clickhouseDistributed
+	}{
+		{
+			"Distributed('logs', 'default', 'events_local', rand())",
+			clickhouseDistributed{"logs", "default", "events_local", "rand()"},
+		},
+		{
+			"Distributed(logs, analytics, events_local)",
+			clickhouseDistributed{"logs", "analytics", "events_local", ""},
+		},
+		{
+			"Distributed('logs', currentDatabase(), 'events_local', cityHash64(user_id), 'ssd') SETTINGS fsync_after_insert = 1",
+			clickhouseDistributed{"logs", "", "events_local", "cityHash64(user_id)"},
+		},
+	}
+
+	for i, test := range tests {
+		dist, err := clickhouseParseDistributed(test.Engine)
+		if err != nil {
+			t.Errorf("%d) %v", i, err)
+			continue
+		}
+		if *dist != test.Want {
+			t.Errorf("%d) want %#v, got %#v", i, test.Want, *dist)
+		}
+	}
+
+	bad := []string{"Distributed", "Distributed('logs', 'default')", "Distributed('logs', 'default', 'events'"}
+	for _, b := range bad {
+		if _, err := clickhouseParseDistributed(b); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhousePrimaryKeyInfoDistributed(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events", "Distributed('logs', currentDatabase(), 'events_local', rand())"))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events_local", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events_local", "MergeTree PARTITION BY toYYYYMM(date) ORDER BY (id, date)"))
+
+	m := &ClickhouseDriver{dbConn: db}
+	pkey, err := m.PrimaryKeyInfo("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pkey.Name != "events" {
+		t.Errorf("want the distributed table name, got %s", pkey.Name)
+	}
+	if want := []string{"id", "date"}; !reflect.DeepEqual(pkey.Columns, want) {
+		t.Errorf("want primary key %#v, got %#v", want, pkey.Columns)
+	}
+	if want := "toYYYYMM(date)"; pkey.PartitionKey != want {
+		t.Errorf("want partition key %s, got %s", want, pkey.PartitionKey)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseEngineName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Log":                           "Log",
+		"Memory":                        "Memory",
+		" MergeTree ORDER BY id":        "MergeTree",
+		"MergeTree(date, (a, b), 8192)": "MergeTree",
+		"ReplicatedMergeTree('/p', '{replica}') ORDER BY id": "ReplicatedMergeTree",
+	}
+	for engine, want := range tests {
+		if got := clickhouseEngineName(engine); got != want {
+			t.Errorf("%s: want %s, got %s", engine, want, got)
+		}
+	}
+
+	if clickhouseIsMergeTree("Log") || clickhouseIsMergeTree("Memory") || !clickhouseIsMergeTree("CollapsingMergeTree") {
+		t.Error("wrong MergeTree family detection")
+	}
+}
+
+func TestClickhouseParseEngineSortingKey(t *testing.T) {
+	t.Parallel()
+
+	m := &ClickhouseDriver{}
+	engine, err := m.parseEngine("ReplicatedMergeTree('/clickhouse/tables/{shard}/events', '{replica}') PARTITION BY (toYYYYMM(date), kind) PRIMARY KEY (user_id, date) ORDER BY (user_id, date, intHash32(id)) SETTINGS index_granularity = 8192")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "(toYYYYMM(date), kind)"; engine.PartitioningKey != want {
+		t.Errorf("want partition key %s, got %s", want, engine.PartitioningKey)
+	}
+	if want := []string{"user_id", "date"}; !reflect.DeepEqual(engine.PrimaryKey, want) {
+		t.Errorf("want primary key %#v, got %#v", want, engine.PrimaryKey)
+	}
+	if want := []string{"user_id", "date", "intHash32(id)"}; !reflect.DeepEqual(engine.SortingKey, want) {
+		t.Errorf("want sorting key %#v, got %#v", want, engine.SortingKey)
+	}
+
+	engine, err = m.parseEngine("MergeTree(date, (a, b), 8192)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(engine.SortingKey, want) {
+		t.Errorf("want sorting key %#v, got %#v", want, engine.SortingKey)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeBoolComment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Comment    string
+		Type       string
+	}{
+		{"UInt8", "", "uint8"},
+		{"UInt8", "number of retries", "uint8"},
+		{"UInt8", "@sqlboiler:bool", "bool"},
+		{"UInt8", "is the user active @sqlboiler:bool", "bool"},
+		{"Nullable(UInt8)", "@sqlboiler:bool", "null.Bool"},
+		{"Nullable(UInt8)", "", "null.Uint8"},
+		{"UInt16", "@sqlboiler:bool", "uint16"},
+		{"Array(UInt8)", "@sqlboiler:bool", "[]uint8"},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType, Comment: test.Comment})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s %q: want type %s, got %s", i, test.FullDBType, test.Comment, test.Type, col.Type)
+		}
+	}
+}
+
+func TestClickhouseBuildQueryStringTLS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Secure     bool
+		SkipVerify bool
+		DSN        string
+	}{
+		{false, false, "tcp://localhost:9000?database=db&debug=false&no_delay=true"},
+		{true, false, "tcp://localhost:9000?database=db&debug=false&no_delay=true&secure=true"},
+		{false, true, "tcp://localhost:9000?database=db&debug=false&no_delay=true&skip_verify=true"},
+		{true, true, "tcp://localhost:9000?database=db&debug=false&no_delay=true&secure=true&skip_verify=true"},
+	}
+
+	for i, test := range tests {
+		dsn := ClickhouseBuildQueryString(ClickhouseDriverConfig{
+			Host:       "localhost",
+			Port:       9000,
+			Database:   "db",
+			Secure:     test.Secure,
+			SkipVerify: test.SkipVerify,
+		})
+
+		if dsn != test.DSN {
+			t.Errorf("%d) want dsn %s, got %s", i, test.DSN, dsn)
+		}
+	}
+}
+
+func TestClickhouseBuildQueryStringCompress(t *testing.T) {
+	t.Parallel()
+
+	config := ClickhouseDriverConfig{
+		Host:     "localhost",
+		Port:     9000,
+		Database: "db",
+	}
+
+	want := "tcp://localhost:9000?database=db&debug=false&no_delay=true"
+	if dsn := ClickhouseBuildQueryString(config); dsn != want {
+		t.Errorf("want dsn %s, got %s", want, dsn)
+	}
+
+	config.Compress = true
+	want = "tcp://localhost:9000?compress=true&database=db&debug=false&no_delay=true"
+	if dsn := ClickhouseBuildQueryString(config); dsn != want {
+		t.Errorf("want dsn %s, got %s", want, dsn)
+	}
+}
+
+func TestClickhouseBuildQueryStringDialTimeout(t *testing.T) {
+	t.Parallel()
+
+	config := ClickhouseDriverConfig{
+		Host:     "localhost",
+		Port:     9000,
+		Database: "db",
+	}
+
+	want := "tcp://localhost:9000?database=db&debug=false&no_delay=true"
+	if dsn := ClickhouseBuildQueryString(config); dsn != want {
+		t.Errorf("want dsn %s, got %s", want, dsn)
+	}
+
+	config.DialTimeout = 5
+	want = "tcp://localhost:9000?database=db&debug=false&no_delay=true&timeout=5"
+	if dsn := ClickhouseBuildQueryString(config); dsn != want {
+		t.Errorf("want dsn %s, got %s", want, dsn)
+	}
+}
+
+func TestClickhouseBuildQueryStringSettings(t *testing.T) {
+	t.Parallel()
+
+	config := ClickhouseDriverConfig{
+		Host:     "localhost",
+		Port:     9000,
+		Database: "db",
+		Settings: map[string]string{
+			"send_timeout":       "30",
+			"max_execution_time": "60",
+			"database":           "other",
+			"debug":              "true",
+		},
+	}
+
+	want := "tcp://localhost:9000?database=db&debug=false&max_execution_time=60&no_delay=true&send_timeout=30"
+	for i := 0; i < 10; i++ {
+		if dsn := ClickhouseBuildQueryString(config); dsn != want {
+			t.Fatalf("want dsn %s, got %s", want, dsn)
+		}
+	}
+}
+
+func TestClickhouseIgnoredTable(t *testing.T) {
+	t.Parallel()
+
+	tables := []string{"users", ".inner.users_mv", "users_mv", "events_tmp", "_tmp_events", "inner_join"}
+
+	tests := []struct {
+		Prefixes []string
+		Want     []string
+	}{
+		{nil, []string{"users", "users_mv", "events_tmp", "_tmp_events", "inner_join"}},
+		{[]string{".inner.", "_tmp"}, []string{"users", "users_mv", "events_tmp", "inner_join"}},
+		{[]string{"users"}, []string{".inner.users_mv", "events_tmp", "_tmp_events", "inner_join"}},
+	}
+
+	for i, test := range tests {
+		m := NewClickhouseDriver(ClickhouseDriverConfig{IgnoreTablePrefixes: test.Prefixes})
+
+		var got []string
+		for _, table := range tables {
+			if !m.ignoredTable(table) {
+				got = append(got, table)
+			}
+		}
+
+		if !reflect.DeepEqual(got, test.Want) {
+			t.Errorf("%d) want %#v, got %#v", i, test.Want, got)
+		}
+	}
+}
+
+func TestClickhouseIsViewEngine(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"View":                true,
+		"MaterializedView":    true,
+		"MergeTree":           false,
+		"ReplicatedMergeTree": false,
+		"Distributed":         false,
+	}
+	for engine, want := range tests {
+		if got := clickhouseIsViewEngine(engine); got != want {
+			t.Errorf("%s: want %t, got %t", engine, want, got)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeDateTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Precision  int
+		Timezone   string
+	}{
+		{"DateTime", "time.Time", 0, ""},
+		{"DateTime('UTC')", "time.Time", 0, "UTC"},
+		{"DateTime64(3)", "time.Time", 3, ""},
+		{"DateTime64(9,'Europe/Moscow')", "time.Time", 9, "Europe/Moscow"},
+		{"DateTime64(6, 'UTC')", "time.Time", 6, "UTC"},
+		{"Nullable(DateTime64(3))", "null.Time", 3, ""},
+		{"Nullable(DateTime('Europe/Moscow'))", "null.Time", 0, "Europe/Moscow"},
+		{"DateTime( 'Asia/Tokyo' )", "time.Time", 0, "Asia/Tokyo"},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.DateTimePrecision != test.Precision {
+			t.Errorf("%d) %s: want precision %d, got %d", i, test.FullDBType, test.Precision, col.DateTimePrecision)
+		}
+		if col.Timezone != test.Timezone {
+			t.Errorf("%d) %s: want timezone %s, got %s", i, test.FullDBType, test.Timezone, col.Timezone)
+		}
+	}
+
+	bad := []string{"DateTime64", "DateTime64(x)", "DateTime64(3, 'UTC', 1)"}
+	for _, b := range bad {
+		if _, _, err := clickhouseParseDateTime(b); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeNullableDates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+		Timezone   string
+	}{
+		{"Date", "time.Time", false, ""},
+		{"DateTime", "time.Time", false, ""},
+		{"DateTime64(3, 'UTC')", "time.Time", false, "UTC"},
+		{"Nullable(Date)", "null.Time", true, ""},
+		{"Nullable(DateTime)", "null.Time", true, ""},
+		{"Nullable(DateTime64(3, 'UTC'))", "null.Time", true, "UTC"},
+		{"LowCardinality(Nullable(Date))", "null.Time", true, ""},
+		{"SimpleAggregateFunction(max, Nullable(DateTime))", "null.Time", true, ""},
+		{"Array(Nullable(DateTime))", "[]null.Time", false, ""},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"})
+	for i, test := range tests {
+		rows.AddRow("c"+strconv.Itoa(i), test.FullDBType, "", "", "", i+1)
+	}
+	mock.ExpectQuery(`from system.columns`).WithArgs("events", "default").WillReturnRows(rows)
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	columns, err := m.Columns("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != len(tests) {
+		t.Fatalf("want %d columns, got %d", len(tests), len(columns))
+	}
+
+	for i, test := range tests {
+		col := m.TranslateColumnType(columns[i])
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+		if col.Timezone != test.Timezone {
+			t.Errorf("%d) %s: want timezone %s, got %s", i, test.FullDBType, test.Timezone, col.Timezone)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeLowCardinality(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+	}{
+		{"LowCardinality(String)", "string", false},
+		{"LowCardinality(Nullable(String))", "null.String", true},
+		{"LowCardinality(UInt32)", "uint32", false},
+		{"LowCardinality(Nullable(Float64))", "null.Float64", true},
+		{"LowCardinality(FixedString(2))", "types.FixedString", false},
+		{"Array(LowCardinality(String))", "types.ClickhouseStringArray", false},
+		{"Array(LowCardinality(Nullable(String)))", "[]null.String", false},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+	}
+}
+
+func TestClickhousePing(t *testing.T) {
+	t.Parallel()
+
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	primaryMock.ExpectQuery(`SELECT version\(\)`).WillReturnError(errors.New("connection refused"))
+
+	_, alt1Mock, err := sqlmock.NewWithDSN("clickhouse_ping_alt1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alt1Mock.ExpectQuery(`SELECT version\(\)`).WillReturnError(errors.New("connection refused"))
+
+	_, alt2Mock, err := sqlmock.NewWithDSN("clickhouse_ping_alt2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alt2Mock.ExpectQuery(`SELECT version\(\)`).WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("21.8.3.44"))
+
+	m := &ClickhouseDriver{
+		dbConn:        primary,
+		sqlDriverName: "sqlmock",
+		hosts:         []string{"primary:9000", "alt1:9000", "alt2:9000"},
+		hostConnStrs:  []string{"clickhouse_ping_primary", "clickhouse_ping_alt1", "clickhouse_ping_alt2"},
+	}
+
+	host, err := m.Ping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "alt2:9000" {
+		t.Errorf("want host alt2:9000, got %s", host)
+	}
+	if m.connStr != "clickhouse_ping_alt2" {
+		t.Errorf("want the alt2 connection to be kept, got %s", m.connStr)
+	}
+	if v := m.ServerVersion(); v != "21.8.3" {
+		t.Errorf("want the alt2 version 21.8.3, got %s", v)
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{primaryMock, alt1Mock, alt2Mock} {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClickhousePingNoHost(t *testing.T) {
+	t.Parallel()
+
+	primary, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`SELECT version\(\)`).WillReturnError(errors.New("connection refused"))
+
+	m := &ClickhouseDriver{
+		dbConn:        primary,
+		sqlDriverName: "sqlmock",
+		hosts:         []string{"primary:9000"},
+		hostConnStrs:  []string{"clickhouse_ping_nohost"},
+	}
+
+	if _, err := m.Ping(); err == nil {
+		t.Error("expected an error when no host answers")
+	}
+}
+
+func TestClickhouseParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]clickhouseVersion{
+		"21.8.3.44":  {21, 8, 3},
+		"1.1.54394":  {1, 1, 54394},
+		"22.3.2-lts": {22, 3, 2},
+		" 23.3.1.2 ": {23, 3, 1},
+		"18.14.19.1": {18, 14, 19},
+	}
+	for version, want := range tests {
+		got, err := clickhouseParseVersion(version)
+		if err != nil {
+			t.Errorf("%s: %v", version, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: want %v, got %v", version, want, got)
+		}
+	}
+
+	bad := []string{"", "21", "21.8", "x.8.3", "21.y.3"}
+	for _, b := range bad {
+		if _, err := clickhouseParseVersion(b); err == nil {
+			t.Errorf("%q: expected an error", b)
+		}
+	}
+}
+
+func TestClickhouseColumnsLegacyVersion(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`SELECT version\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("1.1.54394"))
+	mock.ExpectQuery(`select name, type, default_type, default_expression, ''`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_type", "default_expression", "''", "0"}).
+			AddRow("id", "UInt64", "", "", "", 0).
+			AddRow("day", "Date", "MATERIALIZED", "today()", "", 0))
+
+	m := NewClickhouseDriverFromDB(db)
+	if _, err := m.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.ServerVersion(); v != "1.1.54394" {
+		t.Errorf("want version 1.1.54394, got %s", v)
+	}
+
+	columns, err := m.Columns("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 2 || columns[1].Default != "today()" || !columns[1].AutoGenerated {
+		t.Errorf("unexpected columns %#v", columns)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	// Unknown and recent versions use the current columns
+	for _, v := range []clickhouseVersion{{}, {18, 1, 0}, {21, 8, 3}} {
+		m.version = v
+		if q := m.columnsQuery(); q != clickhouseColumnsQuery {
+			t.Errorf("%v: want the current columns query, got %s", v, q)
+		}
+	}
+}
+
+func TestClickhouseDriverDSN(t *testing.T) {
+	t.Parallel()
+
+	dsn := "tcp://ch1:9440?database=analytics&username=bob&password=secret&secure=true&read_timeout=10"
+	m := NewClickhouseDriver(ClickhouseDriverConfig{DSN: dsn, Nagle: true, BlockSize: 0})
+
+	if m.connStr != dsn {
+		t.Errorf("want the dsn as is, got %s", m.connStr)
+	}
+	if want := []string{"ch1:9440"}; !reflect.DeepEqual(m.hosts, want) {
+		t.Errorf("want hosts %v, got %v", want, m.hosts)
+	}
+	if want := []string{dsn}; !reflect.DeepEqual(m.hostConnStrs, want) {
+		t.Errorf("want connection strings %v, got %v", want, m.hostConnStrs)
+	}
+	if m.configErr != nil {
+		t.Error(m.configErr)
+	}
+}
+
+func TestClickhouseDriverDSNOptions(t *testing.T) {
+	t.Parallel()
+
+	config := ClickhouseDriverConfig{
+		Host:                  "ch1",
+		Port:                  9440,
+		Databases:             []string{"analytics", "billing"},
+		CaseInsensitiveTables: true,
+		Cluster:               "main",
+		BulkIntrospection:     true,
+		EnumAsInt:             true,
+		RelationsFile:         "relations.toml",
+	}
+	fields := NewClickhouseDriver(config)
+
+	config.DSN = "tcp://ch1:9440"
+	config.Host, config.Port = "", 0
+	dsn := NewClickhouseDriver(config)
+
+	// Only the connection differs, the options are taken the same way
+	fields.connStr, fields.hosts, fields.hostConnStrs = dsn.connStr, dsn.hosts, dsn.hostConnStrs
+	if !reflect.DeepEqual(fields, dsn) {
+		t.Errorf("want the same driver, got:\n%#v\n%#v", fields, dsn)
+	}
+	if dsn.cluster != "main" || !dsn.bulk || len(dsn.systemDatabases) == 0 {
+		t.Errorf("the dsn driver wasn't configured: %#v", dsn)
+	}
+
+	config.UnknownType = "float"
+	if NewClickhouseDriver(config).configErr == nil {
+		t.Error("expected an error for the unknown type of the dsn driver")
+	}
+}
+
+func TestClickhouseDriverDSNConflicts(t *testing.T) {
+	t.Parallel()
+
+	dsn := "tcp://ch1:9440?database=analytics&username=bob&password=secret&read_timeout=10"
+
+	tests := []struct {
+		Config   ClickhouseDriverConfig
+		Conflict bool
+	}{
+		{ClickhouseDriverConfig{}, false},
+		{ClickhouseDriverConfig{Host: "ch1", Port: 9440, Database: "analytics", Username: "bob"}, false},
+		{ClickhouseDriverConfig{Password: "secret", ReadTimeout: 10}, false},
+		{ClickhouseDriverConfig{Port: 9000}, false},
+		{ClickhouseDriverConfig{Host: "ch1", Port: 9000}, true},
+		{ClickhouseDriverConfig{Host: "ch2", Port: 9440}, true},
+		{ClickhouseDriverConfig{Database: "default"}, true},
+		{ClickhouseDriverConfig{Password: "other"}, true},
+		{ClickhouseDriverConfig{WriteTimeout: 20}, true},
+		{ClickhouseDriverConfig{AltHosts: []string{"ch2:9440"}}, true},
+		{ClickhouseDriverConfig{Settings: map[string]string{"max_threads": "4"}}, true},
+	}
+
+	for i, test := range tests {
+		test.Config.DSN = dsn
+		err := NewClickhouseDriver(test.Config).configErr
+		if conflict := err != nil; conflict != test.Conflict {
+			t.Errorf("%d) want conflict %t, got error %v", i, test.Conflict, err)
+		}
+		if err != nil && strings.Contains(err.Error(), "secret") {
+			t.Errorf("%d) the error leaks the password: %v", i, err)
+		}
+	}
+
+	bad := []string{"localhost:9000", "http://ch1:8123", "tcp://%zz"}
+	for _, b := range bad {
+		if err := NewClickhouseDriver(ClickhouseDriverConfig{DSN: b}).Open(); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestClickhouseDriverFromDB(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT version\(\)`).WillReturnRows(sqlmock.NewRows([]string{"version()"}).AddRow("21.8.3.44"))
+	mock.ExpectQuery(`select database, name, engine from system.tables`).
+		WithArgs("default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "events", "MergeTree"))
+	mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	m := NewClickhouseDriverFromDB(db)
+	if err := m.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if m.dbConn != db {
+		t.Fatal("Open replaced the provided connection")
+	}
+
+	if host, err := m.Ping(); err != nil || host != "" {
+		t.Errorf("want no host and no error, got %q, %v", host, err)
+	}
+
+	names, err := m.TableNames("default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"events"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %#v, got %#v", want, names)
+	}
+
+	// The connection belongs to the caller and is still usable after Close
+	m.Close()
+	var one int
+	if err := db.QueryRow("SELECT 1").Scan(&one); err != nil {
+		t.Errorf("the provided connection was closed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseLogger(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from system.tables where database in`).
+		WithArgs("default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).AddRow("default", "events", "MergeTree"))
+	mock.ExpectQuery(`from system.columns`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events", "MergeTree ORDER BY id"))
+
+	type logged struct {
+		query string
+		args  []interface{}
+	}
+	var logs []logged
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{
+		Logger: func(query string, args ...interface{}) {
+			logs = append(logs, logged{query: query, args: args})
+		},
+	})
+	m.dbConn = db
+
+	if _, err := m.TableNames("default", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Columns("default", "events"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.PrimaryKeyInfo("default", "events"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		contains string
+		args     []interface{}
+	}{
+		{"from system.tables where database in (?)", []interface{}{"default", "system"}},
+		{"from system.columns", []interface{}{"events", "default"}},
+		{"engine_full", []interface{}{"events", "default"}},
+	}
+	if len(logs) != len(want) {
+		t.Fatalf("want %d logged queries, got %d: %#v", len(want), len(logs), logs)
+	}
+	for i, w := range want {
+		if !strings.Contains(logs[i].query, w.contains) {
+			t.Errorf("%d) want a query containing %q, got %q", i, w.contains, logs[i].query)
+		}
+		if !reflect.DeepEqual(logs[i].args, w.args) {
+			t.Errorf("%d) want args %#v, got %#v", i, w.args, logs[i].args)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseAltHostQueryString(t *testing.T) {
+	t.Parallel()
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{
+		Host:     "primary",
+		Port:     9000,
+		Database: "db",
+		AltHosts: []string{"alt1:9001", "alt2:9002"},
+	})
+
+	want := []string{
+		"tcp://primary:9000?alt_hosts=alt1%3A9001%2Calt2%3A9002&database=db&debug=false&no_delay=true",
+		"tcp://alt1:9001?database=db&debug=false&no_delay=true",
+		"tcp://alt2:9002?database=db&debug=false&no_delay=true",
+	}
+	if !reflect.DeepEqual(m.hostConnStrs, want) {
+		t.Errorf("want %#v, got %#v", want, m.hostConnStrs)
+	}
+}
+
+func TestClickhouseTableNamesDatabases(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?,\?\)`).
+		WithArgs("analytics", "billing", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("analytics", "events", "MergeTree").
+			AddRow("analytics", "users", "MergeTree").
+			AddRow("billing", "users", "ReplacingMergeTree").
+			AddRow("billing", "users_view", "View"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{Databases: []string{"analytics", "billing"}})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"analytics_events", "analytics_users", "billing_users", "billing_users_view"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("want %#v, got %#v", want, names)
+	}
+
+	tests := []struct {
+		Name     string
+		Database string
+		Table    string
+		Schema   string
+	}{
+		{"analytics_users", "analytics", "users", "`analytics`.`users`"},
+		{"billing_users", "billing", "users", "`billing`.`users`"},
+	}
+
+	for _, test := range tests {
+		database, table := m.table("default", test.Name)
+		if database != test.Database || table != test.Table {
+			t.Errorf("%s: want %s.%s, got %s.%s", test.Name, test.Database, test.Table, database, table)
+		}
+
+		schemaName, err := m.SchemaName("default", test.Name)
+		if err != nil {
+			t.Error(err)
+		}
+		if schemaName != test.Schema {
+			t.Errorf("%s: want schema name %s, got %s", test.Name, test.Schema, schemaName)
+		}
+	}
+
+	if isView, _ := m.IsView("default", "billing_users_view"); !isView {
+		t.Error("billing_users_view should be a view")
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment, position\s+from system.columns\s+where table = \? and database = \?`).
+		WithArgs("users", "billing").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1))
+
+	columns, err := m.Columns("default", "billing_users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 1 || columns[0].Name != "id" {
+		t.Errorf("wrong columns: %#v", columns)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTableNamesSingleDatabase(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?\) and database not in \(\?\) and name in \(\?\)`).
+		WithArgs("default", "system", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "users", "MergeTree"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", []string{"users"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"users"}) {
+		t.Errorf("want unprefixed names, got %#v", names)
+	}
+
+	if schemaName, _ := m.SchemaName("default", "users"); schemaName != "" {
+		t.Errorf("want no schema name, got %s", schemaName)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTableNamesSystemDatabases(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?,\?,\?\) and database not in \(\?,\?\) order by database, name;`).
+		WithArgs("analytics", "ch_system", "monitoring", "ch_system", "monitoring").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("analytics", "events", "MergeTree"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{
+		Databases:       []string{"analytics", "ch_system", "monitoring"},
+		SystemDatabases: []string{"ch_system", "monitoring"},
+	})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"analytics_events"}) {
+		t.Errorf("want only the analytics tables, got %#v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if defaults := NewClickhouseDriver(ClickhouseDriverConfig{}).systemDatabases; !reflect.DeepEqual(defaults, []string{"system"}) {
+		t.Errorf("want the system database excluded by default, got %v", defaults)
+	}
+}
+
+func TestClickhouseTableNamesCluster(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each replica returns its own copy of the tables, views included
+	mock.ExpectQuery(`select database, name, engine from clusterAllReplicas\(\?, system.tables\) where database in \(\?\) and database not in \(\?\) order by database, name;`).
+		WithArgs("analytics", "default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "events", "ReplicatedMergeTree").
+			AddRow("default", "events", "ReplicatedMergeTree").
+			AddRow("default", "events_local", "ReplicatedMergeTree").
+			AddRow("default", "events_mv", "MaterializedView").
+			AddRow("default", "events_mv", "MaterializedView").
+			AddRow("default", "users", "ReplicatedMergeTree"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{Cluster: "analytics"})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"events", "events_local", "events_mv", "users"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %#v", want, names)
+	}
+	if !m.views["events_mv"] || len(m.views) != 1 {
+		t.Errorf("want only events_mv to be a view, got %v", m.views)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseClusterReplicaOnlyTable(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// events_backfill only exists on another replica than the one queried,
+	// which then must not read its own system tables for it
+	mock.ExpectQuery(`select database, name, engine from clusterAllReplicas\(\?, system.tables\) where database in \(\?\)`).
+		WithArgs("analytics", "default", "system").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "events_backfill", "ReplicatedMergeTree"))
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment, position\s+from clusterAllReplicas\(\?, system.columns\)\s+where table = \? and database = \?`).
+		WithArgs("analytics", "events_backfill", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("date", "Date", "", "", "", 2).
+			AddRow("date", "Date", "", "", "", 2))
+	mock.ExpectQuery(`select name, engine_full\s+from clusterAllReplicas\(\?, system.tables\)\s+where name = \? and database = \?\s+limit 1`).
+		WithArgs("analytics", "events_backfill", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events_backfill", "ReplicatedMergeTree('/clickhouse/events_backfill', '{replica}') ORDER BY (id, date)"))
+	mock.ExpectQuery(`select total_rows from clusterAllReplicas\(\?, system.tables\) where name = \? and database = \? limit 1`).
+		WithArgs("analytics", "events_backfill", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(uint64(42)))
+	mock.ExpectQuery(`select name, type, expr, granularity from clusterAllReplicas\(\?, system.data_skipping_indices\) where table = \? and database = \?`).
+		WithArgs("analytics", "events_backfill", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "expr", "granularity"}).
+			AddRow("id_idx", "minmax", "id", uint64(1)).
+			AddRow("id_idx", "minmax", "id", uint64(1)))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{Cluster: "analytics"})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"events_backfill"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %#v", want, names)
+	}
+
+	columns, err := m.Columns("default", "events_backfill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var columnNames []string
+	for _, c := range columns {
+		columnNames = append(columnNames, c.Name)
+	}
+	if want := []string{"id", "date"}; !reflect.DeepEqual(columnNames, want) {
+		t.Errorf("want the columns once each %v, got %v", want, columnNames)
+	}
+
+	pkey, err := m.PrimaryKeyInfo("default", "events_backfill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"id", "date"}; pkey == nil || !reflect.DeepEqual(pkey.Columns, want) {
+		t.Errorf("want primary key %v, got %#v", want, pkey)
+	}
+
+	if rows, err := m.TableRowEstimate("default", "events_backfill"); err != nil || rows != 42 {
+		t.Errorf("want 42 rows, got %d, %v", rows, err)
+	}
+
+	indexes, err := m.IndexInfo("default", "events_backfill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) != 1 || indexes[0].Name != "id_idx" {
+		t.Errorf("want the index once, got %#v", indexes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTableNamesCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?\) and database not in \(\?\) and lower\(name\) in \(\?,\?\)`).
+		WithArgs("default", "system", "users", "pageviews").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "PageViews", "MergeTree").
+			AddRow("default", "users", "MergeTree"))
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?\) and database not in \(\?\) and lower\(concat\(database, '_', name\)\) not in \(\?\)`).
+		WithArgs("default", "system", "default_pageviews").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "users", "MergeTree"))
+	mock.ExpectQuery(`select database, name, engine from system.tables where database in \(\?\) and database not in \(\?\) and name in \(\?\)`).
+		WithArgs("default", "system", "Users").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{CaseInsensitiveTables: true})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", []string{"Users", "PAGEVIEWS"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"PageViews", "users"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %#v, got %#v", want, names)
+	}
+
+	m.databases = []string{"default"}
+	if _, err := m.TableNames("default", nil, []string{"Default_PageViews"}); err != nil {
+		t.Fatal(err)
+	}
+
+	exact := NewClickhouseDriver(ClickhouseDriverConfig{})
+	exact.dbConn = db
+
+	names, err = exact.TableNames("default", []string{"Users"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Errorf("want no names matched exactly, got %#v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTableNamesOrdered(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`from system.tables where .* and name not in \(\?\) order by database, name;`).
+		WithArgs("default", "system", "migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"database", "name", "engine"}).
+			AddRow("default", "users", "MergeTree").
+			AddRow("default", "events", "MergeTree").
+			AddRow("default", "accounts", "MergeTree"))
+	mock.ExpectQuery(`from system.columns\s+where table = \? and database = \?\s+order by position;`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("name", "String", "", "", "", 2).
+			AddRow("created_at", "DateTime", "", "", "", 3))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	names, err := m.TableNames("default", nil, []string{"migrations"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"accounts", "events", "users"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %#v, got %#v", want, names)
+	}
+
+	columns, err := m.Columns("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var columnNames []string
+	for _, c := range columns {
+		columnNames = append(columnNames, c.Name)
+	}
+	if want := []string{"id", "name", "created_at"}; !reflect.DeepEqual(columnNames, want) {
+		t.Errorf("want %#v, got %#v", want, columnNames)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseColumnsDefaultKind(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("created_at", "DateTime", "DEFAULT", "now()", "", 2).
+			AddRow("day", "Date", "MATERIALIZED", "toDate(created_at)", "", 3).
+			AddRow("hour", "UInt8", "ALIAS", "toHour(created_at)", "", 4))
+
+	m := &ClickhouseDriver{dbConn: db}
+	columns, err := m.Columns("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Kind          string
+		Default       string
+		AutoGenerated bool
+	}{
+		{"", "", false},
+		{"DEFAULT", "now()", false},
+		{"MATERIALIZED", "toDate(created_at)", true},
+		{"ALIAS", "toHour(created_at)", true},
+	}
+
+	if len(columns) != len(tests) {
+		t.Fatalf("want %d columns, got %d", len(tests), len(columns))
+	}
+	for i, test := range tests {
+		c := columns[i]
+		if c.DefaultKind != test.Kind || c.Default != test.Default || c.AutoGenerated != test.AutoGenerated {
+			t.Errorf("%s: want kind %q default %q auto %t, got kind %q default %q auto %t",
+				c.Name, test.Kind, test.Default, test.AutoGenerated, c.DefaultKind, c.Default, c.AutoGenerated)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseColumnsComment(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment`).
+		WithArgs("users", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("email", "String", "", "", "primary email\nof the user", 2).
+			AddRow("active", "UInt8", "", "", "is the user active @sqlboiler:bool", 3))
+
+	m := &ClickhouseDriver{dbConn: db}
+	columns, err := m.Columns("default", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"", "primary email\nof the user", "is the user active @sqlboiler:bool"}
+	if len(columns) != len(want) {
+		t.Fatalf("want %d columns, got %d", len(want), len(columns))
+	}
+	for i, c := range columns {
+		if c.Comment != want[i] {
+			t.Errorf("%s: want comment %q, got %q", c.Name, want[i], c.Comment)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseColumnsOrderedByPosition(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment, position\s+from system.columns\s+where table = \? and database = \?\s+order by position`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("name", "String", "", "", "", 3).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("value", "Float64", "", "", "", 4).
+			AddRow("created_at", "DateTime", "", "", "", 2))
+
+	m := &ClickhouseDriver{dbConn: db}
+	columns, err := m.Columns("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, c := range columns {
+		names = append(names, c.Name)
+	}
+	if want := []string{"id", "created_at", "name", "value"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseColumnsDateTimeTimezone(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment`).
+		WithArgs("visits", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("created", "DateTime", "", "", "", 1).
+			AddRow("local", "DateTime('Europe/Moscow')", "", "", "", 2).
+			AddRow("compressed", "DateTime('UTC') CODEC(DoubleDelta)", "", "", "", 3))
+
+	m := &ClickhouseDriver{dbConn: db}
+	columns, err := m.Columns("default", "visits")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		FullDBType string
+		Timezone   string
+	}{
+		{"DateTime", ""},
+		{"DateTime('Europe/Moscow')", "Europe/Moscow"},
+		{"DateTime('UTC')", "UTC"},
+	}
+	if len(columns) != len(want) {
+		t.Fatalf("want %d columns, got %d", len(want), len(columns))
+	}
+	for i, c := range columns {
+		c = m.TranslateColumnType(c)
+		if c.DBType != "DateTime" || c.FullDBType != want[i].FullDBType {
+			t.Errorf("%s: want DateTime, %s, got %s, %s", c.Name, want[i].FullDBType, c.DBType, c.FullDBType)
+		}
+		if c.Type != "time.Time" || c.Timezone != want[i].Timezone {
+			t.Errorf("%s: want time.Time in %q, got %s in %q", c.Name, want[i].Timezone, c.Type, c.Timezone)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseColumnsCodec(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select name, type, default_kind, default_expression, comment`).
+		WithArgs("metrics", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("ts", "DateTime CODEC(DoubleDelta, LZ4)", "", "", "", 1).
+			AddRow("value", "Int64 CODEC(Delta, ZSTD)", "", "", "", 2).
+			AddRow("price", "Decimal(18, 4) CODEC(ZSTD(3))", "", "", "", 3).
+			AddRow("tags", "Array(LowCardinality(String))", "", "", "", 4))
+
+	m := &ClickhouseDriver{dbConn: db}
+	columns, err := m.Columns("default", "metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		DBType     string
+		FullDBType string
+		Codec      string
+	}{
+		{"DateTime", "DateTime", "DoubleDelta, LZ4"},
+		{"Int64", "Int64", "Delta, ZSTD"},
+		{"Decimal", "Decimal(18, 4)", "ZSTD(3)"},
+		{"Array", "Array(LowCardinality(String))", ""},
+	}
+	if len(columns) != len(want) {
+		t.Fatalf("want %d columns, got %d", len(want), len(columns))
+	}
+	for i, c := range columns {
+		if c.DBType != want[i].DBType || c.FullDBType != want[i].FullDBType || c.Codec != want[i].Codec {
+			t.Errorf("%s: want %s, %s, %q, got %s, %s, %q", c.Name,
+				want[i].DBType, want[i].FullDBType, want[i].Codec, c.DBType, c.FullDBType, c.Codec)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Kind       string
+		Expression string
+		Default    string
+	}{
+		{"", "", ""},
+		{"", "0", ""},
+		{"DEFAULT", "", ""},
+		{"DEFAULT", "  ", ""},
+		{"DEFAULT", "0", "0"},
+		{"DEFAULT", "''", "''"},
+		{"DEFAULT", " now() ", "now()"},
+		{"MATERIALIZED", "toDate(created_at)", "toDate(created_at)"},
+	}
+
+	for i, test := range tests {
+		if got := clickhouseDefault(test.Kind, test.Expression); got != test.Default {
+			t.Errorf("%d) %s %q: want %q, got %q", i, test.Kind, test.Expression, test.Default, got)
+		}
+	}
+
+	cols := bdb.FilterColumnsByDefault(true, []bdb.Column{
+		{Name: "a", Default: clickhouseDefault("DEFAULT", "0")},
+		{Name: "b", Default: clickhouseDefault("DEFAULT", " ")},
+		{Name: "c", Default: clickhouseDefault("", "")},
+	})
+	if len(cols) != 1 || cols[0].Name != "a" {
+		t.Errorf("want only a to have a default, got %#v", cols)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+	}{
+		{"Map(String, UInt64)", "map[string]uint64"},
+		{"Map(String,UInt64)", "map[string]uint64"},
+		{"Map( String , Array(Int8) )", "map[string][]int8"},
+		{"Map(LowCardinality(String), Nullable(Float64))", "map[string]null.Float64"},
+		{"Map(UInt32, Map(String, Array(Nullable(String))))", "map[uint32]map[string][]null.String"},
+		{"Map(String, Decimal(18, 4))", "map[string]types.Decimal"},
+		{"Map(Array(String), UInt8)", "[]byte"},
+		{"Array(Map(String, String))", "[]map[string]string"},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeBool(t *testing.T) {
+	// TinyintAsBool is a global, t.Parallel() cannot be used
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+	}{
+		{"Bool", "bool", false},
+		{"Boolean", "bool", false},
+		{"Nullable(Bool)", "null.Bool", true},
+		{"LowCardinality(Nullable(Bool))", "null.Bool", true},
+		{"Array(Bool)", "[]bool", false},
+	}
+
+	defer func() { TinyintAsBool = false }()
+
+	m := &ClickhouseDriver{}
+	for _, tinyintAsBool := range []bool{false, true} {
+		TinyintAsBool = tinyintAsBool
+		for i, test := range tests {
+			col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+			if col.Type != test.Type || col.Nullable != test.Nullable {
+				t.Errorf("%d) %s (tinyint as bool %t): want %s, nullable %t, got %s, %t", i, test.FullDBType,
+					tinyintAsBool, test.Type, test.Nullable, col.Type, col.Nullable)
+			}
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeGeo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+	}{
+		{"Point", "types.Point"},
+		{"Ring", "types.Ring"},
+		{"LineString", "types.Ring"},
+		{"Polygon", "types.Polygon"},
+		{"MultiLineString", "types.Polygon"},
+		{"MultiPolygon", "types.MultiPolygon"},
+		{"Array(Point)", "[]types.Point"},
+		{"Array(Polygon)", "[]types.Polygon"},
+		{"Map(String, Point)", "map[string]types.Point"},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable {
+			t.Errorf("%d) %s: want not null", i, test.FullDBType)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeAggregateFunction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType     string
+		Type           string
+		Nullable       bool
+		AggregateState bool
+	}{
+		{"SimpleAggregateFunction(sum, UInt64)", "uint64", false, false},
+		{"SimpleAggregateFunction(anyLast, Nullable(String))", "null.String", true, false},
+		{"SimpleAggregateFunction(any, LowCardinality(String))", "string", false, false},
+		{"SimpleAggregateFunction(groupUniqArrayArray, Array(UInt32))", "[]uint32", false, false},
+		{"Array(SimpleAggregateFunction(max, Int8))", "[]int8", false, false},
+		{"AggregateFunction(sum, UInt64)", "[]byte", false, true},
+		{"AggregateFunction(quantiles(0.5, 0.9), Float64)", "[]byte", false, true},
+		{"AggregateFunction(argMax, String, DateTime)", "[]byte", false, true},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+		if col.AggregateState != test.AggregateState {
+			t.Errorf("%d) %s: want aggregate state %t, got %t", i, test.FullDBType, test.AggregateState, col.AggregateState)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeTuple(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Elements   []bdb.TupleElement
+	}{
+		{
+			FullDBType: "Tuple(String, UInt8)",
+			Elements: []bdb.TupleElement{
+				{DBType: "String", Type: "string"},
+				{DBType: "UInt8", Type: "uint8"},
+			},
+		},
+		{
+			FullDBType: "Tuple(a String, b Nullable(UInt8))",
+			Elements: []bdb.TupleElement{
+				{Name: "a", DBType: "String", Type: "string"},
+				{Name: "b", DBType: "Nullable(UInt8)", Type: "null.Uint8"},
+			},
+		},
+		{
+			FullDBType: "Tuple(Decimal(18, 4), DateTime('UTC'), Array(Tuple(String, Int8)))",
+			Elements: []bdb.TupleElement{
+				{DBType: "Decimal(18, 4)", Type: "types.Decimal"},
+				{DBType: "DateTime('UTC')", Type: "time.Time"},
+				{DBType: "Array(Tuple(String, Int8))", Type: "[][]interface{}"},
+			},
+		},
+		{
+			FullDBType: "Tuple(`first name` String, price Decimal(9, 2))",
+			Elements: []bdb.TupleElement{
+				{Name: "first name", DBType: "String", Type: "string"},
+				{Name: "price", DBType: "Decimal(9, 2)", Type: "types.Decimal"},
+			},
+		},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != "[]interface{}" {
+			t.Errorf("%d) %s: want type []interface{}, got %s", i, test.FullDBType, col.Type)
+		}
+		if !reflect.DeepEqual(col.TupleElements, test.Elements) {
+			t.Errorf("%d) %s: want elements %#v, got %#v", i, test.FullDBType, test.Elements, col.TupleElements)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeNested(t *testing.T) {
+	t.Parallel()
+
+	m := &ClickhouseDriver{}
+	col := m.TranslateColumnType(bdb.Column{
+		DBType:     "Nested",
+		FullDBType: "Nested(a String, b UInt8)",
+	})
+
+	if col.Type != "[][]interface{}" {
+		t.Errorf("want type [][]interface{}, got %s", col.Type)
+	}
+	want := []bdb.TupleElement{
+		{Name: "a", DBType: "String", Type: "string"},
+		{Name: "b", DBType: "UInt8", Type: "uint8"},
+	}
+	if !reflect.DeepEqual(col.TupleElements, want) {
+		t.Errorf("want fields %#v, got %#v", want, col.TupleElements)
+	}
+
+	// Flattened by the server, the fields are array columns of their own
+	col = m.TranslateColumnType(bdb.Column{Name: "n.b", DBType: "Array", FullDBType: "Array(UInt8)"})
+	if col.Type != "[]uint8" {
+		t.Errorf("want type []uint8, got %s", col.Type)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeFixedString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Length     int
+	}{
+		{"FixedString(16)", "types.FixedString", 16},
+		{"FixedString(255)", "types.FixedString", 255},
+		{"Nullable(FixedString(16))", "types.NullFixedString", 16},
+		{"LowCardinality(FixedString(255))", "types.FixedString", 255},
+		{"FixedString(abc)", "types.FixedString", 0},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.FixedStringLength != test.Length {
+			t.Errorf("%d) %s: want length %d, got %d", i, test.FullDBType, test.Length, col.FixedStringLength)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"JSON":                      "types.JSON",
+		"JSON(max_dynamic_paths=8)": "types.JSON",
+		"Object('json')":            "types.JSON",
+		"Object('JSON')":            "types.JSON",
+		`Object("json")`:            "types.JSON",
+		"Object(json)":              "types.JSON",
+		"Object( 'json' )":          "types.JSON",
+		"Object('xml')":             "[]byte",
+	}
+
+	m := &ClickhouseDriver{}
+	for fullType, want := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: fullType})
+		if col.Type != want {
+			t.Errorf("%s: want type %s, got %s", fullType, want, col.Type)
+		}
+	}
+}
+
+func TestClickhouseParseTTL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		DDL string
+		TTL string
+	}{
+		{"CREATE TABLE default.logs (`message` String) ENGINE = Log", ""},
+		{"CREATE TABLE default.events (`id` UInt64, `day` Date) ENGINE = MergeTree ORDER BY id SETTINGS index_granularity = 8192", ""},
+		{
+			"CREATE TABLE default.events (`id` UInt64, `day` Date) ENGINE = MergeTree PARTITION BY toYYYYMM(day) ORDER BY id TTL day + toIntervalMonth(1) SETTINGS index_granularity = 8192",
+			"day + toIntervalMonth(1)",
+		},
+		{
+			"CREATE TABLE default.events\n(\n    `id` UInt64,\n    `day` Date\n)\nENGINE = MergeTree\nORDER BY id\nTTL day + toIntervalWeek(1) DELETE WHERE id = 0,\n    day + toIntervalDay(1) TO VOLUME 'cold'",
+			"day + toIntervalWeek(1) DELETE WHERE id = 0, day + toIntervalDay(1) TO VOLUME 'cold'",
+		},
+		// The TTL of a column isn't the one of the table
+		{"CREATE TABLE default.events (`id` UInt64, `day` Date, `payload` String TTL day + toIntervalDay(7)) ENGINE = MergeTree ORDER BY id", ""},
+	}
+
+	for i, test := range tests {
+		if ttl := clickhouseParseTTL(test.DDL); ttl != test.TTL {
+			t.Errorf("%d) want ttl %q, got %q", i, test.TTL, ttl)
+		}
+	}
+}
+
+func TestClickhouseTableTTL(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select create_table_query from system.tables where name = \? and database = \?`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}).
+			AddRow("CREATE TABLE default.events (`id` UInt64, `day` Date) ENGINE = MergeTree ORDER BY id TTL day + toIntervalDay(30)"))
+	mock.ExpectQuery(`select create_table_query`).
+		WithArgs("logs", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}).
+			AddRow("CREATE TABLE default.logs (`message` String) ENGINE = Log"))
+	mock.ExpectQuery(`select create_table_query`).
+		WithArgs("dropped", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	tests := map[string]string{
+		"events":  "day + toIntervalDay(30)",
+		"logs":    "",
+		"dropped": "",
+	}
+	for _, name := range []string{"events", "logs", "dropped"} {
+		ttl, err := m.TableTTL("default", name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if ttl != tests[name] {
+			t.Errorf("%s: want ttl %q, got %q", name, tests[name], ttl)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeDate32(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+	}{
+		{"Date32", "time.Time", false},
+		{"Nullable(Date32)", "null.Time", true},
+		{"LowCardinality(Nullable(Date32))", "null.Time", true},
+		{"Array(Date32)", "[]time.Time", false},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+	}
+}
+
+func TestClickhouseTranslateColumnTypeNothing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Nullable   bool
+	}{
+		{"Nothing", false},
+		{"Nullable(Nothing)", true},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != "interface{}" {
+			t.Errorf("%d) %s: want type interface{}, got %s", i, test.FullDBType, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+		if !col.AutoGenerated {
+			t.Errorf("%d) %s: want the column read-only", i, test.FullDBType)
+		}
+		if col.UnknownType {
+			t.Errorf("%d) %s: want a known type", i, test.FullDBType)
+		}
+	}
+}
+
+func TestClickhouseUnknownType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		UnknownType string
+		FullDBType  string
+		Type        string
+	}{
+		{"", "IntervalDay", "[]byte"},
+		{"", "Nullable(IntervalDay)", "null.Bytes"},
+		{"[]byte", "Array(IntervalDay)", "[][]byte"},
+		{"string", "IntervalDay", "string"},
+		{"string", "Nullable(IntervalDay)", "null.String"},
+		{"string", "Map(String, IntervalDay)", "map[string]string"},
+		{"interface{}", "Nullable(IntervalDay)", "interface{}"},
+		{"interface{}", "String", "string"},
+		{"", "AggregateFunction(uniq, UInt64)", "[]byte"},
+		{"string", "AggregateFunction(uniq, UInt64)", "[]byte"},
+		{"interface{}", "AggregateFunction(quantiles(0.5, 0.9), Float64)", "[]byte"},
+		{"error", "AggregateFunction(sum, UInt64)", "[]byte"},
+	}
+
+	for i, test := range tests {
+		m := NewClickhouseDriver(ClickhouseDriverConfig{UnknownType: test.UnknownType})
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s with %q: want type %s, got %s", i, test.FullDBType, test.UnknownType, test.Type, col.Type)
+		}
+		if strings.HasPrefix(test.FullDBType, "AggregateFunction") && (col.UnknownType || !col.AggregateState) {
+			t.Errorf("%d) %s with %q: want a known aggregate state, got %#v", i, test.FullDBType, test.UnknownType, col)
+		}
+	}
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{UnknownType: "int"})
+	if err := m.Open(); err == nil || !strings.Contains(err.Error(), "unknown type") {
+		t.Errorf("want an invalid unknown type error, got %v", err)
+	}
+}
+
+func TestClickhouseUnknownTypeError(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("retention", "Nullable(IntervalDay)", "", "", "", 2)
+	}
+	mock.ExpectQuery(`from system.columns`).WithArgs("events", "default").WillReturnRows(columns())
+	mock.ExpectQuery(`from system.columns`).WithArgs("users", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}).
+			AddRow("id", "UInt64", "", "", "", 1).
+			AddRow("tags", "Array(LowCardinality(String))", "", "", "", 2))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{UnknownType: "error"})
+	m.dbConn = db
+
+	_, err = m.Columns("default", "events")
+	if errors.Cause(err) != ErrUnknownType {
+		t.Fatalf("want ErrUnknownType, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "events.retention") || !strings.Contains(err.Error(), "Nullable(IntervalDay)") {
+		t.Errorf("want the column and its type in the error, got %v", err)
+	}
+
+	// Known types go through
+	cols, err := m.Columns("default", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 {
+		t.Errorf("want the users columns, got %#v", cols)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTranslateColumnTypeBigInt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		FullDBType string
+		Type       string
+		Nullable   bool
+	}{
+		{"Int128", "types.BigInt", false},
+		{"UInt128", "types.BigInt", false},
+		{"Int256", "types.BigInt", false},
+		{"UInt256", "types.BigInt", false},
+		{"Nullable(Int128)", "types.NullBigInt", true},
+		{"Nullable(UInt256)", "types.NullBigInt", true},
+		{"Array(Int256)", "[]types.BigInt", false},
+	}
+
+	m := &ClickhouseDriver{}
+	for i, test := range tests {
+		col := m.TranslateColumnType(bdb.Column{FullDBType: test.FullDBType})
+		if col.Type != test.Type {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.FullDBType, test.Type, col.Type)
+		}
+		if col.Nullable != test.Nullable {
+			t.Errorf("%d) %s: want nullable %t, got %t", i, test.FullDBType, test.Nullable, col.Nullable)
+		}
+		if col.UnknownType {
+			t.Errorf("%d) %s: want a known type", i, test.FullDBType)
+		}
+	}
+}
+
+func TestClickhouseParseProjections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		DDL         string
+		Projections []bdb.Projection
+	}{
+		{"CREATE TABLE default.logs (`message` String) ENGINE = Log", nil},
+		{"CREATE VIEW default.daily AS SELECT day, count() FROM default.events GROUP BY day", nil},
+		{
+			"CREATE TABLE default.events (`id` UInt64, `day` Date, PROJECTION by_day (SELECT day, count() GROUP BY day)) ENGINE = MergeTree ORDER BY id",
+			[]bdb.Projection{{Name: "by_day", Query: "SELECT day, count() GROUP BY day"}},
+		},
+		{
+			"CREATE TABLE default.events\n(\n    `id` UInt64,\n    `name` String,\n    PROJECTION `by_name`\n    (\n        SELECT *\n        ORDER BY name\n    ),\n    PROJECTION totals (SELECT name, sum(id) GROUP BY name)\n)\nENGINE = MergeTree\nORDER BY id",
+			[]bdb.Projection{
+				{Name: "by_name", Query: "SELECT * ORDER BY name"},
+				{Name: "totals", Query: "SELECT name, sum(id) GROUP BY name"},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		if projections := clickhouseParseProjections(test.DDL); !reflect.DeepEqual(projections, test.Projections) {
+			t.Errorf("%d) want projections %#v, got %#v", i, test.Projections, projections)
+		}
+	}
+}
+
+func TestClickhouseTableProjections(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`select create_table_query from system.tables where name = \? and database = \?`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}).
+			AddRow("CREATE TABLE default.events (`id` UInt64, `day` Date, PROJECTION by_day (SELECT day, count() GROUP BY day)) ENGINE = MergeTree ORDER BY id"))
+	mock.ExpectQuery(`select create_table_query`).
+		WithArgs("logs", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"create_table_query"}).
+			AddRow("CREATE TABLE default.logs (`message` String) ENGINE = Log"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	projections, err := m.TableProjections("default", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bdb.Projection{{Name: "by_day", Query: "SELECT day, count() GROUP BY day"}}; !reflect.DeepEqual(projections, want) {
+		t.Errorf("want projections %#v, got %#v", want, projections)
+	}
+
+	projections, err = m.TableProjections("default", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projections) != 0 {
+		t.Errorf("want no projections, got %#v", projections)
+	}
+
+	// The DDL read for the projections is reused for the TTL
+	if ttl, err := m.TableTTL("default", "logs"); err != nil || ttl != "" {
+		t.Errorf("want no ttl, got %q, %v", ttl, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
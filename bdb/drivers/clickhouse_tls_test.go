@@ -0,0 +1,97 @@
+package drivers
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// TestClickhouseSecureSkipVerify connects to a TLS enabled server, usually
+// one with a self-signed certificate, and introspects a table through it.
+// It only runs when SQLBOILER_TEST_CLICKHOUSE_TLS_ADDR is set to the
+// host:port of the secure native port, along with the optional
+// SQLBOILER_TEST_CLICKHOUSE_TLS_USER, _PASSWORD and _DATABASE.
+func TestClickhouseSecureSkipVerify(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	addr := os.Getenv("SQLBOILER_TEST_CLICKHOUSE_TLS_ADDR")
+	if len(addr) == 0 {
+		t.Skip("SQLBOILER_TEST_CLICKHOUSE_TLS_ADDR not set")
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
+	if err != nil {
+		t.Skipf("no clickhouse server at %s: %v", addr, err)
+	}
+	conn.Close()
+
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database := os.Getenv("SQLBOILER_TEST_CLICKHOUSE_TLS_DATABASE")
+	if len(database) == 0 {
+		database = "default"
+	}
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{
+		Username:   os.Getenv("SQLBOILER_TEST_CLICKHOUSE_TLS_USER"),
+		Password:   os.Getenv("SQLBOILER_TEST_CLICKHOUSE_TLS_PASSWORD"),
+		Database:   database,
+		Host:       host,
+		Port:       port,
+		Secure:     true,
+		SkipVerify: true,
+	})
+	if err := m.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	connected, err := m.Ping()
+	if err != nil {
+		t.Fatalf("unable to connect over tls: %v", err)
+	}
+	if connected != addr {
+		t.Errorf("want to be connected to %s, got %s", addr, connected)
+	}
+	if len(m.ServerVersion()) == 0 {
+		t.Error("want the server version recorded")
+	}
+
+	const table = "sqlboiler_tls_test"
+	if _, err := m.dbConn.Exec("CREATE TABLE IF NOT EXISTS " + table + " (id UInt64, name String) ENGINE = MergeTree ORDER BY id"); err != nil {
+		t.Fatal(err)
+	}
+	defer m.dbConn.Exec("DROP TABLE IF EXISTS " + table)
+
+	tables, err := bdb.Tables(m, database, []string{table}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("want the %s table, got %#v", table, tables)
+	}
+
+	var names []string
+	for _, c := range tables[0].Columns {
+		names = append(names, c.Name)
+	}
+	if len(names) != 2 || names[0] != "id" || names[1] != "name" {
+		t.Errorf("want the id and name columns, got %v", names)
+	}
+	if pkey := tables[0].PKey; pkey == nil || len(pkey.Columns) != 1 || pkey.Columns[0] != "id" {
+		t.Errorf("want the id primary key, got %#v", pkey)
+	}
+}
@@ -0,0 +1,82 @@
+package drivers
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The errors of a failed introspection have one of these as their cause
+// when the reason is known, callers can tell them apart with errors.Cause.
+var (
+	// ErrTableNotFound is returned for a table or database missing from the
+	// database server
+	ErrTableNotFound = errors.New("table not found")
+
+	// ErrPermissionDenied is returned when the user isn't allowed to read
+	// the metadata of a table
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrEngineParse is returned when the engine of a table, along with its
+	// keys, can't be parsed
+	ErrEngineParse = errors.New("unable to parse the table engine")
+
+	// ErrNoColumns is returned for a table the database reports no columns for
+	ErrNoColumns = errors.New("no columns found")
+
+	// ErrUnknownType is returned for a column of a type the driver doesn't
+	// know when it's set to fail on them
+	ErrUnknownType = errors.New("unknown column type")
+)
+
+// rgxClickhouseException matches the errors of the exceptions the Clickhouse
+// server sends back, ex: code: 60, message: Table default.events doesn't exist
+var rgxClickhouseException = regexp.MustCompile(`^code: (\d+), message: `)
+
+// clickhouseErrorCauses are the known causes of Clickhouse exception codes
+var clickhouseErrorCauses = map[int]error{
+	60:  ErrTableNotFound,    // UNKNOWN_TABLE
+	81:  ErrTableNotFound,    // UNKNOWN_DATABASE
+	497: ErrPermissionDenied, // ACCESS_DENIED
+}
+
+// clickhouseError gives the exceptions of the server with a known code their
+// cause, other errors are returned as is.
+func clickhouseError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	match := rgxClickhouseException.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	code, _ := strconv.Atoi(match[1])
+	if cause, ok := clickhouseErrorCauses[code]; ok {
+		return withCause(err, cause)
+	}
+
+	return err
+}
+
+// causeError is an error given a known cause, errors.Cause returns the
+// cause while the error itself is kept for its message and Unwrap.
+type causeError struct {
+	err   error
+	cause error
+}
+
+// withCause gives err the cause
+func withCause(err, cause error) error {
+	return &causeError{err: err, cause: cause}
+}
+
+func (e *causeError) Error() string { return e.err.Error() + ": " + e.cause.Error() }
+
+// Cause returns the known cause of the error
+func (e *causeError) Cause() error { return e.cause }
+
+// Unwrap returns the error given the cause
+func (e *causeError) Unwrap() error { return e.err }
@@ -0,0 +1,195 @@
+package drivers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestClickhouseEngineParseError(t *testing.T) {
+	t.Parallel()
+
+	m := &ClickhouseDriver{}
+	bad := []string{
+		"MergeTree(date, (a, b)",
+		"MergeTree(date, (a, b), x)",
+		"ReplicatedMergeTree('/path')",
+		"MergeTree PARTITION BY date SETTINGS index_granularity = 8192",
+		"MergeTree ORDER BY id SETTINGS index_granularity = x",
+	}
+	for _, b := range bad {
+		if _, err := m.parseEngine(b); errors.Cause(err) != ErrEngineParse {
+			t.Errorf("%s: want ErrEngineParse, got %v", b, err)
+		}
+	}
+
+	if _, err := clickhouseParseDistributed("Distributed('cluster')"); errors.Cause(err) != ErrEngineParse {
+		t.Errorf("want ErrEngineParse, got %v", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events", "MergeTree PARTITION BY date"))
+
+	m = NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	_, err = m.PrimaryKeyInfo("default", "events")
+	if errors.Cause(err) != ErrEngineParse {
+		t.Errorf("want ErrEngineParse, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseTableNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events_dist", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}).
+			AddRow("events_dist", "Distributed('cluster', 'default', 'events', rand())"))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "engine_full"}))
+	mock.ExpectQuery(`from system.columns`).
+		WithArgs("dropped", "default").
+		WillReturnError(errors.New("code: 60, message: Table default.dropped doesn't exist."))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	if _, err := m.PrimaryKeyInfo("default", "events_dist"); errors.Cause(err) != ErrTableNotFound {
+		t.Errorf("want ErrTableNotFound for the missing local table, got %v", err)
+	}
+	if _, err := m.Columns("default", "dropped"); errors.Cause(err) != ErrTableNotFound {
+		t.Errorf("want ErrTableNotFound for the unknown table exception, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhousePermissionDeniedError(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`from system.columns`).
+		WithArgs("events", "default").
+		WillReturnError(errors.New("code: 497, message: bob: Not enough privileges."))
+	mock.ExpectQuery(`from system.columns`).
+		WithArgs("events", "default").
+		WillReturnError(errors.New("code: 159, message: Timeout exceeded"))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	_, err = m.Columns("default", "events")
+	if errors.Cause(err) != ErrPermissionDenied {
+		t.Errorf("want ErrPermissionDenied, got %v", err)
+	}
+
+	// Exceptions without a known cause are returned as is
+	_, err = m.Columns("default", "events")
+	if cause := errors.Cause(err); cause == ErrPermissionDenied || cause == ErrTableNotFound || err.Error() != "code: 159, message: Timeout exceeded" {
+		t.Errorf("want the exception as is, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseQueryRowError(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`select total_rows`).
+		WithArgs("events", "default").
+		WillReturnError(errors.New("code: 497, message: bob: Not enough privileges."))
+	mock.ExpectQuery(`select name, engine_full`).
+		WithArgs("dropped", "default").
+		WillReturnError(errors.New("code: 60, message: Table default.dropped doesn't exist."))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	if _, err := m.TableRowEstimate("default", "events"); errors.Cause(err) != ErrPermissionDenied {
+		t.Errorf("want ErrPermissionDenied, got %v", err)
+	}
+	if _, err := m.PrimaryKeyInfo("default", "dropped"); errors.Cause(err) != ErrTableNotFound {
+		t.Errorf("want ErrTableNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClickhouseErrorKeepsTheError(t *testing.T) {
+	t.Parallel()
+
+	exception := errors.New("code: 60, message: Table default.dropped doesn't exist.")
+	err := clickhouseError(exception)
+	if errors.Cause(err) != ErrTableNotFound {
+		t.Errorf("want ErrTableNotFound, got %v", err)
+	}
+	if unwrapped := err.(interface{ Unwrap() error }).Unwrap(); unwrapped != exception {
+		t.Errorf("want the exception kept, got %v", unwrapped)
+	}
+
+	m := &ClickhouseDriver{}
+	_, err = m.parseEngine("MergeTree ORDER BY id SETTINGS index_granularity = x")
+	if errors.Cause(err) != ErrEngineParse {
+		t.Errorf("want ErrEngineParse, got %v", err)
+	}
+	if unwrapped, ok := err.(interface{ Unwrap() error }); !ok || errors.Cause(unwrapped.Unwrap()) == ErrEngineParse {
+		t.Errorf("want the granularity error kept, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "bad index_granularity setting") {
+		t.Errorf("want the granularity error in the message, got %v", err)
+	}
+}
+
+func TestClickhouseNoColumnsError(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`from system.columns`).
+		WithArgs("events", "default").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "default_kind", "default_expression", "comment", "position"}))
+
+	m := NewClickhouseDriver(ClickhouseDriverConfig{})
+	m.dbConn = db
+
+	if _, err := m.Columns("default", "events"); errors.Cause(err) != ErrNoColumns {
+		t.Errorf("want ErrNoColumns, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,8 +1,11 @@
 package drivers
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 
@@ -22,6 +25,47 @@ var TinyintAsBool bool
 type MySQLDriver struct {
 	connStr string
 	dbConn  *sql.DB
+
+	// tlsConfig is registered by Open under mysqlTLSConfigName, which the
+	// connection string refers to
+	tlsConfig *MySQLTLSConfig
+}
+
+// mysqlTLSConfigName is the name the custom TLS config is registered with
+const mysqlTLSConfigName = "sqlboiler"
+
+// MySQLTLSConfig configures a TLS connection beyond what the tls parameter
+// of the DSN allows. CA, Cert and Key are paths to PEM files, Cert and Key
+// go together and are only needed for client authentication.
+type MySQLTLSConfig struct {
+	CA, Cert, Key string
+	ServerName    string
+}
+
+// build loads the files of c into a tls.Config
+func (c MySQLTLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{ServerName: c.ServerName}
+
+	if len(c.CA) != 0 {
+		pem, err := ioutil.ReadFile(c.CA)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificate found in %s", c.CA)
+		}
+	}
+
+	if len(c.Cert) != 0 || len(c.Key) != 0 {
+		cert, err := tls.LoadX509KeyPair(c.Cert, c.Key)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
 }
 
 // NewMySQLDriver takes the database connection details as parameters and
@@ -36,6 +80,17 @@ func NewMySQLDriver(user, pass, dbname, host string, port int, sslmode string) *
 	return &driver
 }
 
+// NewMySQLDriverTLS is NewMySQLDriver connecting with a custom TLS config,
+// it's registered with the mysql driver when the connection is opened.
+func NewMySQLDriverTLS(user, pass, dbname, host string, port int, tlsConfig MySQLTLSConfig) *MySQLDriver {
+	driver := MySQLDriver{
+		connStr:   MySQLBuildQueryString(user, pass, dbname, host, port, mysqlTLSConfigName),
+		tlsConfig: &tlsConfig,
+	}
+
+	return &driver
+}
+
 // MySQLBuildQueryString builds a query string for MySQL.
 func MySQLBuildQueryString(user, pass, dbname, host string, port int, sslmode string) string {
 	config := mysql.NewConfig()
@@ -62,6 +117,16 @@ func MySQLBuildQueryString(user, pass, dbname, host string, port int, sslmode st
 
 // Open opens the database connection using the connection string
 func (m *MySQLDriver) Open() error {
+	if m.tlsConfig != nil {
+		config, err := m.tlsConfig.build()
+		if err != nil {
+			return errors.Wrap(err, "unable to load the mysql tls config")
+		}
+		if err = mysql.RegisterTLSConfig(mysqlTLSConfigName, config); err != nil {
+			return errors.Wrap(err, "unable to register the mysql tls config")
+		}
+	}
+
 	var err error
 	m.dbConn, err = sql.Open("mysql", m.connStr)
 	if err != nil {
@@ -0,0 +1,127 @@
+package drivers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// writeTestCert writes a self-signed certificate and its key to dir.
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestMySQLTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlboiler_mysql_tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCert(t, dir)
+
+	tlsConfig := MySQLTLSConfig{CA: certFile, Cert: certFile, Key: keyFile, ServerName: "db.internal"}
+	config, err := tlsConfig.build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.RootCAs == nil || len(config.Certificates) != 1 || config.ServerName != "db.internal" {
+		t.Errorf("want the CA, the client certificate and the server name, got %#v", config)
+	}
+
+	m := NewMySQLDriverTLS("bob", "secret", "shop", "localhost", 3306, tlsConfig)
+	if !strings.Contains(m.connStr, "tls="+mysqlTLSConfigName) {
+		t.Errorf("want the dsn to refer to the tls config, got %s", m.connStr)
+	}
+
+	if err := m.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	// Parsing fails on unknown tls config names, it's registered by Open
+	dsn, err := mysql.ParseDSN(m.connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dsn.TLSConfig != mysqlTLSConfigName {
+		t.Errorf("want tls config %s, got %s", mysqlTLSConfigName, dsn.TLSConfig)
+	}
+
+	plain := NewMySQLDriver("bob", "secret", "shop", "localhost", 3306, "skip-verify")
+	if plain.tlsConfig != nil || !strings.Contains(plain.connStr, "tls=skip-verify") {
+		t.Errorf("want the sslmode used as is, got %s", plain.connStr)
+	}
+}
+
+func TestMySQLTLSConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "sqlboiler_mysql_tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err := ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []MySQLTLSConfig{
+		{CA: filepath.Join(dir, "missing.pem")},
+		{CA: notPEM},
+		{Cert: notPEM},
+	}
+
+	for _, test := range tests {
+		m := NewMySQLDriverTLS("bob", "", "shop", "localhost", 3306, test)
+		if err := m.Open(); err == nil {
+			t.Errorf("%#v: want an error", test)
+		}
+	}
+}
@@ -19,6 +19,41 @@ import (
 type PostgresDriver struct {
 	connStr string
 	dbConn  *sql.DB
+
+	// cockroach switches the introspection queries and type names to the
+	// ones understood by CockroachDB
+	cockroach bool
+
+	// schemas are generated together when set, their tables are named
+	// schema_table to avoid collisions
+	schemas []string
+
+	// tables are the schemas and names of the tables, filled by TableNames
+	tables map[string]postgresTable
+}
+
+// postgresTable is the location of a generated table in the database.
+type postgresTable struct {
+	schema, name string
+}
+
+// PostgresDriverConfig is config for postgres
+type PostgresDriverConfig struct {
+	User, Pass, DBName, Host string
+	Port                     int
+	SSLMode                  string
+
+	// CockroachDB switches the introspection to CockroachDB compatible
+	// queries and type names.
+	CockroachDB bool
+
+	// Schemas are introspected together in a single run instead of the
+	// schema given to the driver when set, table names are then prefixed
+	// with their schema.
+	Schemas []string
+
+	// SearchPath is set as the search_path of the connection, ex: "app, public".
+	SearchPath string
 }
 
 // NewPostgresDriver takes the database connection details as parameters and
@@ -33,6 +68,40 @@ func NewPostgresDriver(user, pass, dbname, host string, port int, sslmode string
 	return &driver
 }
 
+// NewCockroachDBDriver returns a PostgresDriver for a CockroachDB database.
+// CockroachDB speaks the postgres protocol but lacks parts of the catalog
+// the postgres queries rely on, and names its types differently.
+func NewCockroachDBDriver(user, pass, dbname, host string, port int, sslmode string) *PostgresDriver {
+	driver := NewPostgresDriver(user, pass, dbname, host, port, sslmode)
+	driver.cockroach = true
+
+	return driver
+}
+
+// NewPostgresDriverFromConfig returns a PostgresDriver for the config, like
+// NewPostgresDriver and NewCockroachDBDriver do with fewer options.
+func NewPostgresDriverFromConfig(config PostgresDriverConfig) *PostgresDriver {
+	driver := PostgresDriver{
+		connStr:   PostgresBuildQueryString(config.User, config.Pass, config.DBName, config.Host, config.Port, config.SSLMode),
+		cockroach: config.CockroachDB,
+		schemas:   config.Schemas,
+	}
+
+	if len(config.SearchPath) != 0 {
+		driver.connStr += " search_path=" + postgresQuoteValue(config.SearchPath)
+	}
+
+	return &driver
+}
+
+// postgresQuoteValue quotes a value of the connection string, which may
+// then hold spaces and commas.
+func postgresQuoteValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `'`, `\'`, -1)
+	return "'" + value + "'"
+}
+
 // PostgresBuildQueryString builds a query string.
 func PostgresBuildQueryString(user, pass, dbname, host string, port int, sslmode string) string {
 	parts := []string{}
@@ -90,19 +159,30 @@ func (m *PostgresDriver) UseTopClause() bool {
 func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = $1`)
-	args := []interface{}{schema}
+	schemas := p.schemas
+	nameExpr := "table_schema || '_' || table_name"
+	if len(schemas) == 0 {
+		schemas = []string{schema}
+		nameExpr = "table_name"
+	}
+
+	query := fmt.Sprintf(`select table_schema, table_name from information_schema.tables where table_schema in (%s)`, strmangle.Placeholders(true, len(schemas), 1, 1))
+	var args []interface{}
+	for _, s := range schemas {
+		args = append(args, s)
+	}
 	if len(whitelist) > 0 {
-		query += fmt.Sprintf(" and table_name in (%s);", strmangle.Placeholders(true, len(whitelist), 2, 1))
+		query += fmt.Sprintf(" and %s in (%s)", nameExpr, strmangle.Placeholders(true, len(whitelist), len(args)+1, 1))
 		for _, w := range whitelist {
 			args = append(args, w)
 		}
 	} else if len(blacklist) > 0 {
-		query += fmt.Sprintf(" and table_name not in (%s);", strmangle.Placeholders(true, len(blacklist), 2, 1))
+		query += fmt.Sprintf(" and %s not in (%s)", nameExpr, strmangle.Placeholders(true, len(blacklist), len(args)+1, 1))
 		for _, b := range blacklist {
 			args = append(args, b)
 		}
 	}
+	query += ";"
 
 	rows, err := p.dbConn.Query(query, args...)
 
@@ -110,23 +190,67 @@ func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string
 		return nil, err
 	}
 
+	p.tables = map[string]postgresTable{}
+
 	defer rows.Close()
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var tableSchema, table string
+		if err := rows.Scan(&tableSchema, &table); err != nil {
 			return nil, err
 		}
+
+		name := table
+		if len(p.schemas) != 0 {
+			name = tableSchema + "_" + table
+		}
+		p.tables[name] = postgresTable{schema: tableSchema, name: table}
 		names = append(names, name)
 	}
 
 	return names, nil
 }
 
+// table resolves a name returned by TableNames to the schema and the name
+// of the table in the database, other names are returned as is.
+func (p *PostgresDriver) table(schema, name string) (string, string) {
+	if t, ok := p.tables[name]; ok {
+		return t.schema, t.name
+	}
+
+	return schema, name
+}
+
+// tableName is the name a table of the schema is generated as
+func (p *PostgresDriver) tableName(schema, name string) string {
+	if len(p.schemas) == 0 {
+		return name
+	}
+
+	return schema + "_" + name
+}
+
+// SchemaName returns the qualified name of the table when several schemas
+// are generated together, ex: "analytics"."events".
+func (p *PostgresDriver) SchemaName(schema, tableName string) (string, error) {
+	if len(p.schemas) == 0 {
+		return "", nil
+	}
+
+	schema, tableName = p.table(schema, tableName)
+	return fmt.Sprintf(`"%s"."%s"`, schema, tableName), nil
+}
+
 // Columns takes a table name and attempts to retrieve the table information
 // from the database information_schema.columns. It retrieves the column names
 // and column types and returns those as a []Column after TranslateColumnType()
 // converts the SQL types to Go types, for example: "varchar" to "string"
 func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	schema, tableName = p.table(schema, tableName)
+
+	if p.cockroach {
+		return p.cockroachColumns(schema, tableName)
+	}
+
 	var columns []bdb.Column
 
 	rows, err := p.dbConn.Query(`
@@ -221,6 +345,8 @@ func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryK
 	pkey := &bdb.PrimaryKey{}
 	var err error
 
+	schema, tableName = p.table(schema, tableName)
+
 	query := `
 	select tc.constraint_name
 	from information_schema.table_constraints as tc
@@ -238,9 +364,19 @@ func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryK
 	select kcu.column_name
 	from   information_schema.key_column_usage as kcu
 	where  constraint_name = $1 and table_schema = $2;`
+	args := []interface{}{pkey.Name, schema}
+	if p.cockroach {
+		// CockroachDB names every primary key "primary"
+		queryColumns = `
+	select kcu.column_name
+	from   information_schema.key_column_usage as kcu
+	where  constraint_name = $1 and table_schema = $2 and table_name = $3
+	order by kcu.ordinal_position;`
+		args = append(args, tableName)
+	}
 
 	var rows *sql.Rows
-	if rows, err = p.dbConn.Query(queryColumns, pkey.Name, schema); err != nil {
+	if rows, err = p.dbConn.Query(queryColumns, args...); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
@@ -276,31 +412,39 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 		pgc.relname as source_table,
 		pgasrc.attname as source_column,
 		dstlookupname.relname as dest_table,
-		pgadst.attname as dest_column
+		pgadst.attname as dest_column,
+		dstns.nspname as dest_schema
 	from pg_namespace pgn
 		inner join pg_class pgc on pgn.oid = pgc.relnamespace and pgc.relkind = 'r'
 		inner join pg_constraint pgcon on pgn.oid = pgcon.connamespace and pgc.oid = pgcon.conrelid
 		inner join pg_class dstlookupname on pgcon.confrelid = dstlookupname.oid
+		inner join pg_namespace dstns on dstlookupname.relnamespace = dstns.oid
 		inner join pg_attribute pgasrc on pgc.oid = pgasrc.attrelid and pgasrc.attnum = ANY(pgcon.conkey)
 		inner join pg_attribute pgadst on pgcon.confrelid = pgadst.attrelid and pgadst.attnum = ANY(pgcon.confkey)
 	where pgn.nspname = $2 and pgc.relname = $1 and pgcon.contype = 'f'
 	`
+	if p.cockroach {
+		query = cockroachForeignKeysQuery
+	}
 
 	var rows *sql.Rows
 	var err error
+	name := tableName
+	schema, tableName = p.table(schema, tableName)
 	if rows, err = p.dbConn.Query(query, tableName, schema); err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
 		var fkey bdb.ForeignKey
-		var sourceTable string
+		var sourceTable, foreignSchema string
 
-		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		fkey.Table = name
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn, &foreignSchema)
 		if err != nil {
 			return nil, err
 		}
+		fkey.ForeignTable = p.tableName(foreignSchema, fkey.ForeignTable)
 
 		fkeys = append(fkeys, fkey)
 	}
@@ -316,6 +460,10 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
 func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if p.cockroach {
+		c = cockroachNormalizeType(c)
+	}
+
 	if c.Nullable {
 		switch c.DBType {
 		case "bigint", "bigserial":
@@ -408,6 +556,187 @@ func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 	return c
 }
 
+// cockroachForeignKeysQuery finds the foreign keys of a table through
+// information_schema since CockroachDB's pg_constraint is incomplete.
+const cockroachForeignKeysQuery = `
+	select
+		rc.constraint_name,
+		kcu.table_name as source_table,
+		kcu.column_name as source_column,
+		fkcu.table_name as dest_table,
+		fkcu.column_name as dest_column,
+		fkcu.table_schema as dest_schema
+	from information_schema.referential_constraints rc
+		inner join information_schema.key_column_usage kcu
+			on kcu.constraint_schema = rc.constraint_schema and kcu.constraint_name = rc.constraint_name and kcu.table_name = rc.table_name
+		inner join information_schema.key_column_usage fkcu
+			on fkcu.constraint_schema = rc.unique_constraint_schema and fkcu.constraint_name = rc.unique_constraint_name
+			and fkcu.table_name = rc.referenced_table_name and fkcu.ordinal_position = kcu.position_in_unique_constraint
+	where rc.table_name = $1 and rc.constraint_schema = $2
+	`
+
+// cockroachColumns is Columns for CockroachDB. It has no
+// information_schema.element_types and no pg_enum labels, the array
+// element type is taken from the udt_name instead (_int8 for INT8[]).
+func (p *PostgresDriver) cockroachColumns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	rows, err := p.dbConn.Query(`
+		select
+		c.column_name,
+		c.data_type,
+		c.udt_name,
+		c.column_default,
+		c.is_nullable = 'YES' as is_nullable,
+		exists(
+			select 1
+			from information_schema.statistics s
+			where s.table_schema = $1 and s.table_name = c.table_name and s.column_name = c.column_name and
+				s.non_unique = 'NO' and s.storing = 'NO' and s.implicit = 'NO' and
+				(select count(*) from information_schema.statistics s2
+				where s2.table_schema = $1 and s2.table_name = s.table_name and s2.index_name = s.index_name and
+					s2.storing = 'NO' and s2.implicit = 'NO') = 1
+		) as is_unique
+		from information_schema.columns as c
+		where c.table_name = $2 and c.table_schema = $1
+		order by c.ordinal_position;
+	`, schema, tableName)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colType, udtName string
+		var defaultValue *string
+		var nullable, unique bool
+		if err := rows.Scan(&colName, &colType, &udtName, &defaultValue, &nullable, &unique); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := bdb.Column{
+			Name:     colName,
+			DBType:   colType,
+			UDTName:  udtName,
+			Nullable: nullable,
+			Unique:   unique,
+		}
+		if strings.EqualFold(colType, "ARRAY") {
+			arrType := strings.TrimPrefix(udtName, "_")
+			column.DBType = "ARRAY"
+			column.ArrType = &arrType
+		}
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// cockroachTypes maps CockroachDB type names and aliases onto the
+// postgres names TranslateColumnType knows.
+var cockroachTypes = map[string]string{
+	"string":                      "text",
+	"text":                        "text",
+	"varchar":                     "character varying",
+	"char":                        "character",
+	"character":                   "character",
+	"character varying":           "character varying",
+	"name":                        "text",
+	"int":                         "bigint",
+	"int8":                        "bigint",
+	"int64":                       "bigint",
+	"integer":                     "bigint",
+	"bigint":                      "bigint",
+	"serial":                      "bigint",
+	"serial8":                     "bigint",
+	"bigserial":                   "bigint",
+	"int4":                        "integer",
+	"serial4":                     "integer",
+	"int2":                        "smallint",
+	"smallint":                    "smallint",
+	"serial2":                     "smallint",
+	"smallserial":                 "smallint",
+	"float":                       "double precision",
+	"float8":                      "double precision",
+	"double precision":            "double precision",
+	"float4":                      "real",
+	"real":                        "real",
+	"decimal":                     "decimal",
+	"dec":                         "decimal",
+	"numeric":                     "numeric",
+	"bool":                        "boolean",
+	"boolean":                     "boolean",
+	"bytes":                       "bytea",
+	"bytea":                       "bytea",
+	"blob":                        "bytea",
+	"date":                        "date",
+	"time":                        "time without time zone",
+	"time without time zone":      "time without time zone",
+	"timetz":                      "time with time zone",
+	"time with time zone":         "time with time zone",
+	"timestamp":                   "timestamp without time zone",
+	"timestamp without time zone": "timestamp without time zone",
+	"timestamptz":                 "timestamp with time zone",
+	"timestamp with time zone":    "timestamp with time zone",
+	"interval":                    "interval",
+	"uuid":                        "uuid",
+	"inet":                        "inet",
+	"json":                        "json",
+	"jsonb":                       "jsonb",
+	"bit":                         "bit",
+	"varbit":                      "bit varying",
+	"bit varying":                 "bit varying",
+}
+
+// cockroachNormalizeType rewrites a CockroachDB column type, ex: STRING(50),
+// INT8 or STRING[] into the postgres type names used by TranslateColumnType.
+func cockroachNormalizeType(c bdb.Column) bdb.Column {
+	dbType := strings.TrimSpace(c.DBType)
+
+	if strings.HasSuffix(dbType, "[]") {
+		arrType := cockroachTypeName(strings.TrimSuffix(dbType, "[]"))
+		c.DBType = "ARRAY"
+		c.ArrType = &arrType
+		return c
+	}
+
+	if strings.EqualFold(dbType, "ARRAY") {
+		c.DBType = "ARRAY"
+		if c.ArrType != nil {
+			arrType := cockroachTypeName(*c.ArrType)
+			c.ArrType = &arrType
+		}
+		return c
+	}
+
+	c.DBType = cockroachTypeName(dbType)
+	return c
+}
+
+// cockroachTypeName strips the arguments of a type, ex: STRING(50) and
+// returns the postgres name for it. Unknown types, ex: USER-DEFINED are
+// kept as they are.
+func cockroachTypeName(dbType string) string {
+	dbType = strings.TrimSpace(dbType)
+	if i := strings.IndexByte(dbType, '('); i >= 0 {
+		dbType = strings.TrimSpace(dbType[:i])
+	}
+
+	if name, ok := cockroachTypes[strings.ToLower(dbType)]; ok {
+		return name
+	}
+	return dbType
+}
+
 // getArrayType returns the correct boil.Array type for each database type
 func getArrayType(c bdb.Column) string {
 	switch *c.ArrType {
@@ -0,0 +1,278 @@
+package drivers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPostgresCockroachDBTranslateColumnType(t *testing.T) {
+	t.Parallel()
+
+	p := &PostgresDriver{cockroach: true}
+
+	tests := []struct {
+		DBType   string
+		ArrType  string
+		Nullable bool
+		Want     string
+		WantDB   string
+	}{
+		{DBType: "STRING", Want: "string", WantDB: "text"},
+		{DBType: "STRING(50)", Nullable: true, Want: "null.String", WantDB: "text"},
+		{DBType: "INT8", Want: "int64", WantDB: "bigint"},
+		{DBType: "INT", Nullable: true, Want: "null.Int64", WantDB: "bigint"},
+		{DBType: "INT4", Want: "int", WantDB: "integer"},
+		{DBType: "INT2", Want: "int16", WantDB: "smallint"},
+		{DBType: "FLOAT8", Want: "float64", WantDB: "double precision"},
+		{DBType: "FLOAT4", Want: "float32", WantDB: "real"},
+		{DBType: "DECIMAL(10,2)", Want: "float64", WantDB: "decimal"},
+		{DBType: "BOOL", Nullable: true, Want: "null.Bool", WantDB: "boolean"},
+		{DBType: "BYTES", Want: "[]byte", WantDB: "bytea"},
+		{DBType: "TIMESTAMPTZ", Want: "time.Time", WantDB: "timestamp with time zone"},
+		{DBType: "TIMESTAMP", Nullable: true, Want: "null.Time", WantDB: "timestamp without time zone"},
+		{DBType: "JSONB", Want: "types.JSON", WantDB: "jsonb"},
+		{DBType: "UUID", Want: "string", WantDB: "uuid"},
+		{DBType: "STRING[]", Want: "types.StringArray", WantDB: "ARRAYtext"},
+		{DBType: "ARRAY", ArrType: "int8", Want: "types.Int64Array", WantDB: "ARRAYbigint"},
+		{DBType: "bigint", Want: "int64", WantDB: "bigint"},
+		{DBType: "text", Want: "string", WantDB: "text"},
+	}
+
+	for i, test := range tests {
+		col := bdb.Column{DBType: test.DBType, Nullable: test.Nullable}
+		if len(test.ArrType) != 0 {
+			arrType := test.ArrType
+			col.ArrType = &arrType
+		}
+
+		col = p.TranslateColumnType(col)
+		if col.Type != test.Want {
+			t.Errorf("%d) %s: want type %s, got %s", i, test.DBType, test.Want, col.Type)
+		}
+		if col.DBType != test.WantDB {
+			t.Errorf("%d) %s: want db type %s, got %s", i, test.DBType, test.WantDB, col.DBType)
+		}
+	}
+}
+
+func TestPostgresCockroachDBColumns(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"column_name", "data_type", "udt_name", "column_default", "is_nullable", "is_unique"}).
+		AddRow("id", "INT8", "int8", "unique_rowid()", false, true).
+		AddRow("name", "STRING", "text", nil, false, false).
+		AddRow("tags", "ARRAY", "_text", nil, true, false)
+	mock.ExpectQuery(`from information_schema\.statistics`).
+		WithArgs("public", "pilots").
+		WillReturnRows(rows)
+
+	p := &PostgresDriver{dbConn: db, cockroach: true}
+	columns, err := p.Columns("public", "pilots")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arrType := "text"
+	want := []bdb.Column{
+		{Name: "id", DBType: "INT8", UDTName: "int8", Default: "unique_rowid()", Unique: true},
+		{Name: "name", DBType: "STRING", UDTName: "text"},
+		{Name: "tags", DBType: "ARRAY", UDTName: "_text", ArrType: &arrType, Nullable: true},
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, columns)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresCockroachDBKeys(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`select tc\.constraint_name`).
+		WithArgs("jets", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name"}).AddRow("primary"))
+	mock.ExpectQuery(`table_name = \$3`).
+		WithArgs("primary", "public", "jets").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+	mock.ExpectQuery(`from information_schema\.referential_constraints`).
+		WithArgs("jets", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "source_table", "source_column", "dest_table", "dest_column", "dest_schema"}).
+			AddRow("fk_pilot_id_ref_pilots", "jets", "pilot_id", "pilots", "id", "public"))
+
+	p := &PostgresDriver{dbConn: db, cockroach: true}
+
+	pkey, err := p.PrimaryKeyInfo("public", "jets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&bdb.PrimaryKey{Name: "primary", Columns: []string{"id"}}); !reflect.DeepEqual(pkey, want) {
+		t.Errorf("want %#v, got %#v", want, pkey)
+	}
+
+	fkeys, err := p.ForeignKeyInfo("public", "jets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bdb.ForeignKey{
+		{Table: "jets", Name: "fk_pilot_id_ref_pilots", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fkeys, want) {
+		t.Errorf("want %#v, got %#v", want, fkeys)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSchema(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`where table_schema in \(\$1\) and table_name in \(\$2\);`).
+		WithArgs("app", "pilots").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).AddRow("app", "pilots"))
+	mock.ExpectQuery(`select tc\.constraint_name`).
+		WithArgs("pilots", "app").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name"}).AddRow("pilots_pkey"))
+	mock.ExpectQuery(`from information_schema\.key_column_usage`).
+		WithArgs("pilots_pkey", "app").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+
+	p := &PostgresDriver{dbConn: db}
+
+	names, err := p.TableNames("app", []string{"pilots"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"pilots"}) {
+		t.Errorf("want the pilots table, got %v", names)
+	}
+
+	if _, err := p.PrimaryKeyInfo("app", "pilots"); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, err := p.SchemaName("app", "pilots"); err != nil || len(name) != 0 {
+		t.Errorf("want no schema name for a single schema, got %q, %v", name, err)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSchemas(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`where table_schema in \(\$1,\$2\) and table_schema \|\| '_' \|\| table_name not in \(\$3\);`).
+		WithArgs("app", "billing", "app_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).
+			AddRow("app", "users").
+			AddRow("billing", "invoices"))
+	mock.ExpectQuery(`from information_schema\.statistics`).
+		WithArgs("billing", "invoices").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "udt_name", "column_default", "is_nullable", "is_unique"}).
+			AddRow("id", "INT8", "int8", nil, false, true))
+	mock.ExpectQuery(`select tc\.constraint_name`).
+		WithArgs("invoices", "billing").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name"}).AddRow("primary"))
+	mock.ExpectQuery(`table_name = \$3`).
+		WithArgs("primary", "billing", "invoices").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+	mock.ExpectQuery(`from information_schema\.referential_constraints`).
+		WithArgs("invoices", "billing").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "source_table", "source_column", "dest_table", "dest_column", "dest_schema"}).
+			AddRow("invoices_user_id_fkey", "invoices", "user_id", "users", "id", "app"))
+
+	p := NewPostgresDriverFromConfig(PostgresDriverConfig{CockroachDB: true, Schemas: []string{"app", "billing"}})
+	p.dbConn = db
+
+	names, err := p.TableNames("public", nil, []string{"app_migrations"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"app_users", "billing_invoices"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+
+	if _, err := p.Columns("public", "billing_invoices"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.PrimaryKeyInfo("public", "billing_invoices"); err != nil {
+		t.Fatal(err)
+	}
+
+	fkeys, err := p.ForeignKeyInfo("public", "billing_invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bdb.ForeignKey{
+		{Table: "billing_invoices", Name: "invoices_user_id_fkey", Column: "user_id", ForeignTable: "app_users", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fkeys, want) {
+		t.Errorf("want %#v, got %#v", want, fkeys)
+	}
+
+	name, err := p.SchemaName("public", "billing_invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != `"billing"."invoices"` {
+		t.Errorf("want the qualified name, got %s", name)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSearchPath(t *testing.T) {
+	t.Parallel()
+
+	p := NewPostgresDriverFromConfig(PostgresDriverConfig{
+		User:       "bob",
+		DBName:     "shop",
+		Host:       "localhost",
+		Port:       5432,
+		SSLMode:    "disable",
+		SearchPath: "app, o'brien",
+	})
+	if want := ` search_path='app, o\'brien'`; !strings.HasSuffix(p.connStr, want) {
+		t.Errorf("want the connection string to end with %s, got %s", want, p.connStr)
+	}
+
+	p = NewPostgresDriverFromConfig(PostgresDriverConfig{User: "bob", DBName: "shop", Host: "localhost", Port: 5432, SSLMode: "disable"})
+	if strings.Contains(p.connStr, "search_path") {
+		t.Errorf("want no search_path, got %s", p.connStr)
+	}
+}
@@ -0,0 +1,368 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	// Side-effect import sql driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// SQLiteDriver holds the database connection string and a handle
+// to the database connection.
+type SQLiteDriver struct {
+	connStr string
+	dbConn  *sql.DB
+}
+
+// NewSQLiteDriver takes the path to the database file (or a sqlite
+// connection string, ex: file::memory:?cache=shared) and returns a pointer
+// to a SQLiteDriver object. Note that it is required to call
+// SQLiteDriver.Open() and SQLiteDriver.Close() to open and close the
+// database connection once an object has been obtained.
+func NewSQLiteDriver(dbname string) *SQLiteDriver {
+	driver := SQLiteDriver{
+		connStr: dbname,
+	}
+
+	return &driver
+}
+
+// Open opens the database connection using the connection string
+func (s *SQLiteDriver) Open() error {
+	var err error
+	s.dbConn, err = sql.Open("sqlite3", s.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (s *SQLiteDriver) Close() {
+	s.dbConn.Close()
+}
+
+// UseLastInsertID returns true for sqlite
+func (s *SQLiteDriver) UseLastInsertID() bool {
+	return true
+}
+
+// UseTopClause returns false to indicate SQLite doesnt support SQL TOP clause
+func (s *SQLiteDriver) UseTopClause() bool {
+	return false
+}
+
+// TableNames connects to the sqlite database and retrieves all table names
+// from sqlite_master, leaving out sqlite's own internal tables. SQLite
+// has no schemas so the schema argument is ignored.
+func (s *SQLiteDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select name from sqlite_master where type = 'table' and name not like 'sqlite_%'`
+	var args []interface{}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and name in (%s)", strings.Repeat(",?", len(whitelist))[1:])
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and name not in (%s)", strings.Repeat(",?", len(blacklist))[1:])
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+	query += " order by name"
+
+	rows, err := s.dbConn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// Columns takes a table name and retrieves the table information using
+// PRAGMA table_info. It retrieves the column names and column types and
+// returns those as a []Column after TranslateColumnType() converts the SQL
+// types to Go types, for example: "varchar" to "string"
+func (s *SQLiteDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	unique, err := s.uniqueColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbConn.Query(`select name, type, "notnull", dflt_value, pk from pragma_table_info(?)`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pkeys []int
+	for rows.Next() {
+		var colName, colType string
+		var notNull bool
+		var defaultValue *string
+		var pk int
+		if err := rows.Scan(&colName, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		fullType := strings.ToLower(colType)
+		dbType := fullType
+		if i := strings.IndexByte(dbType, '('); i >= 0 {
+			dbType = strings.TrimSpace(dbType[:i])
+		}
+
+		column := bdb.Column{
+			Name:       colName,
+			FullDBType: fullType, // example: varchar(255) instead of varchar
+			DBType:     dbType,
+			Nullable:   !notNull && pk == 0,
+			Unique:     unique[colName],
+		}
+
+		if defaultValue != nil && *defaultValue != "NULL" {
+			column.Default = *defaultValue
+		}
+
+		if pk > 0 {
+			pkeys = append(pkeys, len(columns))
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// A single "integer primary key" column is an alias for the rowid,
+	// so it behaves just like an auto increment column.
+	if len(pkeys) == 1 {
+		pkey := &columns[pkeys[0]]
+		pkey.Unique = true
+		if pkey.FullDBType == "integer" && len(pkey.Default) == 0 {
+			pkey.Default = "auto_increment"
+		}
+	}
+
+	return columns, nil
+}
+
+// uniqueColumns finds the columns of tableName that are unique on their own
+// through a unique index or constraint.
+func (s *SQLiteDriver) uniqueColumns(tableName string) (map[string]bool, error) {
+	rows, err := s.dbConn.Query(`
+	select min(ii.name)
+	from pragma_index_list(?) as il
+	inner join pragma_index_info(il.name) as ii
+	where il."unique" = 1
+	group by il.name
+	having count(*) = 1`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unique := make(map[string]bool)
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan unique columns for table %s", tableName)
+		}
+		unique[column] = true
+	}
+
+	return unique, rows.Err()
+}
+
+// PrimaryKeyInfo looks up the primary key for a table. SQLite doesn't name
+// its primary keys so the name is made up from the table name.
+func (s *SQLiteDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	rows, err := s.dbConn.Query(`select name, pk from pragma_table_info(?) where pk > 0`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type pkeyColumn struct {
+		name  string
+		order int
+	}
+
+	var pkeyColumns []pkeyColumn
+	for rows.Next() {
+		var col pkeyColumn
+		if err = rows.Scan(&col.name, &col.order); err != nil {
+			return nil, err
+		}
+		pkeyColumns = append(pkeyColumns, col)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(pkeyColumns) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(pkeyColumns, func(i, j int) bool {
+		return pkeyColumns[i].order < pkeyColumns[j].order
+	})
+
+	pkey := &bdb.PrimaryKey{
+		Name: tableName + "_pkey",
+	}
+	for _, col := range pkeyColumns {
+		pkey.Columns = append(pkey.Columns, col.name)
+	}
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name using
+// PRAGMA foreign_key_list.
+func (s *SQLiteDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	var fkeys []bdb.ForeignKey
+
+	rows, err := s.dbConn.Query(`select "table", "from", "to" from pragma_foreign_key_list(?) order by id, seq`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fkey bdb.ForeignKey
+		var foreignColumn *string
+
+		fkey.Table = tableName
+		if err = rows.Scan(&fkey.ForeignTable, &fkey.Column, &foreignColumn); err != nil {
+			return nil, err
+		}
+
+		if foreignColumn != nil {
+			fkey.ForeignColumn = *foreignColumn
+		}
+		fkey.Name = fmt.Sprintf("%s_%s_fkey", tableName, fkey.Column)
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// "references table" without a column refers to the primary key
+	for i, fkey := range fkeys {
+		if len(fkey.ForeignColumn) != 0 {
+			continue
+		}
+
+		pkey, err := s.PrimaryKeyInfo(schema, fkey.ForeignTable)
+		if err != nil {
+			return nil, err
+		}
+		if pkey == nil || len(pkey.Columns) != 1 {
+			return nil, errors.Errorf("unable to resolve the foreign column of %s", fkey.Name)
+		}
+		fkeys[i].ForeignColumn = pkey.Columns[0]
+	}
+
+	return fkeys, nil
+}
+
+// TranslateColumnType converts sqlite database types to Go types, for example
+// "varchar" to "string" and "integer" to "int64". SQLite lets a column have
+// any type name, so the type is chosen following sqlite's type affinity
+// rules. It returns this parsed data as a Column object.
+func (s *SQLiteDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if c.Nullable {
+		switch sqliteAffinity(c.DBType) {
+		case "integer":
+			c.Type = "null.Int64"
+		case "bool":
+			c.Type = "null.Bool"
+		case "time":
+			c.Type = "null.Time"
+		case "text":
+			c.Type = "null.String"
+		case "blob":
+			c.Type = "null.Bytes"
+		default:
+			c.Type = "null.Float64"
+		}
+	} else {
+		switch sqliteAffinity(c.DBType) {
+		case "integer":
+			c.Type = "int64"
+		case "bool":
+			c.Type = "bool"
+		case "time":
+			c.Type = "time.Time"
+		case "text":
+			c.Type = "string"
+		case "blob":
+			c.Type = "[]byte"
+		default:
+			c.Type = "float64"
+		}
+	}
+
+	return c
+}
+
+// sqliteAffinity works out the affinity of a declared column type, see
+// https://www.sqlite.org/datatype3.html#determination_of_column_affinity.
+// Booleans and dates are split out since go-sqlite3 converts them when
+// the declared type says so.
+func sqliteAffinity(dbType string) string {
+	t := strings.ToLower(dbType)
+	switch {
+	case strings.Contains(t, "int"):
+		return "integer"
+	case strings.Contains(t, "bool"):
+		return "bool"
+	case strings.Contains(t, "date"), strings.Contains(t, "time"):
+		return "time"
+	case strings.Contains(t, "char"), strings.Contains(t, "clob"), strings.Contains(t, "text"):
+		return "text"
+	case strings.Contains(t, "blob"), len(t) == 0:
+		return "blob"
+	default:
+		// real, floa, doub and numeric affinity
+		return "real"
+	}
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (s *SQLiteDriver) RightQuote() byte {
+	return '"'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (s *SQLiteDriver) LeftQuote() byte {
+	return '"'
+}
+
+// IndexPlaceholders returns false to indicate SQLite doesnt support indexed placeholders
+func (s *SQLiteDriver) IndexPlaceholders() bool {
+	return false
+}
@@ -0,0 +1,173 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func testSQLiteDriver(t *testing.T) *SQLiteDriver {
+	t.Helper()
+
+	driver := NewSQLiteDriver(":memory:")
+	if err := driver.Open(); err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to :memory: is a new database, stick to one
+	driver.dbConn.SetMaxOpenConns(1)
+
+	schema := []string{
+		`create table pilots (
+			id integer primary key,
+			name varchar(255) not null,
+			email text unique,
+			created_at datetime
+		)`,
+		`create table jets (
+			id integer primary key,
+			pilot_id integer not null references pilots (id),
+			copilot_id integer references pilots,
+			name text not null default 'unnamed',
+			price decimal(10, 2),
+			active boolean not null,
+			photo blob
+		)`,
+		`create table pilot_languages (
+			pilot_id integer not null references pilots (id),
+			language text not null,
+			primary key (pilot_id, language)
+		)`,
+	}
+	for _, s := range schema {
+		if _, err := driver.dbConn.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return driver
+}
+
+func TestSQLiteTableNames(t *testing.T) {
+	t.Parallel()
+
+	driver := testSQLiteDriver(t)
+	defer driver.Close()
+
+	names, err := driver.TableNames("", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"jets", "pilot_languages", "pilots"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+
+	names, err = driver.TableNames("", []string{"jets"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"jets"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+
+	names, err = driver.TableNames("", nil, []string{"jets"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pilot_languages", "pilots"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+}
+
+func TestSQLiteColumns(t *testing.T) {
+	t.Parallel()
+
+	driver := testSQLiteDriver(t)
+	defer driver.Close()
+
+	columns, err := driver.Columns("", "pilots")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.Column{
+		{Name: "id", DBType: "integer", FullDBType: "integer", Default: "auto_increment", Unique: true},
+		{Name: "name", DBType: "varchar", FullDBType: "varchar(255)"},
+		{Name: "email", DBType: "text", FullDBType: "text", Nullable: true, Unique: true},
+		{Name: "created_at", DBType: "datetime", FullDBType: "datetime", Nullable: true},
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, columns)
+	}
+
+	columns, err = driver.Columns("", "jets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantTypes := map[string]string{
+		"id":         "int64",
+		"pilot_id":   "int64",
+		"copilot_id": "null.Int64",
+		"name":       "string",
+		"price":      "null.Float64",
+		"active":     "bool",
+		"photo":      "null.Bytes",
+	}
+	for _, c := range columns {
+		c = driver.TranslateColumnType(c)
+		if c.Type != wantTypes[c.Name] {
+			t.Errorf("%s: want type %s, got %s", c.Name, wantTypes[c.Name], c.Type)
+		}
+		if c.Name == "name" && c.Default != "'unnamed'" {
+			t.Errorf("wrong default: %s", c.Default)
+		}
+	}
+
+	columns, err = driver.Columns("", "pilot_languages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range columns {
+		if c.Unique || c.Nullable || len(c.Default) != 0 {
+			t.Errorf("composite primary key column should be plain: %#v", c)
+		}
+	}
+}
+
+func TestSQLitePrimaryKeyInfo(t *testing.T) {
+	t.Parallel()
+
+	driver := testSQLiteDriver(t)
+	defer driver.Close()
+
+	pkey, err := driver.PrimaryKeyInfo("", "pilot_languages")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &bdb.PrimaryKey{Name: "pilot_languages_pkey", Columns: []string{"pilot_id", "language"}}
+	if !reflect.DeepEqual(pkey, want) {
+		t.Errorf("want %#v, got %#v", want, pkey)
+	}
+}
+
+func TestSQLiteForeignKeyInfo(t *testing.T) {
+	t.Parallel()
+
+	driver := testSQLiteDriver(t)
+	defer driver.Close()
+
+	fkeys, err := driver.ForeignKeyInfo("", "jets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.ForeignKey{
+		{Table: "jets", Name: "jets_copilot_id_fkey", Column: "copilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+		{Table: "jets", Name: "jets_pilot_id_fkey", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fkeys, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, fkeys)
+	}
+}
@@ -3,6 +3,7 @@ package bdb
 
 import (
 	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/strmangle"
@@ -40,9 +41,53 @@ type Interface interface {
 	IndexPlaceholders() bool
 }
 
+// ViewChecker is implemented by drivers able to tell views apart from
+// regular tables.
+type ViewChecker interface {
+	IsView(schema, tableName string) (bool, error)
+}
+
+// SchemaNamer is implemented by drivers whose table names don't map directly
+// to a table of the schema, it returns the quoted and qualified name of the
+// table to use in queries, or an empty string to use the default one.
+type SchemaNamer interface {
+	SchemaName(schema, tableName string) (string, error)
+}
+
+// RowEstimator is implemented by drivers able to tell the approximate
+// number of rows of a table, zero when it's unknown.
+type RowEstimator interface {
+	TableRowEstimate(schema, tableName string) (uint64, error)
+}
+
+// IndexLister is implemented by drivers able to list the secondary indexes
+// of a table.
+type IndexLister interface {
+	IndexInfo(schema, tableName string) ([]Index, error)
+}
+
+// TTLReader is implemented by drivers able to tell how long the rows of a
+// table are kept, it returns the TTL expression or an empty string.
+type TTLReader interface {
+	TableTTL(schema, tableName string) (string, error)
+}
+
+// ProjectionLister is implemented by drivers able to list the projections
+// of a table.
+type ProjectionLister interface {
+	TableProjections(schema, tableName string) ([]Projection, error)
+}
+
 // Tables returns the metadata for all tables, minus the tables
 // specified in the blacklist.
 func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table, error) {
+	return TablesConcurrently(db, schema, whitelist, blacklist, 1)
+}
+
+// TablesConcurrently is Tables introspecting up to concurrency tables at
+// once, the driver must then be safe for concurrent use. The tables are in
+// the same order whatever the concurrency.
+func TablesConcurrently(db Interface, schema string, whitelist, blacklist []string, concurrency int) ([]Table, error) {
 	var err error
 
 	names, err := db.TableNames(schema, whitelist, blacklist)
@@ -52,33 +97,46 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 
 	sort.Strings(names)
 
-	var tables []Table
-	for _, name := range names {
-		t := Table{
-			Name: name,
-		}
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-		if t.Columns, err = db.Columns(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table column info (%s)", name)
-		}
+	tables := make([]Table, len(names))
+	errs := make([]error, len(names))
 
-		for i, c := range t.Columns {
-			t.Columns[i] = db.TranslateColumnType(c)
-		}
+	// The first error stops the remaining tables from being introspected
+	jobs := make(chan int)
+	failed := make(chan struct{})
+	var failOnce sync.Once
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if tables[i], errs[i] = table(db, schema, names[i], whitelist, blacklist); errs[i] != nil {
+					failOnce.Do(func() { close(failed) })
+					return
+				}
+			}
+		}()
+	}
 
-		if t.PKey, err = db.PrimaryKeyInfo(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table pkey info (%s)", name)
+Feed:
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-failed:
+			break Feed
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		if t.FKeys, err = db.ForeignKeyInfo(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table fkey info (%s)", name)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
 		}
-
-		filterForeignKeys(&t, whitelist, blacklist)
-
-		setIsJoinTable(&t)
-
-		tables = append(tables, t)
 	}
 
 	// Relationships have a dependency on foreign key nullability.
@@ -94,6 +152,73 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 	return tables, nil
 }
 
+// table fetches the metadata of a single table
+func table(db Interface, schema, name string, whitelist, blacklist []string) (Table, error) {
+	var err error
+
+	t := Table{
+		Name: name,
+	}
+
+	if vc, ok := db.(ViewChecker); ok {
+		if t.IsView, err = vc.IsView(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table view info (%s)", name)
+		}
+	}
+
+	if sn, ok := db.(SchemaNamer); ok {
+		if t.SchemaName, err = sn.SchemaName(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table schema name (%s)", name)
+		}
+	}
+
+	if t.Columns, err = db.Columns(schema, name); err != nil {
+		return t, errors.Wrapf(err, "unable to fetch table column info (%s)", name)
+	}
+
+	for i, c := range t.Columns {
+		t.Columns[i] = db.TranslateColumnType(c)
+	}
+
+	if t.PKey, err = db.PrimaryKeyInfo(schema, name); err != nil {
+		return t, errors.Wrapf(err, "unable to fetch table pkey info (%s)", name)
+	}
+
+	if t.FKeys, err = db.ForeignKeyInfo(schema, name); err != nil {
+		return t, errors.Wrapf(err, "unable to fetch table fkey info (%s)", name)
+	}
+
+	if est, ok := db.(RowEstimator); ok {
+		if t.RowEstimate, err = est.TableRowEstimate(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table row estimate (%s)", name)
+		}
+	}
+
+	if il, ok := db.(IndexLister); ok {
+		if t.Indexes, err = il.IndexInfo(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table index info (%s)", name)
+		}
+	}
+
+	if tr, ok := db.(TTLReader); ok {
+		if t.TTL, err = tr.TableTTL(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table ttl (%s)", name)
+		}
+	}
+
+	if pl, ok := db.(ProjectionLister); ok {
+		if t.Projections, err = pl.TableProjections(schema, name); err != nil {
+			return t, errors.Wrapf(err, "unable to fetch table projections (%s)", name)
+		}
+	}
+
+	filterForeignKeys(&t, whitelist, blacklist)
+
+	setIsJoinTable(&t)
+
+	return t, nil
+}
+
 // filterForeignKeys filter FK whose ForeignTable is not in whitelist or in blacklist
 func filterForeignKeys(t *Table, whitelist, blacklist []string) {
 	var fkeys []ForeignKey
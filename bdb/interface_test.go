@@ -1,8 +1,11 @@
 package bdb
 
 import (
+	"reflect"
+	"sync/atomic"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
@@ -165,6 +168,138 @@ func TestTables(t *testing.T) {
 	}
 }
 
+type testViewMockDriver struct {
+	testMockDriver
+}
+
+func (m testViewMockDriver) IsView(schema, tableName string) (bool, error) {
+	return tableName == "airports", nil
+}
+
+func TestTablesViews(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testViewMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range tables {
+		if want := table.Name == "airports"; table.IsView != want {
+			t.Errorf("%s: want IsView %t, got %t", table.Name, want, table.IsView)
+		}
+	}
+}
+
+type testRowEstimateMockDriver struct {
+	testMockDriver
+}
+
+func (m testRowEstimateMockDriver) TableRowEstimate(schema, tableName string) (uint64, error) {
+	if tableName == "airports" {
+		return 42, nil
+	}
+	return 0, nil
+}
+
+func TestTablesRowEstimate(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testRowEstimateMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range tables {
+		var want uint64
+		if table.Name == "airports" {
+			want = 42
+		}
+		if table.RowEstimate != want {
+			t.Errorf("%s: want RowEstimate %d, got %d", table.Name, want, table.RowEstimate)
+		}
+	}
+}
+
+type testIndexMockDriver struct {
+	testMockDriver
+}
+
+func (m testIndexMockDriver) IndexInfo(schema, tableName string) ([]Index, error) {
+	if tableName == "jets" {
+		return []Index{{Name: "name_idx", Type: "minmax", Expression: "name", Granularity: 1}}, nil
+	}
+	return nil, nil
+}
+
+func TestTablesIndexes(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testIndexMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range tables {
+		if want := table.Name == "jets"; (len(table.Indexes) == 1) != want {
+			t.Errorf("%s: want an index %t, got %#v", table.Name, want, table.Indexes)
+		}
+	}
+}
+
+func TestTablesConcurrently(t *testing.T) {
+	t.Parallel()
+
+	serial, err := Tables(testViewMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, concurrency := range []int{0, 2, 3, 16} {
+		tables, err := TablesConcurrently(testViewMockDriver{}, "public", nil, nil, concurrency)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tables, serial) {
+			t.Errorf("concurrency %d: want the same tables as the serial introspection, got %#v", concurrency, tables)
+		}
+	}
+}
+
+type testFailingMockDriver struct {
+	testMockDriver
+
+	columns *int32
+}
+
+func (m testFailingMockDriver) Columns(schema, tableName string) ([]Column, error) {
+	atomic.AddInt32(m.columns, 1)
+	if tableName == "jets" {
+		return nil, errors.New("connection reset")
+	}
+	return m.testMockDriver.Columns(schema, tableName)
+}
+
+func TestTablesConcurrentlyError(t *testing.T) {
+	t.Parallel()
+
+	for _, concurrency := range []int{1, 4} {
+		var columns int32
+		_, err := TablesConcurrently(testFailingMockDriver{columns: &columns}, "public", nil, nil, concurrency)
+		if err == nil {
+			t.Fatalf("concurrency %d: want an error", concurrency)
+		}
+		if want := "unable to fetch table column info (jets): connection reset"; err.Error() != want {
+			t.Errorf("concurrency %d: want %q, got %q", concurrency, want, err)
+		}
+
+		// Tables are sorted, serially nothing past jets is introspected
+		if concurrency == 1 && columns != 3 {
+			t.Errorf("want introspection to stop at jets, got %d tables", columns)
+		}
+	}
+}
+
 func TestFilterForeignKeys(t *testing.T) {
 	t.Parallel()
 
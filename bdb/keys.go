@@ -6,6 +6,13 @@ import "fmt"
 type PrimaryKey struct {
 	Name    string
 	Columns []string
+
+	// Clickhouse only bits
+	// PartitionKey is the partitioning expression of a MergeTree table
+	PartitionKey string
+	// SortingKey are the expressions of the ORDER BY of a MergeTree table,
+	// the primary key columns are always a prefix of it
+	SortingKey []string
 }
 
 // ForeignKey represents a foreign key constraint in a database
@@ -22,6 +29,27 @@ type ForeignKey struct {
 	ForeignColumnUnique   bool
 }
 
+// Index is a secondary index of a table, like a Clickhouse data skipping
+// index
+type Index struct {
+	Name string
+	// Type is the kind of index, ex: minmax, set(100) or bloom_filter
+	Type string
+	// Expression is the indexed expression
+	Expression string
+	// Granularity is the number of granules an index entry covers, zero
+	// when it doesn't apply
+	Granularity uint64
+}
+
+// Projection is a projection of a table, an alternative layout of its rows
+// kept up to date by the database, ex: the rows aggregated by day
+type Projection struct {
+	Name string
+	// Query is the SELECT query of the projection
+	Query string
+}
+
 // SQLColumnDef formats a column name and type like an SQL column definition.
 type SQLColumnDef struct {
 	Name string
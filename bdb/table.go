@@ -14,6 +14,21 @@ type Table struct {
 	FKeys []ForeignKey
 
 	IsJoinTable bool
+	// IsView is set for views, they're read-only like the tables without
+	// a primary key, see IsReadOnly.
+	IsView bool
+	// RowEstimate is the approximate number of rows of the table, zero
+	// when the driver can't tell.
+	RowEstimate uint64
+	// Indexes are the secondary indexes of the table, for drivers able
+	// to list them.
+	Indexes []Index
+	// TTL is the expression of the table TTL clause, ex: date + INTERVAL
+	// 1 MONTH, empty when rows are kept forever.
+	TTL string
+	// Projections are the projections of the table, for drivers able to
+	// list them.
+	Projections []Projection
 
 	ToOneRelationships  []ToOneRelationship
 	ToManyRelationships []ToManyRelationship
@@ -41,6 +56,13 @@ func (t Table) GetColumn(name string) (col Column) {
 	panic(fmt.Sprintf("could not find column name: %s", name))
 }
 
+// IsReadOnly reports whether the table can only be read, no mutation
+// helpers are generated for views and for the tables without a primary key,
+// such as those of the Clickhouse Log and Memory engines.
+func (t Table) IsReadOnly() bool {
+	return t.IsView || t.PKey == nil
+}
+
 // CanLastInsertID checks the following:
 // 1. Is there only one primary key?
 // 2. Does the primary key column have a default value?
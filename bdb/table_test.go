@@ -66,6 +66,26 @@ func TestGetColumnMissing(t *testing.T) {
 	table.GetColumn("missing")
 }
 
+func TestIsReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Table    Table
+		ReadOnly bool
+	}{
+		{Table{PKey: &PrimaryKey{Columns: []string{"id"}}}, false},
+		{Table{PKey: &PrimaryKey{Columns: []string{"id"}}, IsView: true}, true},
+		{Table{IsView: true}, true},
+		{Table{}, true},
+	}
+
+	for i, test := range tests {
+		if got := test.Table.IsReadOnly(); got != test.ReadOnly {
+			t.Errorf("%d) want read-only %t, got %t", i, test.ReadOnly, got)
+		}
+	}
+}
+
 func TestCanLastInsertID(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,9 @@
 package boil
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 // Executor can perform SQL queries.
 type Executor interface {
@@ -9,6 +12,16 @@ type Executor interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
+// ContextExecutor can perform SQL queries with a context, on top of being
+// able to execute queries.
+type ContextExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+
+	Executor
+}
+
 // Transactor can commit and rollback, on top of being able to execute queries.
 type Transactor interface {
 	Commit() error
@@ -17,11 +30,22 @@ type Transactor interface {
 	Executor
 }
 
+// ContextPreparer prepares statements with a context, batches are inserted
+// into Clickhouse by executing a prepared INSERT for each row.
+type ContextPreparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
 // Beginner begins transactions.
 type Beginner interface {
 	Begin() (*sql.Tx, error)
 }
 
+// ContextBeginner begins transactions with a context.
+type ContextBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
 // Begin a transaction
 func Begin() (Transactor, error) {
 	creator, ok := currentDB.(Beginner)
@@ -14,7 +14,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/bdb"
-	"github.com/volatiletech/sqlboiler/bdb/drivers"
 	"github.com/volatiletech/sqlboiler/queries"
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
@@ -27,6 +26,10 @@ const (
 	templatesSingletonTestDirectory = "templates_test/singleton"
 
 	templatesTestMainDirectory = "templates_test/main_test"
+
+	// defaultConcurrency is the number of tables introspected at once
+	// unless configured otherwise
+	defaultConcurrency = 4
 )
 
 // State holds the global data needed by most pieces to run
@@ -37,6 +40,10 @@ type State struct {
 	Tables  []bdb.Table
 	Dialect queries.Dialect
 
+	// Aliases name the models of the tables renamed by the replacements
+	// or the table name transforms
+	Aliases tableAliases
+
 	Templates              *templateList
 	TestTemplates          *templateList
 	SingletonTemplates     *templateList
@@ -45,6 +52,15 @@ type State struct {
 	TestMainTemplate *template.Template
 
 	Importer importer
+
+	// generated are the names of the files written by Run
+	generated []string
+}
+
+// pinger is implemented by drivers able to check their connection, and
+// to fall back on other hosts, before introspection starts.
+type pinger interface {
+	Ping() (host string, err error)
 }
 
 // New creates a new state based off of the config
@@ -53,7 +69,17 @@ func New(config *Config) (*State, error) {
 		Config: config,
 	}
 
-	err := s.initDriver(config.DriverName)
+	err := ApplyEnv(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the database config from the environment")
+	}
+	config.deriveSchema()
+
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = s.initDriver(config.DriverName)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +89,25 @@ func New(config *Config) (*State, error) {
 		return nil, errors.Wrap(err, "unable to connect to the database")
 	}
 
+	// Fail early when the database can't be reached instead of half way
+	// through introspection
+	if p, ok := s.Driver.(pinger); ok {
+		host, err := p.Ping()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to ping the database")
+		}
+		if s.Config.Debug {
+			fmt.Printf("connected to %s\n", host)
+		}
+	}
+
+	s.Importer = newImporter()
+	s.Importer.BasedOnType.overridePackages(config.ImportPaths)
+	if config.DriverName == "clickhouse" {
+		// For the context-taking batch insert
+		s.Importer.Standard = combineImports(s.Importer.Standard, imports{standard: importList{`"context"`}})
+	}
+
 	err = s.initTables(config.Schema, config.WhitelistTables, config.BlacklistTables)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to initialize tables")
@@ -76,9 +121,23 @@ func New(config *Config) (*State, error) {
 		fmt.Printf("%s\n", b)
 	}
 
-	err = s.initOutFolder()
+	if s.Config.SoftDeletes {
+		err = checkSoftDeletes(s.Tables, s.Config.AutoColumns.withDefaults().Deleted)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to use soft deletes")
+		}
+	}
+
+	err = s.initNames()
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to initialize the output folder")
+		return nil, errors.Wrap(err, "unable to initialize model names")
+	}
+
+	if !s.Config.DryRun {
+		err = s.initOutFolder()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to initialize the output folder")
+		}
 	}
 
 	err = s.initTemplates()
@@ -91,26 +150,39 @@ func New(config *Config) (*State, error) {
 		return nil, errors.Wrap(err, "unable to initialize struct tags")
 	}
 
-	s.Importer = newImporter()
-
 	return s, nil
 }
 
 // Run executes the sqlboiler templates and outputs them to files based on the
-// state given.
+// state given. In dry run mode the summary of the tables is written to stdout
+// instead.
 func (s *State) Run(includeTests bool) error {
+	if s.Config.DryRun {
+		return s.WriteSummary(os.Stdout)
+	}
+
+	autoColumns := s.Config.AutoColumns.withDefaults()
+	dbTagCasing, jsonTagCasing := s.Config.tagCasings()
+
 	singletonData := &templateData{
-		Tables:           s.Tables,
-		Schema:           s.Config.Schema,
-		DriverName:       s.Config.DriverName,
-		UseLastInsertID:  s.Driver.UseLastInsertID(),
-		PkgName:          s.Config.PkgName,
-		NoHooks:          s.Config.NoHooks,
-		NoAutoTimestamps: s.Config.NoAutoTimestamps,
-		StructTagCasing:  s.Config.StructTagCasing,
-		Dialect:          s.Dialect,
-		LQ:               strmangle.QuoteCharacter(s.Dialect.LQ),
-		RQ:               strmangle.QuoteCharacter(s.Dialect.RQ),
+		Tables:               s.Tables,
+		Aliases:              s.Aliases,
+		Schema:               s.Config.Schema,
+		DriverName:           s.Config.DriverName,
+		UseLastInsertID:      s.Driver.UseLastInsertID(),
+		PkgName:              s.Config.PkgName,
+		NoHooks:              s.Config.NoHooks,
+		NoAutoTimestamps:     s.Config.NoAutoTimestamps,
+		AutoTimestampsExempt: s.Config.AutoTimestampsExempt,
+		AutoColumns:          autoColumns,
+		ModelsOnly:           s.Config.ModelsOnly,
+		ContextOnly:          s.Config.ContextOnly,
+		StructTagCasing:      s.Config.StructTagCasing,
+		DBTagCasing:          dbTagCasing,
+		JSONTagCasing:        jsonTagCasing,
+		Dialect:              s.Dialect,
+		LQ:                   strmangle.QuoteCharacter(s.Dialect.LQ),
+		RQ:                   strmangle.QuoteCharacter(s.Dialect.RQ),
 
 		StringFuncs: templateStringMappers,
 	}
@@ -119,7 +191,8 @@ func (s *State) Run(includeTests bool) error {
 		return errors.Wrap(err, "singleton template output")
 	}
 
-	if !s.Config.NoTests && includeTests {
+	// Models alone have nothing to test
+	if !s.Config.NoTests && !s.Config.ModelsOnly && includeTests {
 		if err := generateTestMainOutput(s, singletonData); err != nil {
 			return errors.Wrap(err, "unable to generate TestMain output")
 		}
@@ -135,19 +208,27 @@ func (s *State) Run(includeTests bool) error {
 		}
 
 		data := &templateData{
-			Tables:           s.Tables,
-			Table:            table,
-			Schema:           s.Config.Schema,
-			DriverName:       s.Config.DriverName,
-			UseLastInsertID:  s.Driver.UseLastInsertID(),
-			PkgName:          s.Config.PkgName,
-			NoHooks:          s.Config.NoHooks,
-			NoAutoTimestamps: s.Config.NoAutoTimestamps,
-			StructTagCasing:  s.Config.StructTagCasing,
-			Tags:             s.Config.Tags,
-			Dialect:          s.Dialect,
-			LQ:               strmangle.QuoteCharacter(s.Dialect.LQ),
-			RQ:               strmangle.QuoteCharacter(s.Dialect.RQ),
+			Tables:               s.Tables,
+			Aliases:              s.Aliases,
+			Table:                table,
+			Schema:               s.Config.Schema,
+			DriverName:           s.Config.DriverName,
+			UseLastInsertID:      s.Driver.UseLastInsertID(),
+			PkgName:              s.Config.PkgName,
+			NoHooks:              s.Config.NoHooks,
+			NoAutoTimestamps:     s.Config.NoAutoTimestamps,
+			AutoTimestampsExempt: s.Config.AutoTimestampsExempt,
+			AutoColumns:          autoColumns,
+			ModelsOnly:           s.Config.ModelsOnly,
+			ContextOnly:          s.Config.ContextOnly,
+			SoftDeletes:          s.Config.SoftDeletes,
+			StructTagCasing:      s.Config.StructTagCasing,
+			DBTagCasing:          dbTagCasing,
+			JSONTagCasing:        jsonTagCasing,
+			Tags:                 s.Config.Tags,
+			Dialect:              s.Dialect,
+			LQ:                   strmangle.QuoteCharacter(s.Dialect.LQ),
+			RQ:                   strmangle.QuoteCharacter(s.Dialect.RQ),
 
 			StringFuncs: templateStringMappers,
 		}
@@ -157,14 +238,25 @@ func (s *State) Run(includeTests bool) error {
 			return errors.Wrap(err, "unable to generate output")
 		}
 
-		// Generate the test templates
-		if !s.Config.NoTests && includeTests {
+		// Generate the test templates, views and tables without a primary
+		// key are read-only so the tests have no way of seeding them
+		if !s.Config.NoTests && !s.Config.ModelsOnly && includeTests && !table.IsReadOnly() {
 			if err := generateTestOutput(s, data); err != nil {
 				return errors.Wrap(err, "unable to generate test output")
 			}
 		}
 	}
 
+	if err := s.WriteUnknownTypes(os.Stderr); err != nil {
+		return errors.Wrap(err, "unable to report the unknown types")
+	}
+
+	// The manifest goes last so a failed run keeps the previous one along
+	// with its stale files
+	if err := s.writeManifest(os.Stderr); err != nil {
+		return errors.Wrap(err, "unable to write the manifest")
+	}
+
 	return nil
 }
 
@@ -220,7 +312,12 @@ func (s *State) processReplacements() error {
 		return err
 	}
 
-	for _, replace := range s.Config.Replacements {
+	_, replacements, err := splitNameReplacements(s.Config.Replacements)
+	if err != nil {
+		return err
+	}
+
+	for _, replace := range replacements {
 		splits := strings.Split(replace, ":")
 		if len(splits) != 2 {
 			return errors.Errorf("replace parameters must have 2 arguments, given: %s", replace)
@@ -288,56 +385,7 @@ func getBasePath(baseDirConfig string) (string, error) {
 // driver flag value. If an invalid flag string is provided an error is returned.
 func (s *State) initDriver(driverName string) error {
 	// Create a driver based off driver flag
-	switch driverName {
-	case "postgres":
-		s.Driver = drivers.NewPostgresDriver(
-			s.Config.Postgres.User,
-			s.Config.Postgres.Pass,
-			s.Config.Postgres.DBName,
-			s.Config.Postgres.Host,
-			s.Config.Postgres.Port,
-			s.Config.Postgres.SSLMode,
-		)
-	case "mysql":
-		s.Driver = drivers.NewMySQLDriver(
-			s.Config.MySQL.User,
-			s.Config.MySQL.Pass,
-			s.Config.MySQL.DBName,
-			s.Config.MySQL.Host,
-			s.Config.MySQL.Port,
-			s.Config.MySQL.SSLMode,
-		)
-	case "mssql":
-		s.Driver = drivers.NewMSSQLDriver(
-			s.Config.MSSQL.User,
-			s.Config.MSSQL.Pass,
-			s.Config.MSSQL.DBName,
-			s.Config.MSSQL.Host,
-			s.Config.MSSQL.Port,
-			s.Config.MSSQL.SSLMode,
-		)
-	case "clickhouse":
-		s.Driver = drivers.NewClickhouseDriver(
-			drivers.ClickhouseDriverConfig{
-				Username:               s.Config.Clickhouse.Username,
-				Password:               s.Config.Clickhouse.Password,
-				Database:               s.Config.Clickhouse.Database,
-				Host:                   s.Config.Clickhouse.Host,
-				Port:                   s.Config.Clickhouse.Port,
-				ReadTimeout:            s.Config.Clickhouse.ReadTimeout,
-				WriteTimeout:           s.Config.Clickhouse.WriteTimeout,
-				Nagle:                  !s.Config.Clickhouse.NoDelay,
-				AltHosts:               s.Config.Clickhouse.AltHosts,
-				ConnectionOpenStrategy: s.Config.Clickhouse.ConnectionOpenStrategy,
-				BlockSize:              s.Config.Clickhouse.BlockSize,
-				Debug:                  s.Config.Clickhouse.Debug,
-				Secure:                 s.Config.Clickhouse.Secure,
-				SkipVerify:             s.Config.Clickhouse.SkipVerify,
-			},
-		)
-	case "mock":
-		s.Driver = &drivers.MockDriver{}
-	}
+	s.Driver = newDriver(driverName, *s.Config)
 
 	if s.Driver == nil {
 		return errors.New("An invalid driver name was provided")
@@ -348,13 +396,40 @@ func (s *State) initDriver(driverName string) error {
 	s.Dialect.IndexPlaceholders = s.Driver.IndexPlaceholders()
 	s.Dialect.UseTopClause = s.Driver.UseTopClause()
 
+	switch s.Config.Placeholders {
+	case "":
+	case "?":
+		s.Dialect.IndexPlaceholders = false
+	case "$N":
+		s.Dialect.IndexPlaceholders = true
+	default:
+		return errors.Errorf("unknown placeholders %q, must be ? or $N", s.Config.Placeholders)
+	}
+
 	return nil
 }
 
 // initTables retrieves all "public" schema table names from the database.
 func (s *State) initTables(schema string, whitelist, blacklist []string) error {
-	var err error
-	s.Tables, err = bdb.Tables(s.Driver, schema, whitelist, blacklist)
+	caseInsensitive := s.Config.Clickhouse.CaseInsensitiveTables
+	whitelist, blacklist, err := expandColumnGlobs(s.Driver, schema, whitelist, blacklist, caseInsensitive)
+	if err != nil {
+		return err
+	}
+
+	driver, whitelist, blacklist := newColumnFilter(s.Driver, whitelist, blacklist)
+	driver, whitelist, blacklist, err = newTableGlobFilter(driver, whitelist, blacklist, caseInsensitive)
+	if err != nil {
+		return err
+	}
+	driver = newNullableFilter(driver, s.Config.ForceNullable)
+
+	concurrency := s.Config.Concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	s.Tables, err = bdb.TablesConcurrently(driver, schema, whitelist, blacklist, concurrency)
 	if err != nil {
 		return errors.Wrap(err, "unable to fetch table data")
 	}
@@ -363,13 +438,64 @@ func (s *State) initTables(schema string, whitelist, blacklist []string) error {
 		return errors.New("no tables found in database")
 	}
 
-	if err := checkPKeys(s.Tables); err != nil {
-		return err
+	if len(s.Config.TypeMapFile) != 0 {
+		typeMap, err := loadTypeMap(s.Config.TypeMapFile, s.Config.DriverName)
+		if err != nil {
+			return err
+		}
+		replaceTypes(s.Tables, typeMap, s.Importer.BasedOnType)
 	}
 
+	replaceTypes(s.Tables, s.Config.TypeReplacements, s.Importer.BasedOnType)
+	classifyDefaults(s.Tables)
+
 	return nil
 }
 
+// replaceTypes sets the Go type of every column matched by a replacement,
+// either by its "table.column" name or by its database type, and registers
+// the import the new type needs. Keys are matched case insensitively since
+// viper lowercases them.
+func replaceTypes(tables []bdb.Table, replacements map[string]TypeReplacement, typeImports mapImports) {
+	if len(replacements) == 0 {
+		return
+	}
+
+	lowered := make(map[string]TypeReplacement, len(replacements))
+	for key, r := range replacements {
+		lowered[strings.ToLower(key)] = r
+	}
+
+	for i := range tables {
+		t := &tables[i]
+		for j := range t.Columns {
+			c := &t.Columns[j]
+
+			r, ok := lowered[strings.ToLower(t.Name+"."+c.Name)]
+			if !ok && len(c.FullDBType) != 0 {
+				r, ok = lowered[strings.ToLower(c.FullDBType)]
+			}
+			if !ok {
+				r, ok = lowered[strings.ToLower(c.DBType)]
+			}
+			if !ok {
+				continue
+			}
+
+			c.Type = r.Type
+			c.UnknownType = false
+			if len(r.Import) == 0 {
+				continue
+			}
+			for _, typ := range rgxQualifiedType.FindAllString(r.Type, -1) {
+				typeImports[typ] = imports{
+					thirdParty: importList{fmt.Sprintf("%q", r.Import)},
+				}
+			}
+		}
+	}
+}
+
 // Tags must be in a format like: json, xml, etc.
 var rgxValidTag = regexp.MustCompile(`[a-zA-Z_\.]+`)
 
@@ -388,7 +514,11 @@ func (s *State) initTags(tags []string) error {
 
 // initOutFolder creates the folder that will hold the generated output.
 func (s *State) initOutFolder() error {
-	if s.Config.Wipe {
+	if s.Config.Wipe && s.Config.WipeOnlyMatching {
+		if err := wipeGenerated(s.Config.OutFolder); err != nil {
+			return err
+		}
+	} else if s.Config.Wipe {
 		if err := os.RemoveAll(s.Config.OutFolder); err != nil {
 			return err
 		}
@@ -396,19 +526,3 @@ func (s *State) initOutFolder() error {
 
 	return os.MkdirAll(s.Config.OutFolder, os.ModePerm)
 }
-
-// checkPKeys ensures every table has a primary key column
-func checkPKeys(tables []bdb.Table) error {
-	var missingPkey []string
-	for _, t := range tables {
-		if t.PKey == nil {
-			missingPkey = append(missingPkey, t.Name)
-		}
-	}
-
-	if len(missingPkey) != 0 {
-		return errors.Errorf("primary key missing in tables (%s)", strings.Join(missingPkey, ", "))
-	}
-
-	return nil
-}
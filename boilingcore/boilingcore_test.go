@@ -4,13 +4,20 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
 )
 
 var state *State
@@ -138,3 +145,137 @@ func outputCompileErrors(buf *bytes.Buffer, outFolder string) {
 		fh.Close()
 	}
 }
+
+func TestReplaceTypes(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{
+		{
+			Name: "payments",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "uint64", DBType: "UInt64", FullDBType: "UInt64"},
+				{Name: "amount", Type: "types.Decimal", DBType: "Decimal", FullDBType: "Decimal(18, 4)"},
+				{Name: "fee", Type: "types.Decimal", DBType: "Decimal", FullDBType: "Decimal(18, 4)"},
+				{Name: "note", Type: "string", DBType: "String", FullDBType: "String"},
+			},
+		},
+		{
+			Name: "refunds",
+			Columns: []bdb.Column{
+				{Name: "amount", Type: "types.Decimal", DBType: "Decimal", FullDBType: "Decimal(9, 2)"},
+			},
+		},
+	}
+
+	replacements := map[string]TypeReplacement{
+		"payments.amount": {Type: "money.Money", Import: "github.com/org/money"},
+		"decimal(18, 4)":  {Type: "float64"},
+		"String":          {Type: "[]byte"},
+	}
+
+	typeImports := mapImports{}
+	replaceTypes(tables, replacements, typeImports)
+
+	want := []string{"uint64", "money.Money", "float64", "[]byte"}
+	for i, c := range tables[0].Columns {
+		if c.Type != want[i] {
+			t.Errorf("%s: want type %s, got %s", c.Name, want[i], c.Type)
+		}
+	}
+	if typ := tables[1].Columns[0].Type; typ != "types.Decimal" {
+		t.Errorf("refunds.amount: want the driver type to be kept, got %s", typ)
+	}
+
+	wantImports := mapImports{
+		"money.Money": imports{thirdParty: importList{`"github.com/org/money"`}},
+	}
+	if !reflect.DeepEqual(typeImports, wantImports) {
+		t.Errorf("want imports %#v, got %#v", wantImports, typeImports)
+	}
+}
+
+// logEngineDriver adds to the mock tables access_logs, a Clickhouse table
+// of the Log engine which has no primary key
+type logEngineDriver struct {
+	drivers.MockDriver
+}
+
+func (l *logEngineDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	names, err := l.MockDriver.TableNames(schema, whitelist, blacklist)
+	return append(names, "access_logs"), err
+}
+
+func (l *logEngineDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	if tableName != "access_logs" {
+		return l.MockDriver.Columns(schema, tableName)
+	}
+	return []bdb.Column{
+		{Name: "path", Type: "string", DBType: "character"},
+		{Name: "status", Type: "int", DBType: "integer"},
+	}, nil
+}
+
+func TestKeylessTables(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_keyless")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	s, err := New(&Config{
+		DriverName:      "mock",
+		PkgName:         "models",
+		OutFolder:       out,
+		BaseDir:         "..",
+		BlacklistTables: []string{"hangars"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mock templates are kept, the tables come from the Log engine
+	s.Driver = &logEngineDriver{}
+	if err := s.initTables("public", nil, []string{"hangars"}); err != nil {
+		t.Fatalf("want the keyless table generated, got %v", err)
+	}
+	if !bdb.GetTable(s.Tables, "access_logs").IsReadOnly() {
+		t.Error("want access_logs to be read-only")
+	}
+
+	if err := s.Run(true); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(out, "access_logs.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessLogs := string(b)
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "access_logs.go", b, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, accessLogs)
+	}
+	for _, want := range []string{"type AccessLog struct", "func AccessLogs(exec boil.Executor"} {
+		if !strings.Contains(accessLogs, want) {
+			t.Errorf("want %q in:\n%s", want, accessLogs)
+		}
+	}
+	for _, omitted := range []string{"func FindAccessLog", ") Insert(", ") Update(", ") Delete(", ") Reload(", "func AccessLogExists"} {
+		if strings.Contains(accessLogs, omitted) {
+			t.Errorf("want no %q in:\n%s", omitted, accessLogs)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "access_logs_test.go")); !os.IsNotExist(err) {
+		t.Errorf("want no tests for the keyless table, got %v", err)
+	}
+	suites, err := ioutil.ReadFile(filepath.Join(out, "boil_suites_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(suites), "AccessLog") {
+		t.Errorf("want no suites for the keyless table in:\n%s", suites)
+	}
+}
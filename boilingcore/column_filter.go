@@ -0,0 +1,164 @@
+package boilingcore
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// splitColumnRules splits the "table.column" entries out of a whitelist
+// or blacklist. It returns the table entries and the column entries
+// grouped by table.
+func splitColumnRules(list []string) ([]string, map[string][]string) {
+	var tables []string
+	var columns map[string][]string
+
+	for _, entry := range list {
+		dot := strings.IndexByte(entry, '.')
+		if dot <= 0 || dot == len(entry)-1 {
+			tables = append(tables, entry)
+			continue
+		}
+
+		if columns == nil {
+			columns = make(map[string][]string)
+		}
+		table := entry[:dot]
+		columns[table] = append(columns[table], entry[dot+1:])
+	}
+
+	return tables, columns
+}
+
+// columnFilter wraps a driver to leave out the columns filtered by the
+// "table.column" entries of the whitelist and blacklist, along with the
+// foreign keys using them.
+//
+// A table with whitelisted columns only keeps those and its primary key,
+// primary key columns can't be blacklisted.
+type columnFilter struct {
+	driverWrapper
+
+	whitelist map[string][]string
+	blacklist map[string][]string
+}
+
+// newColumnFilter returns a driver honoring the column rules, the table
+// rules that are left are meant to be given to bdb.Tables. Tables having
+// whitelisted columns are whitelisted as well.
+func newColumnFilter(driver bdb.Interface, whitelist, blacklist []string) (bdb.Interface, []string, []string) {
+	tableWhitelist, columnWhitelist := splitColumnRules(whitelist)
+	tableBlacklist, columnBlacklist := splitColumnRules(blacklist)
+
+	if len(columnWhitelist) == 0 && len(columnBlacklist) == 0 {
+		return driver, tableWhitelist, tableBlacklist
+	}
+
+	for table := range columnWhitelist {
+		if !strmangle.SetInclude(table, tableWhitelist) {
+			tableWhitelist = append(tableWhitelist, table)
+		}
+	}
+
+	filter := columnFilter{
+		driverWrapper: driverWrapper{driver},
+		whitelist:     columnWhitelist,
+		blacklist:     columnBlacklist,
+	}
+
+	return filter, tableWhitelist, tableBlacklist
+}
+
+// primaryKey returns the primary key columns of table, if it has one.
+func (c columnFilter) primaryKey(schema, table string) ([]string, error) {
+	pkey, err := c.Interface.PrimaryKeyInfo(schema, table)
+	if err != nil || pkey == nil {
+		return nil, err
+	}
+	return pkey.Columns, nil
+}
+
+// included reports whether column of table, whose primary key is pkey,
+// survives the column rules. Primary key columns are always included, it's
+// an error to blacklist them.
+func (c columnFilter) included(table, column string, pkey []string) (bool, error) {
+	blacklisted := strmangle.SetInclude(column, c.blacklist[table])
+	if strmangle.SetInclude(column, pkey) {
+		if blacklisted {
+			return false, errors.Errorf("primary key column %s.%s can't be blacklisted", table, column)
+		}
+		return true, nil
+	}
+	if blacklisted {
+		return false, nil
+	}
+
+	whitelist, ok := c.whitelist[table]
+	return !ok || strmangle.SetInclude(column, whitelist), nil
+}
+
+// Columns returns the columns of tableName left after the column rules
+func (c columnFilter) Columns(schema, tableName string) ([]bdb.Column, error) {
+	columns, err := c.Interface.Columns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, err := c.primaryKey(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []bdb.Column
+	for _, col := range columns {
+		ok, err := c.included(tableName, col.Name, pkey)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, col)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ForeignKeyInfo returns the foreign keys of tableName whose local and
+// foreign columns are both left after the column rules
+func (c columnFilter) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	fkeys, err := c.Interface.ForeignKeyInfo(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkeys := make(map[string][]string)
+	includedIn := func(table, column string) (bool, error) {
+		pkey, ok := pkeys[table]
+		if !ok {
+			if pkey, err = c.primaryKey(schema, table); err != nil {
+				return false, err
+			}
+			pkeys[table] = pkey
+		}
+		return c.included(table, column, pkey)
+	}
+
+	var filtered []bdb.ForeignKey
+	for _, fkey := range fkeys {
+		local, err := includedIn(fkey.Table, fkey.Column)
+		if err != nil {
+			return nil, err
+		}
+		foreign, err := includedIn(fkey.ForeignTable, fkey.ForeignColumn)
+		if err != nil {
+			return nil, err
+		}
+		if local && foreign {
+			filtered = append(filtered, fkey)
+		}
+	}
+
+	return filtered, nil
+}
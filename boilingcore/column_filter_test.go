@@ -0,0 +1,134 @@
+package boilingcore
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+func TestSplitColumnRules(t *testing.T) {
+	t.Parallel()
+
+	tables, columns := splitColumnRules([]string{"pilots", "jets.cargo", "jets.manifest", ".inner.table", "hangars."})
+	if want := []string{"pilots", ".inner.table", "hangars."}; !reflect.DeepEqual(tables, want) {
+		t.Errorf("want tables %v, got %v", want, tables)
+	}
+	if want := map[string][]string{"jets": {"cargo", "manifest"}}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("want columns %v, got %v", want, columns)
+	}
+}
+
+func TestColumnFilterWhitelistedTableBlacklistedColumn(t *testing.T) {
+	t.Parallel()
+
+	driver, whitelist, blacklist := newColumnFilter(&drivers.MockDriver{}, []string{"jets", "pilots"}, []string{"jets.cargo", "jets.pilot_id"})
+	if want := []string{"jets", "pilots"}; !reflect.DeepEqual(whitelist, want) {
+		t.Errorf("want whitelist %v, got %v", want, whitelist)
+	}
+	if len(blacklist) != 0 {
+		t.Errorf("want no table blacklist, got %v", blacklist)
+	}
+
+	tables, err := bdb.Tables(driver, "public", whitelist, blacklist)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := bdb.GetTable(tables, "jets")
+	var names []string
+	for _, c := range jets.Columns {
+		names = append(names, c.Name)
+	}
+	want := []string{"id", "airport_id", "name", "color", "uuid", "identifier", "manifest"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("want columns %v, got %v", want, names)
+	}
+
+	// The foreign key on the blacklisted pilot_id column goes with it, the
+	// airports one is dropped because airports isn't whitelisted
+	if len(jets.FKeys) != 0 {
+		t.Errorf("want no foreign keys, got %#v", jets.FKeys)
+	}
+
+	pilots := bdb.GetTable(tables, "pilots")
+	if len(pilots.Columns) != 2 {
+		t.Errorf("pilots should be untouched, got %#v", pilots.Columns)
+	}
+	if len(pilots.ToManyRelationships) != 0 {
+		t.Errorf("want no relationships to jets, got %#v", pilots.ToManyRelationships)
+	}
+}
+
+func TestColumnFilterWhitelistedColumns(t *testing.T) {
+	t.Parallel()
+
+	driver, whitelist, _ := newColumnFilter(&drivers.MockDriver{}, []string{"pilots", "jets.name", "jets.pilot_id"}, nil)
+	sort.Strings(whitelist)
+	if want := []string{"jets", "pilots"}; !reflect.DeepEqual(whitelist, want) {
+		t.Errorf("want whitelist %v, got %v", want, whitelist)
+	}
+
+	tables, err := bdb.Tables(driver, "public", whitelist, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := bdb.GetTable(tables, "jets")
+	var names []string
+	for _, c := range jets.Columns {
+		names = append(names, c.Name)
+	}
+	// The primary key is always kept
+	if want := []string{"id", "pilot_id", "name"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want columns %v, got %v", want, names)
+	}
+	if len(jets.FKeys) != 1 || jets.FKeys[0].Column != "pilot_id" {
+		t.Errorf("want the pilot_id foreign key, got %#v", jets.FKeys)
+	}
+}
+
+func TestColumnFilterBlacklistedPrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	driver, whitelist, blacklist := newColumnFilter(&drivers.MockDriver{}, nil, []string{"pilots.id"})
+	if _, err := bdb.Tables(driver, "public", whitelist, blacklist); err == nil {
+		t.Error("expected an error blacklisting a primary key column")
+	}
+}
+
+type pkeyCountingDriver struct {
+	drivers.MockDriver
+	pkeyCalls map[string]int
+}
+
+func (p *pkeyCountingDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	p.pkeyCalls[tableName]++
+	return p.MockDriver.PrimaryKeyInfo(schema, tableName)
+}
+
+func TestColumnFilterPrimaryKeyOncePerTable(t *testing.T) {
+	t.Parallel()
+
+	mock := &pkeyCountingDriver{pkeyCalls: make(map[string]int)}
+	driver, _, _ := newColumnFilter(mock, nil, []string{"jets.cargo"})
+
+	if _, err := driver.Columns("public", "jets"); err != nil {
+		t.Fatal(err)
+	}
+	if got := mock.pkeyCalls["jets"]; got != 1 {
+		t.Errorf("want the jets primary key fetched once for its columns, got %d", got)
+	}
+
+	mock.pkeyCalls = make(map[string]int)
+	if _, err := driver.ForeignKeyInfo("public", "jets"); err != nil {
+		t.Fatal(err)
+	}
+	for table, got := range mock.pkeyCalls {
+		if got != 1 {
+			t.Errorf("want the %s primary key fetched once for the foreign keys, got %d", table, got)
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package boilingcore
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
 // Config for the running of the commands
 type Config struct {
 	DriverName       string
@@ -18,10 +24,128 @@ type Config struct {
 	Wipe             bool
 	StructTagCasing  string
 
+	// DBTagCasing and JSONTagCasing override StructTagCasing for the tags
+	// added with Tags, ex: db, and for the json tags respectively
+	DBTagCasing   string
+	JSONTagCasing string
+
+	// WipeOnlyMatching restricts Wipe to the files carrying the generated
+	// code disclaimer, other files of the output folder are kept
+	WipeOnlyMatching bool
+
+	// AutoTimestampsExempt are the table and table.column entries left out
+	// of the auto timestamps, ex: events or users.updated_at
+	AutoTimestampsExempt []string
+
+	// AutoColumns are the names of the auto timestamp columns
+	AutoColumns AutoColumns
+
+	// TableNameTransforms rewrite the table names in order before the models
+	// are named after them, ex: ^tbl_ replaced by nothing generates tbl_users
+	// as User. The tables keep their name in queries.
+	TableNameTransforms []TableNameTransform
+
+	// ModelsOnly generates the structs and column names of the tables
+	// alone, without the queries, relationships and hooks
+	ModelsOnly bool
+
+	// ContextOnly leaves out the variants of the generated methods which
+	// don't take a context, ex: InsertAll is left for InsertAllContext
+	ContextOnly bool
+
+	// SoftDeletes generates Delete methods setting the AutoColumns.Deleted
+	// column of the tables having one instead of deleting their rows
+	SoftDeletes bool
+
+	// Concurrency is the number of tables introspected at once, defaults
+	// to defaultConcurrency
+	Concurrency int
+
+	// DryRun writes the summary of the introspected schema to stdout
+	// instead of generating anything, the output folder is left alone
+	DryRun bool
+
+	// TypeReplacements are keyed by either "table.column" or a database
+	// type, ex: Decimal(18, 4). They take precedence over the Go types the
+	// driver picked.
+	TypeReplacements map[string]TypeReplacement
+
+	// ImportPaths overrides the import paths of the packages of the column
+	// types, keyed by package name, ex: null or types. The upstream paths
+	// are used for the others.
+	ImportPaths map[string]string
+
+	// ForceNullable gives the columns null types even when they're NOT
+	// NULL in the database, ex: null.Int64 rather than int64, to tell zero
+	// values and missing ones apart
+	ForceNullable bool
+
+	// Placeholders overrides the placeholder style of the driver in the
+	// generated queries, either "?" or "$N". Empty keeps the driver's.
+	Placeholders string
+
+	// TypeMapFile is an optional TOML file mapping the database types of
+	// each driver to Go types, it's applied before TypeReplacements
+	TypeMapFile string
+
+	// DriverConfig holds the settings of a driver registered with
+	// RegisterDriver, read from the section of the config file named after
+	// the driver, ex: [vertica]. The built-in drivers have their own configs.
+	DriverConfig map[string]interface{}
+
 	Postgres   PostgresConfig
 	MySQL      MySQLConfig
 	MSSQL      MSSQLConfig
 	Clickhouse ClickhouseConfig
+	SQLite     SQLiteConfig
+}
+
+// tagCasings returns the casings of the db and json tags, StructTagCasing
+// unless they're overridden
+func (c *Config) tagCasings() (db, json string) {
+	db, json = c.DBTagCasing, c.JSONTagCasing
+	if len(db) == 0 {
+		db = c.StructTagCasing
+	}
+	if len(json) == 0 {
+		json = c.StructTagCasing
+	}
+	return db, json
+}
+
+// TableNameTransform replaces the matches of the Pattern regexp in the table
+// names with Replacement, which may refer to the submatches as $1.
+type TableNameTransform struct {
+	Pattern     string
+	Replacement string
+}
+
+// AutoColumns names the columns set by the auto timestamps and used by soft
+// deletes, empty names default to created_at, updated_at and deleted_at
+type AutoColumns struct {
+	Created string
+	Updated string
+	Deleted string
+}
+
+func (a AutoColumns) withDefaults() AutoColumns {
+	if len(a.Created) == 0 {
+		a.Created = "created_at"
+	}
+	if len(a.Updated) == 0 {
+		a.Updated = "updated_at"
+	}
+	if len(a.Deleted) == 0 {
+		a.Deleted = "deleted_at"
+	}
+	return a
+}
+
+// TypeReplacement overrides the Go type of a column, Import is the
+// optional package path the type needs, ex: github.com/org/money
+type TypeReplacement struct {
+	Type   string
+	Import string
 }
 
 // PostgresConfig configures a postgres database
@@ -32,6 +156,12 @@ type PostgresConfig struct {
 	Port    int
 	DBName  string
 	SSLMode string
+	// CockroachDB switches the introspection to CockroachDB compatible queries
+	CockroachDB bool
+	// Schemas are generated together instead of Config.Schema when set
+	Schemas []string
+	// SearchPath is the search_path of the connection
+	SearchPath string
 }
 
 // MySQLConfig configures a mysql database
@@ -42,6 +172,19 @@ type MySQLConfig struct {
 	Port    int
 	DBName  string
 	SSLMode string
+
+	// TLS is used instead of SSLMode when any of its fields is set
+	TLS MySQLTLSConfig
+}
+
+// MySQLTLSConfig configures a TLS connection to a mysql database with a
+// custom CA, client certificate or server name. The certificates are paths
+// to PEM files.
+type MySQLTLSConfig struct {
+	CA         string
+	Cert       string
+	Key        string
+	ServerName string
 }
 
 // MSSQLConfig configures a mysql database
@@ -56,6 +199,7 @@ type MSSQLConfig struct {
 
 // ClickhouseConfig configures a clickhouse database
 type ClickhouseConfig struct {
+	DSN                    string
 	Username               string
 	Password               string
 	Database               string
@@ -63,6 +207,7 @@ type ClickhouseConfig struct {
 	Port                   int
 	ReadTimeout            int
 	WriteTimeout           int
+	DialTimeout            int
 	NoDelay                bool
 	AltHosts               []string
 	ConnectionOpenStrategy string
@@ -70,4 +215,145 @@ type ClickhouseConfig struct {
 	Debug                  bool
 	Secure                 bool
 	SkipVerify             bool
+	Compress               bool
+	Databases              []string
+	IgnoreTablePrefixes    []string
+	SystemDatabases        []string
+	CaseInsensitiveTables  bool
+	Cluster                string
+	BulkIntrospection      bool
+	Settings               map[string]string
+	RelationsFile          string
+	EnumAsInt              bool
+	UnknownType            string
+}
+
+// SQLiteConfig configures a sqlite database
+type SQLiteConfig struct {
+	DBName string
+}
+
+// deriveSchema sets the Schema generated from the database config, the
+// databases of MySQL and Clickhouse stand for schemas and SQLite names its
+// own main. Postgres and MSSQL default to their public and dbo schemas.
+func (c *Config) deriveSchema() {
+	switch c.DriverName {
+	case "postgres":
+		if len(c.Schema) == 0 {
+			c.Schema = "public"
+		}
+	case "mysql":
+		c.Schema = c.MySQL.DBName
+	case "mssql":
+		if len(c.Schema) == 0 {
+			c.Schema = "dbo"
+		}
+	case "clickhouse":
+		c.Schema = c.Clickhouse.Database
+		if len(c.Schema) != 0 || len(c.Clickhouse.DSN) == 0 {
+			break
+		}
+		// The dsn replaces the host and port, it may name the database
+		if dsn, err := url.Parse(c.Clickhouse.DSN); err == nil {
+			c.Schema = dsn.Query().Get("database")
+		}
+	case "sqlite":
+		c.Schema = "main"
+	}
+}
+
+// ConfigErrors lists the problems of an invalid Config, by the names of
+// the settings in the config file, ex: clickhouse.host is empty
+type ConfigErrors []string
+
+func (c ConfigErrors) Error() string {
+	return fmt.Sprintf("invalid config:\n  %s", strings.Join(c, "\n  "))
+}
+
+// Validate checks that the settings the selected driver needs to connect
+// are there, so that a missing one doesn't surface as an obscure connection
+// error, and that the tag casings are known. It returns the ConfigErrors of
+// all the problems found.
+func (c *Config) Validate() error {
+	var errs ConfigErrors
+
+	notEmpty := func(value, name string) {
+		if len(value) == 0 {
+			errs = append(errs, name+" is empty")
+		}
+	}
+	notZero := func(value int, name string) {
+		if value == 0 {
+			errs = append(errs, name+" is zero")
+		}
+	}
+
+	notEmpty(c.DriverName, "driver")
+
+	switch c.DriverName {
+	case "postgres":
+		notEmpty(c.Postgres.User, "postgres.user")
+		notEmpty(c.Postgres.Host, "postgres.host")
+		notZero(c.Postgres.Port, "postgres.port")
+		notEmpty(c.Postgres.DBName, "postgres.dbname")
+		notEmpty(c.Postgres.SSLMode, "postgres.sslmode")
+	case "mysql":
+		notEmpty(c.MySQL.User, "mysql.user")
+		notEmpty(c.MySQL.Host, "mysql.host")
+		notZero(c.MySQL.Port, "mysql.port")
+		notEmpty(c.MySQL.DBName, "mysql.dbname")
+		notEmpty(c.MySQL.SSLMode, "mysql.sslmode")
+	case "mssql":
+		notEmpty(c.MSSQL.User, "mssql.user")
+		notEmpty(c.MSSQL.Host, "mssql.host")
+		notZero(c.MSSQL.Port, "mssql.port")
+		notEmpty(c.MSSQL.DBName, "mssql.dbname")
+		notEmpty(c.MSSQL.SSLMode, "mssql.sslmode")
+	case "clickhouse":
+		// The databases can be listed instead of the database
+		hasDatabase := len(c.Clickhouse.Database) != 0 || len(c.Clickhouse.Databases) != 0
+		if len(c.Clickhouse.DSN) == 0 {
+			notEmpty(c.Clickhouse.Host, "clickhouse.host")
+			notZero(c.Clickhouse.Port, "clickhouse.port")
+			if !hasDatabase {
+				notEmpty(c.Clickhouse.Database, "clickhouse.database")
+			}
+			break
+		}
+
+		// The dsn replaces the host and port, it may name the database. The
+		// url.Error quotes the dsn and its password, only its cause is kept.
+		dsn, err := url.Parse(c.Clickhouse.DSN)
+		if uerr, ok := err.(*url.Error); ok {
+			err = uerr.Err
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("clickhouse.dsn is invalid: %v", err))
+		} else if !hasDatabase {
+			notEmpty(dsn.Query().Get("database"), "clickhouse.database")
+		}
+	case "sqlite":
+		notEmpty(c.SQLite.DBName, "sqlite.dbname")
+	}
+
+	casings := []struct {
+		name   string
+		casing string
+	}{
+		{"struct-tag-casing", c.StructTagCasing},
+		{"db-tag-casing", c.DBTagCasing},
+		{"json-tag-casing", c.JSONTagCasing},
+	}
+	for _, tag := range casings {
+		switch tag.casing {
+		case "", "snake", "camel", "pascal", "title":
+		default:
+			errs = append(errs, fmt.Sprintf("%s %q is unknown, must be one of snake, camel, pascal or title", tag.name, tag.casing))
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
 }
@@ -0,0 +1,71 @@
+package boilingcore
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// rgxDefaultCast matches a trailing cast of a default, ex: 'x'::text
+var rgxDefaultCast = regexp.MustCompile(`::[a-zA-Z_][a-zA-Z0-9_ ]*(\([0-9, ]*\))?(\[\])?$`)
+
+// classifyDefaults flags the columns whose default is an expression the
+// server evaluates, like a function call or a column reference, as opposed
+// to a literal the Go side can reason about.
+func classifyDefaults(tables []bdb.Table) {
+	for i := range tables {
+		for j := range tables[i].Columns {
+			c := &tables[i].Columns[j]
+			c.DefaultIsExpression = len(c.Default) != 0 && !isLiteralDefault(c.Default)
+		}
+	}
+}
+
+// isLiteralDefault reports whether a default is a literal: a number, a
+// quoted string, NULL, true or false, optionally followed by a cast.
+func isLiteralDefault(def string) bool {
+	def = strings.TrimSpace(def)
+	for {
+		cast := rgxDefaultCast.FindStringIndex(def)
+		if cast == nil {
+			break
+		}
+		def = strings.TrimSpace(def[:cast[0]])
+	}
+
+	switch strings.ToLower(def) {
+	case "null", "true", "false":
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(def, 64); err == nil {
+		return true
+	}
+
+	return isQuotedString(def)
+}
+
+// isQuotedString reports whether s is a single quoted string, quotes inside
+// of it being escaped either with a backslash or by doubling them.
+func isQuotedString(s string) bool {
+	if len(s) < 2 || s[0] != '\'' {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i == len(s)-1
+		}
+	}
+
+	return false
+}
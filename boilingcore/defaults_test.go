@@ -0,0 +1,61 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func TestIsLiteralDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"0":                                true,
+		"-1.5":                             true,
+		"'x'":                              true,
+		"'it''s'":                          true,
+		`'it\'s'`:                          true,
+		"''":                               true,
+		"NULL":                             true,
+		"false":                            true,
+		"'x'::character varying":           true,
+		"'{}'::text[]":                     true,
+		"now()":                            false,
+		"toUInt8(0)":                       false,
+		"a + b":                            false,
+		"a":                                false,
+		"'a' || 'b'":                       false,
+		"nextval('jets_id_seq'::regclass)": false,
+	}
+
+	for def, want := range tests {
+		if got := isLiteralDefault(def); got != want {
+			t.Errorf("%s: want literal %t, got %t", def, want, got)
+		}
+	}
+}
+
+func TestClassifyDefaults(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{
+		{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "id"},
+				{Name: "count", Default: "0"},
+				{Name: "kind", Default: "'x'"},
+				{Name: "created_at", Default: "now()"},
+				{Name: "total", Default: "a + b"},
+			},
+		},
+	}
+	classifyDefaults(tables)
+
+	want := []bool{false, false, false, true, true}
+	for i, c := range tables[0].Columns {
+		if c.DefaultIsExpression != want[i] {
+			t.Errorf("%s: want expression %t, got %t", c.Name, want[i], c.DefaultIsExpression)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package boilingcore
+
+import "github.com/volatiletech/sqlboiler/bdb"
+
+// driverWrapper is embedded by the drivers wrapping another one to filter
+// or alter what it returns. Besides bdb.Interface, it forwards the optional
+// interfaces the wrapped driver implements, which embedding the interface
+// alone would hide.
+type driverWrapper struct {
+	bdb.Interface
+}
+
+// IsView forwards to the wrapped driver when it tells views apart
+func (w driverWrapper) IsView(schema, tableName string) (bool, error) {
+	if vc, ok := w.Interface.(bdb.ViewChecker); ok {
+		return vc.IsView(schema, tableName)
+	}
+	return false, nil
+}
+
+// SchemaName forwards to the wrapped driver when it names its tables
+func (w driverWrapper) SchemaName(schema, tableName string) (string, error) {
+	if sn, ok := w.Interface.(bdb.SchemaNamer); ok {
+		return sn.SchemaName(schema, tableName)
+	}
+	return "", nil
+}
+
+// TableRowEstimate forwards to the wrapped driver when it estimates rows
+func (w driverWrapper) TableRowEstimate(schema, tableName string) (uint64, error) {
+	if est, ok := w.Interface.(bdb.RowEstimator); ok {
+		return est.TableRowEstimate(schema, tableName)
+	}
+	return 0, nil
+}
+
+// IndexInfo forwards to the wrapped driver when it lists indexes
+func (w driverWrapper) IndexInfo(schema, tableName string) ([]bdb.Index, error) {
+	if il, ok := w.Interface.(bdb.IndexLister); ok {
+		return il.IndexInfo(schema, tableName)
+	}
+	return nil, nil
+}
+
+// TableTTL forwards to the wrapped driver when it reads TTLs
+func (w driverWrapper) TableTTL(schema, tableName string) (string, error) {
+	if tr, ok := w.Interface.(bdb.TTLReader); ok {
+		return tr.TableTTL(schema, tableName)
+	}
+	return "", nil
+}
+
+// TableProjections forwards to the wrapped driver when it lists projections
+func (w driverWrapper) TableProjections(schema, tableName string) ([]bdb.Projection, error) {
+	if pl, ok := w.Interface.(bdb.ProjectionLister); ok {
+		return pl.TableProjections(schema, tableName)
+	}
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+type ttlDriver struct {
+	drivers.MockDriver
+}
+
+func (*ttlDriver) IsView(schema, tableName string) (bool, error) {
+	return tableName == "jets_view", nil
+}
+
+func (*ttlDriver) TableTTL(schema, tableName string) (string, error) {
+	return "created + INTERVAL 1 DAY", nil
+}
+
+func TestDriverWrappersForward(t *testing.T) {
+	t.Parallel()
+
+	driver := &ttlDriver{}
+	columns, _, _ := newColumnFilter(driver, []string{"jets.name"}, nil)
+	globs, _, _, err := newTableGlobFilter(driver, []string{"jets*"}, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrappers := map[string]bdb.Interface{
+		"columnFilter":    columns,
+		"tableGlobFilter": globs,
+		"nullableFilter":  newNullableFilter(driver, true),
+	}
+	for name, wrapper := range wrappers {
+		if isView, err := wrapper.(bdb.ViewChecker).IsView("public", "jets_view"); err != nil || !isView {
+			t.Errorf("%s: want jets_view to be a view, got %t, %v", name, isView, err)
+		}
+		if ttl, err := wrapper.(bdb.TTLReader).TableTTL("public", "jets"); err != nil || ttl != "created + INTERVAL 1 DAY" {
+			t.Errorf("%s: want the wrapped TTL, got %q, %v", name, ttl, err)
+		}
+		if indexes, err := wrapper.(bdb.IndexLister).IndexInfo("public", "jets"); err != nil || indexes != nil {
+			t.Errorf("%s: want no indexes, got %#v, %v", name, indexes, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package boilingcore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+// DriverFactory creates a driver from the generator's configuration
+type DriverFactory func(Config) bdb.Interface
+
+var (
+	driversMut      sync.RWMutex
+	driverFactories = map[string]DriverFactory{}
+)
+
+func init() {
+	RegisterDriver("postgres", newPostgresDriver)
+	RegisterDriver("mysql", newMySQLDriver)
+	RegisterDriver("mssql", newMSSQLDriver)
+	RegisterDriver("clickhouse", newClickhouseDriver)
+	RegisterDriver("sqlite", newSQLiteDriver)
+	RegisterDriver("mock", func(Config) bdb.Interface { return &drivers.MockDriver{} })
+}
+
+// RegisterDriver makes a driver available under name, so that it can be
+// picked with Config.DriverName. It lets drivers living outside of this
+// repository plug into the generator, the driver's test main template
+// (templates_test/main_test/<name>_main.tpl) has to be provided unless
+// tests aren't generated. It panics when called twice for the same name
+// or with a nil factory.
+func RegisterDriver(name string, factory func(Config) bdb.Interface) {
+	driversMut.Lock()
+	defer driversMut.Unlock()
+
+	if factory == nil {
+		panic("boilingcore: RegisterDriver factory is nil")
+	}
+	if _, dup := driverFactories[name]; dup {
+		panic(fmt.Sprintf("boilingcore: RegisterDriver called twice for driver %s", name))
+	}
+	driverFactories[name] = factory
+}
+
+// Drivers returns the sorted names of the registered drivers
+func Drivers() []string {
+	driversMut.RLock()
+	defer driversMut.RUnlock()
+
+	names := make([]string, 0, len(driverFactories))
+	for name := range driverFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// newDriver creates the driver registered under name, it returns nil
+// if there's no such driver.
+func newDriver(name string, config Config) bdb.Interface {
+	driversMut.RLock()
+	factory, ok := driverFactories[name]
+	driversMut.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	return factory(config)
+}
+
+func newPostgresDriver(config Config) bdb.Interface {
+	return drivers.NewPostgresDriverFromConfig(drivers.PostgresDriverConfig{
+		User:        config.Postgres.User,
+		Pass:        config.Postgres.Pass,
+		DBName:      config.Postgres.DBName,
+		Host:        config.Postgres.Host,
+		Port:        config.Postgres.Port,
+		SSLMode:     config.Postgres.SSLMode,
+		CockroachDB: config.Postgres.CockroachDB,
+		Schemas:     config.Postgres.Schemas,
+		SearchPath:  config.Postgres.SearchPath,
+	})
+}
+
+func newMySQLDriver(config Config) bdb.Interface {
+	if tlsConfig := config.MySQL.TLS; tlsConfig != (MySQLTLSConfig{}) {
+		return drivers.NewMySQLDriverTLS(
+			config.MySQL.User,
+			config.MySQL.Pass,
+			config.MySQL.DBName,
+			config.MySQL.Host,
+			config.MySQL.Port,
+			drivers.MySQLTLSConfig{
+				CA:         tlsConfig.CA,
+				Cert:       tlsConfig.Cert,
+				Key:        tlsConfig.Key,
+				ServerName: tlsConfig.ServerName,
+			},
+		)
+	}
+
+	return drivers.NewMySQLDriver(
+		config.MySQL.User,
+		config.MySQL.Pass,
+		config.MySQL.DBName,
+		config.MySQL.Host,
+		config.MySQL.Port,
+		config.MySQL.SSLMode,
+	)
+}
+
+func newMSSQLDriver(config Config) bdb.Interface {
+	return drivers.NewMSSQLDriver(
+		config.MSSQL.User,
+		config.MSSQL.Pass,
+		config.MSSQL.DBName,
+		config.MSSQL.Host,
+		config.MSSQL.Port,
+		config.MSSQL.SSLMode,
+	)
+}
+
+func newClickhouseDriver(config Config) bdb.Interface {
+	// Introspection queries are printed in debug mode
+	var logger func(string, ...interface{})
+	if config.Debug {
+		logger = queryLogger(os.Stdout)
+	}
+
+	return drivers.NewClickhouseDriver(
+		drivers.ClickhouseDriverConfig{
+			DSN:                    config.Clickhouse.DSN,
+			Username:               config.Clickhouse.Username,
+			Password:               config.Clickhouse.Password,
+			Database:               config.Clickhouse.Database,
+			Host:                   config.Clickhouse.Host,
+			Port:                   config.Clickhouse.Port,
+			ReadTimeout:            config.Clickhouse.ReadTimeout,
+			WriteTimeout:           config.Clickhouse.WriteTimeout,
+			DialTimeout:            config.Clickhouse.DialTimeout,
+			Nagle:                  !config.Clickhouse.NoDelay,
+			AltHosts:               config.Clickhouse.AltHosts,
+			ConnectionOpenStrategy: config.Clickhouse.ConnectionOpenStrategy,
+			BlockSize:              config.Clickhouse.BlockSize,
+			Debug:                  config.Clickhouse.Debug,
+			Secure:                 config.Clickhouse.Secure,
+			SkipVerify:             config.Clickhouse.SkipVerify,
+			Compress:               config.Clickhouse.Compress,
+			Databases:              config.Clickhouse.Databases,
+			IgnoreTablePrefixes:    config.Clickhouse.IgnoreTablePrefixes,
+			SystemDatabases:        config.Clickhouse.SystemDatabases,
+			CaseInsensitiveTables:  config.Clickhouse.CaseInsensitiveTables,
+			Cluster:                config.Clickhouse.Cluster,
+			BulkIntrospection:      config.Clickhouse.BulkIntrospection,
+			Settings:               config.Clickhouse.Settings,
+			RelationsFile:          config.Clickhouse.RelationsFile,
+			EnumAsInt:              config.Clickhouse.EnumAsInt,
+			UnknownType:            config.Clickhouse.UnknownType,
+			Logger:                 logger,
+		},
+	)
+}
+
+// queryLogger returns a logger printing a query and its args to w. Both go
+// out in a single write so that the lines of concurrent queries don't mix.
+func queryLogger(w io.Writer) func(string, ...interface{}) {
+	return func(query string, args ...interface{}) {
+		fmt.Fprint(w, query+"\n"+fmt.Sprintln(args...))
+	}
+}
+
+func newSQLiteDriver(config Config) bdb.Interface {
+	return drivers.NewSQLiteDriver(config.SQLite.DBName)
+}
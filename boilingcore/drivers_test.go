@@ -0,0 +1,190 @@
+package boilingcore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+type fakeDriver struct {
+	drivers.MockDriver
+	schema string
+}
+
+func (f *fakeDriver) LeftQuote() byte  { return '[' }
+func (f *fakeDriver) RightQuote() byte { return ']' }
+
+func TestRegisterDriver(t *testing.T) {
+	t.Parallel()
+
+	RegisterDriver("fake", func(config Config) bdb.Interface {
+		return &fakeDriver{schema: config.Schema}
+	})
+
+	if !strmangle.SetInclude("fake", Drivers()) {
+		t.Errorf("fake driver isn't listed in %v", Drivers())
+	}
+
+	s := &State{Config: &Config{DriverName: "fake", Schema: "dbo"}}
+	if err := s.initDriver("fake"); err != nil {
+		t.Fatal(err)
+	}
+
+	driver, ok := s.Driver.(*fakeDriver)
+	if !ok {
+		t.Fatalf("want a fake driver, got %T", s.Driver)
+	}
+	if driver.schema != "dbo" {
+		t.Errorf("the driver didn't get the config, schema: %q", driver.schema)
+	}
+	if s.Dialect.LQ != '[' || s.Dialect.RQ != ']' {
+		t.Errorf("the dialect doesn't come from the driver: %c %c", s.Dialect.LQ, s.Dialect.RQ)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic registering a driver twice")
+		}
+	}()
+	RegisterDriver("fake", func(config Config) bdb.Interface { return nil })
+}
+
+type configuredDriver struct {
+	drivers.MockDriver
+	host string
+	port int
+}
+
+func TestRegisterDriverConfig(t *testing.T) {
+	t.Parallel()
+
+	RegisterDriver("configured", func(config Config) bdb.Interface {
+		host, _ := config.DriverConfig["host"].(string)
+		port, _ := config.DriverConfig["port"].(int)
+		return &configuredDriver{host: host, port: port}
+	})
+
+	s := &State{Config: &Config{
+		DriverName:   "configured",
+		DriverConfig: map[string]interface{}{"host": "vertica.local", "port": 5433},
+	}}
+	if err := s.initDriver("configured"); err != nil {
+		t.Fatal(err)
+	}
+
+	driver := s.Driver.(*configuredDriver)
+	if driver.host != "vertica.local" || driver.port != 5433 {
+		t.Errorf("the driver didn't get its settings: %#v", driver)
+	}
+}
+
+func TestBuiltinDrivers(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"postgres", "mysql", "mssql", "clickhouse", "sqlite", "mock"} {
+		if newDriver(name, Config{}) == nil {
+			t.Errorf("%s isn't registered", name)
+		}
+
+		// Tests are generated by default, they need the TestMain template
+		if _, err := loadTemplate("../"+templatesTestMainDirectory, name+"_main.tpl"); err != nil {
+			t.Errorf("%s has no TestMain template: %v", name, err)
+		}
+	}
+
+	s := &State{Config: &Config{}}
+	if err := s.initDriver("firebird"); err == nil {
+		t.Error("expected an error for an unknown driver")
+	}
+}
+
+func TestPlaceholdersOverride(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := bdb.Table{
+		Name: "pilots",
+		Columns: []bdb.Column{
+			{Name: "id", Type: "uint64"},
+			{Name: "name", Type: "string"},
+		},
+		PKey: &bdb.PrimaryKey{Columns: []string{"id"}},
+	}
+
+	tests := []struct {
+		Placeholders string
+		Index        bool
+		Want         string
+	}{
+		{"", false, "select %s from `pilots` where `id`=?"},
+		{"?", false, "select %s from `pilots` where `id`=?"},
+		{"$N", true, "select %s from `pilots` where `id`=$1"},
+	}
+
+	for _, test := range tests {
+		s := &State{Config: &Config{DriverName: "clickhouse", Placeholders: test.Placeholders}}
+		if err := s.initDriver("clickhouse"); err != nil {
+			t.Fatal(err)
+		}
+		if s.Dialect.IndexPlaceholders != test.Index {
+			t.Errorf("%q: want index placeholders %t, got %t", test.Placeholders, test.Index, s.Dialect.IndexPlaceholders)
+		}
+
+		data := templateData{
+			Tables:      []bdb.Table{table},
+			Table:       table,
+			Schema:      "default",
+			PkgName:     "models",
+			DriverName:  "clickhouse",
+			Dialect:     s.Dialect,
+			LQ:          "`",
+			RQ:          "`",
+			StringFuncs: templateStringMappers,
+		}
+
+		buf := &bytes.Buffer{}
+		if err := tpls.ExecuteTemplate(buf, "14_find.tpl", data); err != nil {
+			t.Fatal(err)
+		}
+		if out := buf.String(); !strings.Contains(out, test.Want) {
+			t.Errorf("%q: want %s in:\n%s", test.Placeholders, test.Want, out)
+		}
+	}
+
+	s := &State{Config: &Config{DriverName: "clickhouse", Placeholders: ":name"}}
+	if err := s.initDriver("clickhouse"); err == nil {
+		t.Error("expected an error for unknown placeholders")
+	}
+}
+
+type writeCounter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *writeCounter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestQueryLogger(t *testing.T) {
+	t.Parallel()
+
+	w := &writeCounter{}
+	queryLogger(w)("select name from system.tables where database = ?", "default", 2)
+
+	if want := "select name from system.tables where database = ?\ndefault 2\n"; w.String() != want {
+		t.Errorf("want %q, got %q", want, w.String())
+	}
+	if w.writes != 1 {
+		t.Errorf("want the query and args in a single write, got %d", w.writes)
+	}
+}
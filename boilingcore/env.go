@@ -0,0 +1,146 @@
+package boilingcore
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvPrefix is the prefix of the environment variables read by ApplyEnv
+const EnvPrefix = "SQLBOILER_"
+
+// ApplyEnv overlays the database configs with the SQLBOILER_<DRIVER>_<KEY>
+// environment variables, KEY being the config file key in upper case, ex:
+// SQLBOILER_CLICKHOUSE_PASSWORD for clickhouse.password. Unset or empty
+// variables leave the config as is, lists are comma separated.
+func ApplyEnv(config *Config) error {
+	return applyEnv(config, os.LookupEnv)
+}
+
+func applyEnv(config *Config, lookup func(string) (string, bool)) error {
+	e := envOverlay{lookup: lookup}
+
+	e.String("POSTGRES_USER", &config.Postgres.User)
+	e.String("POSTGRES_PASS", &config.Postgres.Pass)
+	e.String("POSTGRES_HOST", &config.Postgres.Host)
+	e.Int("POSTGRES_PORT", &config.Postgres.Port)
+	e.String("POSTGRES_DBNAME", &config.Postgres.DBName)
+	e.String("POSTGRES_SSLMODE", &config.Postgres.SSLMode)
+	e.Bool("POSTGRES_COCKROACHDB", &config.Postgres.CockroachDB)
+	e.Strings("POSTGRES_SCHEMAS", &config.Postgres.Schemas)
+	e.String("POSTGRES_SEARCH_PATH", &config.Postgres.SearchPath)
+
+	e.String("MYSQL_USER", &config.MySQL.User)
+	e.String("MYSQL_PASS", &config.MySQL.Pass)
+	e.String("MYSQL_HOST", &config.MySQL.Host)
+	e.Int("MYSQL_PORT", &config.MySQL.Port)
+	e.String("MYSQL_DBNAME", &config.MySQL.DBName)
+	e.String("MYSQL_SSLMODE", &config.MySQL.SSLMode)
+	e.String("MYSQL_TLS_CA", &config.MySQL.TLS.CA)
+	e.String("MYSQL_TLS_CERT", &config.MySQL.TLS.Cert)
+	e.String("MYSQL_TLS_KEY", &config.MySQL.TLS.Key)
+	e.String("MYSQL_TLS_SERVER_NAME", &config.MySQL.TLS.ServerName)
+
+	e.String("MSSQL_USER", &config.MSSQL.User)
+	e.String("MSSQL_PASS", &config.MSSQL.Pass)
+	e.String("MSSQL_HOST", &config.MSSQL.Host)
+	e.Int("MSSQL_PORT", &config.MSSQL.Port)
+	e.String("MSSQL_DBNAME", &config.MSSQL.DBName)
+	e.String("MSSQL_SSLMODE", &config.MSSQL.SSLMode)
+
+	e.String("CLICKHOUSE_DSN", &config.Clickhouse.DSN)
+	e.String("CLICKHOUSE_USERNAME", &config.Clickhouse.Username)
+	e.String("CLICKHOUSE_PASSWORD", &config.Clickhouse.Password)
+	e.String("CLICKHOUSE_DATABASE", &config.Clickhouse.Database)
+	e.String("CLICKHOUSE_HOST", &config.Clickhouse.Host)
+	e.Int("CLICKHOUSE_PORT", &config.Clickhouse.Port)
+	e.Int("CLICKHOUSE_READ_TIMEOUT", &config.Clickhouse.ReadTimeout)
+	e.Int("CLICKHOUSE_WRITE_TIMEOUT", &config.Clickhouse.WriteTimeout)
+	e.Int("CLICKHOUSE_DIAL_TIMEOUT", &config.Clickhouse.DialTimeout)
+	e.Bool("CLICKHOUSE_NO_DELAY", &config.Clickhouse.NoDelay)
+	e.Strings("CLICKHOUSE_ALT_HOSTS", &config.Clickhouse.AltHosts)
+	e.String("CLICKHOUSE_CONNECTION_OPEN_STRATEGY", &config.Clickhouse.ConnectionOpenStrategy)
+	e.Int("CLICKHOUSE_BLOCK_SIZE", &config.Clickhouse.BlockSize)
+	e.Bool("CLICKHOUSE_DEBUG", &config.Clickhouse.Debug)
+	e.Bool("CLICKHOUSE_SECURE", &config.Clickhouse.Secure)
+	e.Bool("CLICKHOUSE_SKIP_VERIFY", &config.Clickhouse.SkipVerify)
+	e.Bool("CLICKHOUSE_COMPRESS", &config.Clickhouse.Compress)
+	e.Strings("CLICKHOUSE_DATABASES", &config.Clickhouse.Databases)
+	e.Strings("CLICKHOUSE_IGNORE_TABLE_PREFIXES", &config.Clickhouse.IgnoreTablePrefixes)
+	e.Strings("CLICKHOUSE_SYSTEM_DATABASES", &config.Clickhouse.SystemDatabases)
+	e.Bool("CLICKHOUSE_CASE_INSENSITIVE_TABLES", &config.Clickhouse.CaseInsensitiveTables)
+	e.String("CLICKHOUSE_CLUSTER", &config.Clickhouse.Cluster)
+	e.Bool("CLICKHOUSE_BULK_INTROSPECTION", &config.Clickhouse.BulkIntrospection)
+	e.String("CLICKHOUSE_RELATIONS_FILE", &config.Clickhouse.RelationsFile)
+	e.Bool("CLICKHOUSE_ENUM_AS_INT", &config.Clickhouse.EnumAsInt)
+	e.String("CLICKHOUSE_UNKNOWN_TYPE", &config.Clickhouse.UnknownType)
+
+	e.String("SQLITE_DBNAME", &config.SQLite.DBName)
+
+	return e.err
+}
+
+// envOverlay sets config fields from the environment, the first error
+// is kept and stops any further overlay.
+type envOverlay struct {
+	lookup func(string) (string, bool)
+	err    error
+}
+
+func (e *envOverlay) get(name string) (string, bool) {
+	if e.err != nil {
+		return "", false
+	}
+
+	value, ok := e.lookup(EnvPrefix + name)
+	return value, ok && len(value) != 0
+}
+
+func (e *envOverlay) String(name string, dst *string) {
+	if value, ok := e.get(name); ok {
+		*dst = value
+	}
+}
+
+func (e *envOverlay) Int(name string, dst *int) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		e.err = errors.Wrapf(err, "invalid integer in %s%s", EnvPrefix, name)
+		return
+	}
+	*dst = i
+}
+
+func (e *envOverlay) Bool(name string, dst *bool) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		e.err = errors.Wrapf(err, "invalid boolean in %s%s", EnvPrefix, name)
+		return
+	}
+	*dst = b
+}
+
+func (e *envOverlay) Strings(name string, dst *[]string) {
+	value, ok := e.get(name)
+	if !ok {
+		return
+	}
+
+	list := strings.Split(value, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	*dst = list
+}
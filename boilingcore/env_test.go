@@ -0,0 +1,87 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"SQLBOILER_CLICKHOUSE_PASSWORD":  "secret",
+		"SQLBOILER_CLICKHOUSE_PORT":      "9440",
+		"SQLBOILER_CLICKHOUSE_SECURE":    "false",
+		"SQLBOILER_CLICKHOUSE_ALT_HOSTS": "alt1:9440, alt2:9440",
+		"SQLBOILER_CLICKHOUSE_HOST":      "",
+		"SQLBOILER_POSTGRES_DBNAME":      "ci",
+		"SQLBOILER_POSTGRES_COCKROACHDB": "true",
+		"SQLBOILER_POSTGRES_SCHEMAS":     "app,billing",
+		"SQLBOILER_UNRELATED_SETTING":    "ignored",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	config := &Config{
+		Clickhouse: ClickhouseConfig{
+			Username: "default",
+			Password: "from the config",
+			Host:     "localhost",
+			Port:     9000,
+			Secure:   true,
+		},
+		Postgres: PostgresConfig{User: "admin", DBName: "dev"},
+	}
+	if err := applyEnv(config, lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ClickhouseConfig{
+		Username: "default",
+		Password: "secret",
+		// Empty variables don't clobber the config
+		Host:     "localhost",
+		Port:     9440,
+		AltHosts: []string{"alt1:9440", "alt2:9440"},
+	}
+	if !reflect.DeepEqual(config.Clickhouse, want) {
+		t.Errorf("want %#v, got %#v", want, config.Clickhouse)
+	}
+	if want := (PostgresConfig{User: "admin", DBName: "ci", CockroachDB: true, Schemas: []string{"app", "billing"}}); !reflect.DeepEqual(config.Postgres, want) {
+		t.Errorf("want %#v, got %#v", want, config.Postgres)
+	}
+}
+
+func TestApplyEnvUnset(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		MySQL:  MySQLConfig{User: "root", Host: "db", Port: 3306, DBName: "app", SSLMode: "true"},
+		SQLite: SQLiteConfig{DBName: "app.db"},
+	}
+	want := config
+
+	lookup := func(string) (string, bool) { return "", false }
+	if err := applyEnv(&config, lookup); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("want %#v, got %#v", want, config)
+	}
+}
+
+func TestApplyEnvInvalid(t *testing.T) {
+	t.Parallel()
+
+	lookup := func(name string) (string, bool) {
+		if name == "SQLBOILER_MSSQL_PORT" {
+			return "fourteen", true
+		}
+		return "", false
+	}
+	if err := applyEnv(&Config{}, lookup); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
@@ -3,6 +3,7 @@ package boilingcore
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -48,6 +49,9 @@ func combineImports(a, b imports) imports {
 	return c
 }
 
+// rgxQualifiedType matches package qualified type names such as null.Int64
+var rgxQualifiedType = regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*\.[a-zA-Z_][a-zA-Z0-9_]*`)
+
 func combineTypeImports(a imports, b map[string]imports, columns []bdb.Column) imports {
 	tmpImp := imports{
 		standard:   make(importList, len(a.standard)),
@@ -58,8 +62,10 @@ func combineTypeImports(a imports, b map[string]imports, columns []bdb.Column) i
 	copy(tmpImp.thirdParty, a.thirdParty)
 
 	for _, col := range columns {
-		for key, imp := range b {
-			if col.Type == key {
+		// Composite types like []time.Time need the imports of every
+		// qualified type they're built from.
+		for _, typ := range rgxQualifiedType.FindAllString(col.Type, -1) {
+			if imp, ok := b[typ]; ok {
 				tmpImp.standard = append(tmpImp.standard, imp.standard...)
 				tmpImp.thirdParty = append(tmpImp.thirdParty, imp.thirdParty...)
 			}
@@ -302,6 +308,18 @@ func newImporter() importer {
 				`_ "github.com/denisenkom/go-mssqldb"`,
 			},
 		},
+		"sqlite": {
+			standard: importList{
+				`"database/sql"`,
+				`"io/ioutil"`,
+				`"os"`,
+			},
+			thirdParty: importList{
+				`"github.com/pkg/errors"`,
+				`"github.com/spf13/viper"`,
+				`_ "github.com/mattn/go-sqlite3"`,
+			},
+		},
 	}
 
 	// basedOnType imports are only included in the template output if the
@@ -380,17 +398,88 @@ func newImporter() importer {
 		"types.StringArray": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
+		"types.ClickhouseStringArray": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.ClickhouseInt64Array": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.ClickhouseFloat64Array": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
 		"types.Hstore": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
 		"types.FixedString": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
+		"types.NullFixedString": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.UUID": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullUUID": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.IP": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullIP": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Decimal": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullDecimal": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.BigInt": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullBigInt": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Point": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Ring": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Polygon": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.MultiPolygon": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
 	}
 
 	return imp
 }
 
+// overridePackages imports the types of the packages of paths, keyed by
+// package name such as null or types, from another path, ex: a fork. The
+// package name is kept as the import alias so the types don't change.
+func (m mapImports) overridePackages(paths map[string]string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	for typ := range m {
+		idx := strings.IndexByte(typ, '.')
+		if idx < 0 {
+			continue
+		}
+
+		pkg := typ[:idx]
+		if path, ok := paths[pkg]; ok {
+			m[typ] = imports{
+				thirdParty: importList{fmt.Sprintf("%s %q", pkg, path)},
+			}
+		}
+	}
+}
+
 // Remove an import matching the match string under the specified key.
 // Remove will search both standard and thirdParty import lists for a match.
 func (m mapImports) Remove(key string, match string) {
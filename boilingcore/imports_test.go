@@ -3,6 +3,7 @@ package boilingcore
 import (
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -263,6 +264,9 @@ func TestCombineTypeImports(t *testing.T) {
 		{
 			Type: "null.Float",
 		},
+		{
+			Type: "[]null.Time",
+		},
 	}
 
 	imps := newImporter()
@@ -290,6 +294,29 @@ func TestCombineTypeImports(t *testing.T) {
 	if !reflect.DeepEqual(res2, importsExpected) {
 		t.Errorf("Expected res2 to match importsExpected, got:\n\n%#v\n", res1)
 	}
+
+	cols = []bdb.Column{
+		{
+			Type: "[][]time.Time",
+		},
+	}
+
+	res3 := combineTypeImports(imports1, imps.BasedOnType, cols)
+
+	importsExpected = imports{
+		standard: importList{
+			`"errors"`,
+			`"fmt"`,
+			`"time"`,
+		},
+		thirdParty: importList{
+			`"github.com/volatiletech/sqlboiler/boil"`,
+		},
+	}
+
+	if !reflect.DeepEqual(res3, importsExpected) {
+		t.Errorf("Expected res3 to match importsExpected, got:\n\n%#v\n", res3)
+	}
 }
 
 func TestCombineImports(t *testing.T) {
@@ -393,3 +420,53 @@ func TestCombineStringSlices(t *testing.T) {
 		t.Errorf("Slice mismatch: %#v + %#v != #%v", a, b, slice)
 	}
 }
+
+func TestOverridePackages(t *testing.T) {
+	t.Parallel()
+
+	imps := newImporter()
+	imps.BasedOnType.overridePackages(map[string]string{
+		"null":  "github.com/org/null",
+		"types": "github.com/org/sqlboiler/types",
+	})
+
+	cols := []bdb.Column{
+		{Type: "null.Int64"},
+		{Type: "types.Decimal"},
+		{Type: "time.Time"},
+		{Type: "[]null.Time"},
+	}
+
+	res := combineTypeImports(imports{}, imps.BasedOnType, cols)
+
+	expected := imports{
+		standard: importList{
+			`"time"`,
+		},
+		thirdParty: importList{
+			`null "github.com/org/null"`,
+			`types "github.com/org/sqlboiler/types"`,
+		},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("want the overridden imports, got:\n\n%#v\n", res)
+	}
+
+	importString := string(buildImportString(res))
+	for _, want := range []string{`null "github.com/org/null"`, `types "github.com/org/sqlboiler/types"`} {
+		if !strings.Contains(importString, want) {
+			t.Errorf("want %s in:\n%s", want, importString)
+		}
+	}
+	if strings.Contains(importString, "volatiletech") {
+		t.Errorf("want no upstream import in:\n%s", importString)
+	}
+
+	// The other packages keep their upstream paths
+	defaults := newImporter()
+	defaults.BasedOnType.overridePackages(nil)
+	res = combineTypeImports(imports{}, defaults.BasedOnType, cols)
+	if !reflect.DeepEqual(res.thirdParty, importList{`"github.com/volatiletech/sqlboiler/types"`, `"gopkg.in/volatiletech/null.v6"`}) {
+		t.Errorf("want the upstream imports, got %#v", res.thirdParty)
+	}
+}
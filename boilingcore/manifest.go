@@ -0,0 +1,181 @@
+package boilingcore
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// manifestFileName is the file of the output folder listing the files
+// generated by the last run
+const manifestFileName = ".sqlboiler_manifest"
+
+var manifestHeader = []byte(`# Files generated by SQLBoiler (https://github.com/volatiletech/sqlboiler).
+# Those missing from the next run are deleted by it unless they were edited
+# since, each is listed with the SHA-256 of its content. Don't edit this file.
+`)
+
+// manifestEntry is a file listed by the manifest along with the hex encoded
+// SHA-256 of its content when it was generated, manifests written before
+// the sums were recorded have none.
+type manifestEntry struct {
+	Name string
+	Sum  string
+}
+
+// writeOutput writes a generated file to the output folder and records it
+// for the manifest
+func (s *State) writeOutput(fileName string, input *bytes.Buffer) error {
+	if err := writeFile(s.Config.OutFolder, fileName, input); err != nil {
+		return err
+	}
+
+	s.generated = append(s.generated, fileName)
+	return nil
+}
+
+// readManifest returns the files listed by the manifest of the folder, they
+// are nil only when there is no manifest.
+func readManifest(folder string) ([]manifestEntry, error) {
+	path := filepath.Join(folder, manifestFileName)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "unable to open the manifest %s", path)
+	}
+	defer f.Close()
+
+	entries := []manifestEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entry := manifestEntry{Name: fields[0]}
+		if len(fields) > 1 {
+			entry.Sum = fields[1]
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to read the manifest %s", path)
+	}
+
+	return entries, nil
+}
+
+// fileSum returns the hex encoded SHA-256 of the content of the file
+func fileSum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to open %s", path)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", errors.Wrapf(err, "unable to read %s", path)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isUnedited reports whether the generated file listed by entry is still as
+// it was written: it starts with the generated code disclaimer and its
+// content has the sum recorded, if any.
+func isUnedited(path string, entry manifestEntry) (bool, error) {
+	generated, err := isGenerated(path)
+	if err != nil || !generated {
+		return false, err
+	}
+	if len(entry.Sum) == 0 {
+		return true, nil
+	}
+
+	sum, err := fileSum(path)
+	if err != nil {
+		return false, err
+	}
+
+	return sum == entry.Sum, nil
+}
+
+// writeManifest deletes the files the previous manifest lists which weren't
+// generated this time, such as those of dropped tables, and replaces it with
+// the sorted list of the generated files. It's meant to be called once all
+// the output was written, so a failed run leaves the previous manifest.
+//
+// Each deleted file is logged to w. Files which were edited since they were
+// generated are kept instead, with a warning.
+func (s *State) writeManifest(w io.Writer) error {
+	folder := s.Config.OutFolder
+
+	previous, err := readManifest(folder)
+	if err != nil {
+		return err
+	}
+
+	generated := make(map[string]struct{}, len(s.generated))
+	for _, name := range s.generated {
+		generated[name] = struct{}{}
+	}
+
+	for _, entry := range previous {
+		if _, ok := generated[entry.Name]; ok || filepath.Base(entry.Name) != entry.Name {
+			continue
+		}
+
+		path := filepath.Join(folder, entry.Name)
+		unedited, err := isUnedited(path, entry)
+		if os.IsNotExist(errors.Cause(err)) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !unedited {
+			fmt.Fprintf(w, "warning: kept stale file %s, it was edited since it was generated\n", path)
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "unable to delete stale file %s", path)
+		}
+		fmt.Fprintf(w, "deleted stale file %s\n", path)
+	}
+
+	files := make([]string, 0, len(generated))
+	for name := range generated {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	out := &bytes.Buffer{}
+	out.Write(manifestHeader)
+	for _, name := range files {
+		sum, err := fileSum(filepath.Join(folder, name))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s %s\n", name, sum)
+	}
+
+	path := filepath.Join(folder, manifestFileName)
+	if err := testHarnessWriteFile(path, out.Bytes(), 0666); err != nil {
+		return errors.Wrapf(err, "failed to write the manifest %s", path)
+	}
+
+	return nil
+}
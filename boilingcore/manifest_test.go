@@ -0,0 +1,211 @@
+package boilingcore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	generate := func(blacklist []string) {
+		s, err := New(&Config{
+			DriverName:      "mock",
+			PkgName:         "models",
+			OutFolder:       out,
+			BaseDir:         "..",
+			NoTests:         true,
+			BlacklistTables: blacklist,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Run(false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	custom := filepath.Join(out, "custom.go")
+	if err := ioutil.WriteFile(custom, []byte("package models\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	generate([]string{"hangars"})
+
+	files, err := manifestNames(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sort.StringsAreSorted(files) {
+		t.Errorf("want the manifest sorted, got %v", files)
+	}
+
+	onDisk, err := filepath.Glob(filepath.Join(out, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, path := range onDisk {
+		if path != custom {
+			want = append(want, filepath.Base(path))
+		}
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("want the manifest to list the generated files %v, got %v", want, files)
+	}
+
+	generate([]string{"hangars", "jets"})
+
+	if _, err := os.Stat(filepath.Join(out, "jets.go")); !os.IsNotExist(err) {
+		t.Errorf("want the file of the removed table deleted, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "pilots.go")); err != nil {
+		t.Errorf("want the other tables kept: %v", err)
+	}
+	if _, err := os.Stat(custom); err != nil {
+		t.Errorf("want hand-written files kept: %v", err)
+	}
+
+	files, err = manifestNames(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range files {
+		if name == "jets.go" {
+			t.Errorf("want jets.go out of the manifest, got %v", files)
+		}
+	}
+}
+
+// manifestNames returns the names of the files listed by the manifest
+func manifestNames(folder string) ([]string, error) {
+	entries, err := readManifest(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names, nil
+}
+
+func TestManifestStaleFiles(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	generated := append(append([]byte{}, noEditDisclaimer...), "package models\n"...)
+	for _, name := range []string{"pilots.go", "jets.go", "hangars.go", "airports.go"} {
+		if err := ioutil.WriteFile(filepath.Join(out, name), generated, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &State{
+		Config:    &Config{OutFolder: out},
+		generated: []string{"pilots.go", "jets.go", "hangars.go", "airports.go"},
+	}
+	if err := s.writeManifest(ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	// hangars.go was edited since but kept the disclaimer
+	edited := append(append([]byte{}, generated...), "\n// Hand-written helpers\n"...)
+	if err := ioutil.WriteFile(filepath.Join(out, "hangars.go"), edited, 0666); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := readManifest(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if len(entry.Sum) != 64 {
+			t.Errorf("want the sum of %s recorded, got %q", entry.Name, entry.Sum)
+		}
+	}
+
+	s.generated = []string{"pilots.go"}
+	log := &bytes.Buffer{}
+	if err := s.writeManifest(log); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "jets.go")); !os.IsNotExist(err) {
+		t.Errorf("want the stale jets.go deleted, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "hangars.go")); err != nil {
+		t.Errorf("want the edited hangars.go kept: %v", err)
+	}
+
+	got := log.String()
+	for _, want := range []string{
+		"deleted stale file " + filepath.Join(out, "jets.go") + "\n",
+		"deleted stale file " + filepath.Join(out, "airports.go") + "\n",
+		"warning: kept stale file " + filepath.Join(out, "hangars.go") + ", it was edited since it was generated\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want %q logged, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestManifestFailedRun(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	previous := append(append([]byte{}, manifestHeader...), "zeppelins.go\n"...)
+	path := filepath.Join(out, manifestFileName)
+	if err := ioutil.WriteFile(path, previous, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// A folder in the way of jets.go fails the run halfway through
+	if err := os.Mkdir(filepath.Join(out, "jets.go"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(&Config{
+		DriverName: "mock",
+		PkgName:    "models",
+		OutFolder:  out,
+		BaseDir:    "..",
+		NoTests:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run(false); err == nil {
+		t.Fatal("want the run to fail")
+	}
+
+	manifest, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(manifest, previous) {
+		t.Errorf("want the previous manifest kept, got:\n%s", manifest)
+	}
+}
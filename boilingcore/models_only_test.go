@@ -0,0 +1,79 @@
+package boilingcore
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestModelsOnly(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_models_only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	s, err := New(&Config{
+		DriverName: "mock",
+		PkgName:    "models",
+		OutFolder:  out,
+		BaseDir:    "..",
+		ModelsOnly: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run(true); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(out, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+
+	hasTableNames := false
+	for _, name := range names {
+		hasTableNames = hasTableNames || name == "boil_table_names.go"
+		if strings.HasSuffix(name, "_test.go") {
+			t.Errorf("want no tests, got %s", name)
+		}
+		if name == "boil_queries.go" || name == "boil_types.go" {
+			t.Errorf("want no query helpers, got %s", name)
+		}
+	}
+	if !hasTableNames {
+		t.Errorf("want the table names generated, got %v", names)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(out, "pilots.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pilots := string(b)
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "pilots.go", b, 0); err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, pilots)
+	}
+
+	for _, want := range []string{"type Pilot struct", "var PilotColumns = struct"} {
+		if !strings.Contains(pilots, want) {
+			t.Errorf("want %q in:\n%s", want, pilots)
+		}
+	}
+	for _, omitted := range []string{"func FindPilot", "func Pilots(", "pilotR", "pilotL", "PilotHook", "func (o *Pilot) Insert", "sqlboiler/queries"} {
+		if strings.Contains(pilots, omitted) {
+			t.Errorf("want no %q in:\n%s", omitted, pilots)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package boilingcore
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// nameReplacementSep separates the table from the struct name in the
+// "table=>StructName" entries of the replacements
+const nameReplacementSep = "=>"
+
+var rgxStructName = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+// splitNameReplacements splits the "table=>StructName" entries out of the
+// replacements. It returns the struct names keyed by table, along with the
+// template replacements that are left.
+func splitNameReplacements(replacements []string) (map[string]string, []string, error) {
+	var names map[string]string
+	var templates []string
+
+	for _, replace := range replacements {
+		idx := strings.Index(replace, nameReplacementSep)
+		if idx < 0 {
+			templates = append(templates, replace)
+			continue
+		}
+
+		table := strings.TrimSpace(replace[:idx])
+		name := strings.TrimSpace(replace[idx+len(nameReplacementSep):])
+		if len(table) == 0 || !rgxStructName.MatchString(name) {
+			return nil, nil, errors.Errorf("name replacements must look like table=>StructName, given: %s", replace)
+		}
+
+		if names == nil {
+			names = make(map[string]string)
+		}
+		if other, ok := names[table]; ok && other != name {
+			return nil, nil, errors.Errorf("table %s is renamed to both %s and %s", table, other, name)
+		}
+		names[table] = name
+	}
+
+	return names, templates, nil
+}
+
+// tableAliases are the singular snake_case names the models of the renamed
+// tables are generated with, keyed by table. The templates name the models
+// with them, other names such as those of the columns are left alone.
+type tableAliases map[string]string
+
+// Singular returns the singular name of the model of table, ex: account for
+// users renamed to Account, user otherwise
+func (a tableAliases) Singular(table string) string {
+	if name, ok := a[table]; ok {
+		return name
+	}
+	return strmangle.Singular(table)
+}
+
+// Plural returns the plural name of the model of table, ex: accounts for
+// users renamed to Account, users otherwise
+func (a tableAliases) Plural(table string) string {
+	if name, ok := a[table]; ok {
+		return strmangle.Plural(name)
+	}
+	return strmangle.Plural(table)
+}
+
+// initNames renames the models of the tables given a "table=>StructName"
+// replacement or the table name transforms, the tables keep their name in
+// queries.
+func (s *State) initNames() error {
+	names, _, err := splitNameReplacements(s.Config.Replacements)
+	if err != nil {
+		return err
+	}
+
+	transformed, err := transformTableNames(s.Tables, s.Config.TableNameTransforms)
+	if err != nil {
+		return err
+	}
+
+	aliases, err := modelNames(s.Tables, names, transformed)
+	if err != nil {
+		return err
+	}
+
+	s.Aliases = aliases
+	return nil
+}
+
+// transformTableNames applies the transforms to the table names in order,
+// it returns the names that changed keyed by table.
+func transformTableNames(tables []bdb.Table, transforms []TableNameTransform) (map[string]string, error) {
+	if len(transforms) == 0 {
+		return nil, nil
+	}
+
+	rgxs := make([]*regexp.Regexp, len(transforms))
+	for i, transform := range transforms {
+		rgx, err := regexp.Compile(transform.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid table name transform %q", transform.Pattern)
+		}
+		rgxs[i] = rgx
+	}
+
+	transformed := make(map[string]string)
+	for _, t := range tables {
+		name := t.Name
+		for i, rgx := range rgxs {
+			name = rgx.ReplaceAllString(name, transforms[i].Replacement)
+		}
+
+		if len(name) == 0 {
+			return nil, errors.Errorf("table %s is left without a name by the table name transforms", t.Name)
+		}
+		if name != t.Name {
+			transformed[t.Name] = name
+		}
+	}
+
+	return transformed, nil
+}
+
+// modelNames converts the struct names keyed by table to the aliases of
+// the tables, the tables without one are named after their transformed name
+// if any. It fails on unknown tables and on models that would end up with
+// the same struct name.
+func modelNames(tables []bdb.Table, names, transformed map[string]string) (tableAliases, error) {
+	if len(names) == 0 && len(transformed) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t.Name] = true
+	}
+
+	snakeNames := make(tableAliases, len(names))
+	for table, name := range names {
+		if !known[table] {
+			return nil, errors.Errorf("unable to rename unknown table %s to %s", table, name)
+		}
+
+		snake := snakeCase(name)
+		if titled := strmangle.TitleCase(snake); titled != name {
+			return nil, errors.Errorf("table %s can't be renamed to %s, the generated name would be %s", table, name, titled)
+		}
+		snakeNames[table] = snake
+	}
+
+	for table, name := range transformed {
+		if _, ok := snakeNames[table]; !ok {
+			snakeNames[table] = strmangle.Singular(name)
+		}
+	}
+
+	models := make(map[string]string, len(tables))
+	for _, t := range tables {
+		model := strmangle.TitleCase(snakeNames.Singular(t.Name))
+		if other, ok := models[model]; ok {
+			return nil, errors.Errorf("tables %s and %s would both be generated as %s", other, t.Name, model)
+		}
+		models[model] = t.Name
+	}
+
+	return snakeNames, nil
+}
+
+// snakeCase converts a struct name to snake_case, keeping initialisms
+// together: UserHTTPLog becomes user_http_log.
+func snakeCase(name string) string {
+	buf := &bytes.Buffer{}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if isUpper(c) && i > 0 {
+			prev := name[i-1]
+			nextLower := i+1 < len(name) && !isUpper(name[i+1])
+			if !isUpper(prev) || nextLower {
+				buf.WriteByte('_')
+			}
+		}
+		buf.WriteString(strings.ToLower(string(c)))
+	}
+
+	return buf.String()
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
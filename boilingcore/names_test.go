@@ -0,0 +1,270 @@
+package boilingcore
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+func TestSplitNameReplacements(t *testing.T) {
+	t.Parallel()
+
+	names, templates, err := splitNameReplacements([]string{
+		"templates/00_struct.tpl:custom/00_struct.tpl",
+		"users=>Account",
+		"user_logs => UserHTTPLog",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"users": "Account", "user_logs": "UserHTTPLog"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want names %v, got %v", want, names)
+	}
+	if want := []string{"templates/00_struct.tpl:custom/00_struct.tpl"}; !reflect.DeepEqual(templates, want) {
+		t.Errorf("want templates %v, got %v", want, templates)
+	}
+
+	bad := [][]string{
+		{"users=>"},
+		{"=>Account"},
+		{"users=>account"},
+		{"users=>Account", "users=>Member"},
+	}
+	for _, replacements := range bad {
+		if _, _, err := splitNameReplacements(replacements); err == nil {
+			t.Errorf("expected an error for %v", replacements)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Account":     "account",
+		"UserAccount": "user_account",
+		"UserHTTPLog": "user_http_log",
+		"HTTPLog":     "http_log",
+		"Log2Entry":   "log2_entry",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("%s: want %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestModelNamesCollisions(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{{Name: "pilots"}, {Name: "jets"}}
+
+	tests := []map[string]string{
+		{"hangars": "Hangar"},
+		{"jets": "Pilot"},
+		{"pilots": "Craft", "jets": "Craft"},
+	}
+	for _, names := range tests {
+		if _, err := modelNames(tables, names, nil); err == nil {
+			t.Errorf("expected an error renaming %v", names)
+		}
+	}
+}
+
+func TestTableAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases := tableAliases{"users": "account"}
+	if got := aliases.Singular("users"); got != "account" {
+		t.Errorf("want account, got %s", got)
+	}
+	if got := aliases.Plural("users"); got != "accounts" {
+		t.Errorf("want accounts, got %s", got)
+	}
+	if got := aliases.Singular("videos"); got != "video" {
+		t.Errorf("want video, got %s", got)
+	}
+
+	var none tableAliases
+	if got := none.Plural("jet"); got != "jets" {
+		t.Errorf("want jets, got %s", got)
+	}
+}
+
+func TestInitNames(t *testing.T) {
+	t.Parallel()
+
+	tables, err := bdb.Tables(&drivers.MockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &State{
+		Config: &Config{Replacements: []string{"pilots=>Aviator"}},
+		Tables: tables,
+	}
+	if err := s.initNames(); err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := template.Must(template.New("").Funcs(templateFunctions).Parse(
+		`type {{$.Aliases.Singular .Table.Name | titleCase}}Slice []*{{$.Aliases.Singular .Table.Name | titleCase}}
+func {{$.Aliases.Plural .Table.Name | titleCase}}() {{$.Aliases.Singular .Table.Name | camelCase}}Query { from({{.SchemaTable .Table.Name}}) }
+{{"pilots" | singular | titleCase}}`,
+	))
+
+	buf := &bytes.Buffer{}
+	data := templateData{
+		Table:      bdb.GetTable(tables, "pilots"),
+		Aliases:    s.Aliases,
+		DriverName: "postgres",
+		Schema:     "public",
+		LQ:         `"`,
+		RQ:         `"`,
+	}
+	if err := tpl.Execute(buf, data); err != nil {
+		t.Fatal(err)
+	}
+
+	// singular is left alone, it's given column names as well
+	want := `type AviatorSlice []*Aviator
+func Aviators() aviatorQuery { from("pilots") }
+Pilot`
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+	if got := strmangle.Singular("pilots"); got != "pilot" {
+		t.Errorf("the rename leaked into strmangle: %s", got)
+	}
+
+	// Relationships to the renamed table use its new name too, the
+	// functions named after the pilot_id column keep it
+	jets := bdb.GetTable(tables, "jets")
+	rel := txtsFromFKey(s.Aliases, tables, jets, jets.FKeys[0])
+	if rel.ForeignTable.NameGo != "Aviator" || rel.ForeignTable.NamePluralGo != "Aviators" {
+		t.Errorf("want the Aviator model, got %s and %s", rel.ForeignTable.NameGo, rel.ForeignTable.NamePluralGo)
+	}
+	if rel.Function.Name != "Pilot" {
+		t.Errorf("want the Pilot function, got %s", rel.Function.Name)
+	}
+
+	// The names of a State don't carry over to another one
+	other := &State{Config: &Config{}, Tables: tables}
+	if err := other.initNames(); err != nil {
+		t.Fatal(err)
+	}
+	if got := other.Aliases.Singular("pilots"); got != "pilot" {
+		t.Errorf("want pilot, got %s", got)
+	}
+}
+
+func TestInitNamesTransforms(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{{Name: "tbl_users"}, {Name: "ch_events"}, {Name: "airports"}, {Name: "tbl_logs"}}
+	s := &State{
+		Config: &Config{
+			Replacements: []string{"tbl_logs=>AuditLog"},
+			TableNameTransforms: []TableNameTransform{
+				{Pattern: "^(tbl|ch)_", Replacement: ""},
+			},
+		},
+		Tables: tables,
+	}
+	if err := s.initNames(); err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := template.Must(template.New("").Funcs(templateFunctions).Parse(
+		`type {{$.Aliases.Singular .Table.Name | titleCase}} struct{}
+func {{$.Aliases.Plural .Table.Name | titleCase}}() {{$.Aliases.Singular .Table.Name | camelCase}}Query { from({{.SchemaTable .Table.Name}}) }`,
+	))
+
+	tests := map[string]string{
+		"tbl_users": `type User struct{}
+func Users() userQuery { from("tbl_users") }`,
+		"ch_events": `type Event struct{}
+func Events() eventQuery { from("ch_events") }`,
+		"airports": `type Airport struct{}
+func Airports() airportQuery { from("airports") }`,
+		"tbl_logs": `type AuditLog struct{}
+func AuditLogs() auditLogQuery { from("tbl_logs") }`,
+	}
+	for table, want := range tests {
+		buf := &bytes.Buffer{}
+		data := templateData{
+			Table:      bdb.GetTable(tables, table),
+			Aliases:    s.Aliases,
+			DriverName: "postgres",
+			Schema:     "public",
+			LQ:         `"`,
+			RQ:         `"`,
+		}
+		if err := tpl.Execute(buf, data); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("%s: want:\n%s\ngot:\n%s", table, want, got)
+		}
+	}
+
+	bad := []struct {
+		Tables     []bdb.Table
+		Transforms []TableNameTransform
+	}{
+		{[]bdb.Table{{Name: "tbl_users"}}, []TableNameTransform{{Pattern: "(tbl"}}},
+		{[]bdb.Table{{Name: "tbl_"}}, []TableNameTransform{{Pattern: "^tbl_"}}},
+		{[]bdb.Table{{Name: "tbl_users"}, {Name: "users"}}, []TableNameTransform{{Pattern: "^tbl_"}}},
+	}
+	for i, test := range bad {
+		s := &State{Config: &Config{TableNameTransforms: test.Transforms}, Tables: test.Tables}
+		if err := s.initNames(); err == nil {
+			t.Errorf("%d) expected an error", i)
+		}
+	}
+}
+
+func TestInitNamesTransformsStayLocal(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{{Name: "tbl_users"}, {Name: "tbl_jets"}}
+	prefixed := &State{
+		Config: &Config{TableNameTransforms: []TableNameTransform{{Pattern: "^tbl_", Replacement: ""}}},
+		Tables: tables,
+	}
+	renamed := &State{
+		Config: &Config{TableNameTransforms: []TableNameTransform{{Pattern: "^tbl_", Replacement: "old_"}}},
+		Tables: tables,
+	}
+	for _, s := range []*State{prefixed, renamed} {
+		if err := s.initNames(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each state names its models through its own aliases
+	if want := (tableAliases{"tbl_users": "user", "tbl_jets": "jet"}); !reflect.DeepEqual(prefixed.Aliases, want) {
+		t.Errorf("want aliases %v, got %v", want, prefixed.Aliases)
+	}
+	if want := (tableAliases{"tbl_users": "old_user", "tbl_jets": "old_jet"}); !reflect.DeepEqual(renamed.Aliases, want) {
+		t.Errorf("want aliases %v, got %v", want, renamed.Aliases)
+	}
+
+	// The transforms don't leak into strmangle, which every state shares
+	if got := strmangle.Singular("tbl_users"); got != "tbl_user" {
+		t.Errorf("want strmangle.Singular untouched, got %s", got)
+	}
+	if got := strmangle.Plural("tbl_jet"); got != "tbl_jets" {
+		t.Errorf("want strmangle.Plural untouched, got %s", got)
+	}
+
+	data := templateData{Table: tables[0], Aliases: prefixed.Aliases}
+	if got := data.Aliases.Plural(data.Table.Name); got != "users" {
+		t.Errorf("want the template data to pluralize the transformed name, got %s", got)
+	}
+}
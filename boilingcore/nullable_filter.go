@@ -0,0 +1,99 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// forcedNullTypes maps the Go types of non-nullable columns to the types
+// they're given when every column is forced to be nullable.
+var forcedNullTypes = map[string]string{
+	"bool":      "null.Bool",
+	"int":       "null.Int",
+	"int8":      "null.Int8",
+	"int16":     "null.Int16",
+	"int32":     "null.Int32",
+	"int64":     "null.Int64",
+	"uint":      "null.Uint",
+	"uint8":     "null.Uint8",
+	"uint16":    "null.Uint16",
+	"uint32":    "null.Uint32",
+	"uint64":    "null.Uint64",
+	"float32":   "null.Float32",
+	"float64":   "null.Float64",
+	"string":    "null.String",
+	"time.Time": "null.Time",
+	"[]byte":    "null.Bytes",
+
+	"types.JSON":        "null.JSON",
+	"types.FixedString": "types.NullFixedString",
+	"types.UUID":        "types.NullUUID",
+	"types.IP":          "types.NullIP",
+	"types.BigInt":      "types.NullBigInt",
+	"types.Decimal":     "types.NullDecimal",
+}
+
+// nullableFilter wraps a driver to give every column a null type after
+// its type is translated, so that zero values and missing ones can be told
+// apart whatever the nullability of the column in the database. Columns of
+// types with no null counterpart, such as arrays, are left alone, and so
+// are primary key columns which are never missing.
+type nullableFilter struct {
+	driverWrapper
+}
+
+// newNullableFilter returns a driver forcing null types when force is set,
+// the driver as is otherwise.
+func newNullableFilter(driver bdb.Interface, force bool) bdb.Interface {
+	if !force {
+		return driver
+	}
+
+	return nullableFilter{driverWrapper{driver}}
+}
+
+// Columns returns the columns of tableName translated by the wrapped
+// driver, with the null type swapped in for the ones outside the primary
+// key. The columns are marked nullable for the foreign keys and
+// relationships using them.
+func (n nullableFilter) Columns(schema, tableName string) ([]bdb.Column, error) {
+	columns, err := n.Interface.Columns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, err := n.Interface.PrimaryKeyInfo(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range columns {
+		c = n.Interface.TranslateColumnType(c)
+		if pkey == nil || !strmangle.SetInclude(c.Name, pkey.Columns) {
+			c = forceNull(c)
+		}
+		columns[i] = c
+	}
+
+	return columns, nil
+}
+
+// TranslateColumnType returns the column as is, Columns translated it
+// already.
+func (n nullableFilter) TranslateColumnType(c bdb.Column) bdb.Column {
+	return c
+}
+
+// forceNull swaps the type of a non-nullable column for its null one
+func forceNull(c bdb.Column) bdb.Column {
+	if c.Nullable {
+		return c
+	}
+
+	if nullType, ok := forcedNullTypes[c.Type]; ok {
+		c.Type = nullType
+		c.Nullable = true
+	}
+
+	return c
+}
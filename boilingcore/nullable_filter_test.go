@@ -0,0 +1,93 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+// translatedDriver is a driver whose columns come translated already
+type translatedDriver struct {
+	drivers.MockDriver
+}
+
+func (*translatedDriver) TranslateColumnType(c bdb.Column) bdb.Column { return c }
+
+// autoIncrementDriver is a driver whose tables have an auto incremented id
+type autoIncrementDriver struct {
+	translatedDriver
+}
+
+func (*autoIncrementDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	return []bdb.Column{
+		{Name: "id", Type: "int64", Default: "auto_increment"},
+		{Name: "amount", Type: "types.Decimal"},
+	}, nil
+}
+
+func TestForceNull(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In       bdb.Column
+		Type     string
+		Nullable bool
+	}{
+		{bdb.Column{Name: "id", Type: "int64"}, "null.Int64", true},
+		{bdb.Column{Name: "hits", Type: "uint32"}, "null.Uint32", true},
+		{bdb.Column{Name: "created_at", Type: "time.Time"}, "null.Time", true},
+		{bdb.Column{Name: "ip", Type: "types.IP"}, "types.NullIP", true},
+		{bdb.Column{Name: "price", Type: "types.Decimal"}, "types.NullDecimal", true},
+		{bdb.Column{Name: "name", Type: "null.String", Nullable: true}, "null.String", true},
+		{bdb.Column{Name: "tags", Type: "[]string"}, "[]string", false},
+	}
+
+	for _, test := range tests {
+		c := forceNull(test.In)
+		if c.Type != test.Type || c.Nullable != test.Nullable {
+			t.Errorf("%s: want %s nullable %t, got %s nullable %t", test.In.Name, test.Type, test.Nullable, c.Type, c.Nullable)
+		}
+	}
+}
+
+func TestNullableFilterColumns(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := newNullableFilter(&drivers.MockDriver{}, false).(*drivers.MockDriver); !ok {
+		t.Error("want the driver as is without force")
+	}
+
+	tables, err := bdb.Tables(newNullableFilter(&autoIncrementDriver{}, true), "public", []string{"pilots"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pilots := bdb.GetTable(tables, "pilots")
+	if c := pilots.GetColumn("id"); c.Type != "int64" || c.Nullable {
+		t.Errorf("want the primary key id left an int64, got %#v", c)
+	}
+	if c := pilots.GetColumn("amount"); c.Type != "types.NullDecimal" || !c.Nullable {
+		t.Errorf("want amount to be a nullable types.NullDecimal, got %#v", c)
+	}
+	if !pilots.CanLastInsertID() {
+		t.Error("want the auto incremented primary key usable with LastInsertId")
+	}
+}
+
+func TestNullableFilterForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	tables, err := bdb.Tables(newNullableFilter(&drivers.MockDriver{}, true), "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	licenses := bdb.GetTable(tables, "licenses")
+	if c := licenses.GetColumn("pilot_id"); c.Type != "null.Int" || !c.Nullable {
+		t.Errorf("want pilot_id to be a nullable null.Int, got %#v", c)
+	}
+	if len(licenses.FKeys) != 1 || !licenses.FKeys[0].Nullable || licenses.FKeys[0].ForeignColumnNullable {
+		t.Errorf("want the foreign key to follow the columns nullability, got %#v", licenses.FKeys)
+	}
+}
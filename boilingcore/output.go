@@ -12,6 +12,7 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
 var noEditDisclaimer = []byte(`// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
@@ -31,16 +32,28 @@ var (
 	testHarnessWriteFile = ioutil.WriteFile
 )
 
+// modelsOnlyTemplates are the templates generated with Config.ModelsOnly,
+// the others build on the queries, relationships and hooks left out.
+var modelsOnlyTemplates = []string{"00_struct.tpl", "boil_table_names.tpl"}
+
 // generateOutput builds the file output and sends it to outHandler for saving
 func generateOutput(state *State, data *templateData) error {
-	return executeTemplates(executeTemplateData{
+	e := executeTemplateData{
 		state:                state,
 		data:                 data,
 		templates:            state.Templates,
 		importSet:            state.Importer.Standard,
 		combineImportsOnType: true,
 		fileSuffix:           ".go",
-	})
+	}
+
+	// The structs only need the imports of their column types
+	if state.Config.ModelsOnly {
+		e.importSet = imports{}
+		e.templateNames = modelsOnlyTemplates
+	}
+
+	return executeTemplates(e)
 }
 
 // generateTestOutput builds the test file output and sends it to outHandler for saving
@@ -58,13 +71,19 @@ func generateTestOutput(state *State, data *templateData) error {
 // generateSingletonOutput processes the templates that should only be run
 // one time.
 func generateSingletonOutput(state *State, data *templateData) error {
-	return executeSingletonTemplates(executeTemplateData{
+	e := executeTemplateData{
 		state:          state,
 		data:           data,
 		templates:      state.SingletonTemplates,
 		importNamedSet: state.Importer.Singleton,
 		fileSuffix:     ".go",
-	})
+	}
+
+	if state.Config.ModelsOnly {
+		e.templateNames = modelsOnlyTemplates
+	}
+
+	return executeSingletonTemplates(e)
 }
 
 // generateSingletonTestOutput processes the templates that should only be run
@@ -90,9 +109,29 @@ type executeTemplateData struct {
 
 	combineImportsOnType bool
 
+	// templateNames restricts the templates executed when set
+	templateNames []string
+
 	fileSuffix string
 }
 
+// names returns the templates to execute, in order
+func (e executeTemplateData) names() []string {
+	names := e.templates.Templates()
+	if e.templateNames == nil {
+		return names
+	}
+
+	var kept []string
+	for _, name := range names {
+		if strmangle.SetInclude(name, e.templateNames) {
+			kept = append(kept, name)
+		}
+	}
+
+	return kept
+}
+
 func executeTemplates(e executeTemplateData) error {
 	if e.data.Table.IsJoinTable {
 		return nil
@@ -112,14 +151,14 @@ func executeTemplates(e executeTemplateData) error {
 	writePackageName(out, e.state.Config.PkgName)
 	writeImports(out, imps)
 
-	for _, tplName := range e.templates.Templates() {
+	for _, tplName := range e.names() {
 		if err := executeTemplate(out, e.templates.Template, tplName, e.data); err != nil {
 			return err
 		}
 	}
 
 	fName := e.data.Table.Name + e.fileSuffix
-	if err := writeFile(e.state.Config.OutFolder, fName, out); err != nil {
+	if err := e.state.writeOutput(fName, out); err != nil {
 		return err
 	}
 
@@ -132,7 +171,7 @@ func executeSingletonTemplates(e executeTemplateData) error {
 	}
 
 	out := templateByteBuffer
-	for _, tplName := range e.templates.Templates() {
+	for _, tplName := range e.names() {
 		out.Reset()
 
 		fName := tplName
@@ -152,7 +191,7 @@ func executeSingletonTemplates(e executeTemplateData) error {
 			return err
 		}
 
-		if err := writeFile(e.state.Config.OutFolder, fName+e.fileSuffix, out); err != nil {
+		if err := e.state.writeOutput(fName+e.fileSuffix, out); err != nil {
 			return err
 		}
 	}
@@ -180,7 +219,7 @@ func generateTestMainOutput(state *State, data *templateData) error {
 		return err
 	}
 
-	if err := writeFile(state.Config.OutFolder, "main_test.go", out); err != nil {
+	if err := state.writeOutput("main_test.go", out); err != nil {
 		return err
 	}
 
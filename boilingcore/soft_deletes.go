@@ -0,0 +1,46 @@
+package boilingcore
+
+import (
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// softDeleteFlagTypes are the Go types of the columns flagging soft deleted
+// rows, ex: an is_deleted UInt8, they're set to true or 1 on delete
+var softDeleteFlagTypes = map[string]bool{
+	"bool":   true,
+	"int":    true,
+	"int8":   true,
+	"int16":  true,
+	"int32":  true,
+	"int64":  true,
+	"uint":   true,
+	"uint8":  true,
+	"uint16": true,
+	"uint32": true,
+	"uint64": true,
+}
+
+// checkSoftDeletes makes sure the tables can be soft deleted with their
+// column, which is either a nullable timestamp set to the deletion time or
+// a flag.
+func checkSoftDeletes(tables []bdb.Table, column string) error {
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Name != column {
+				continue
+			}
+
+			if c.Nullable && c.Type == "null.Time" {
+				continue
+			}
+			if !c.Nullable && softDeleteFlagTypes[c.Type] {
+				continue
+			}
+
+			return errors.Errorf("%s.%s of type %s is neither a nullable timestamp nor a bool or integer flag", t.Name, c.Name, c.Type)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func TestCheckSoftDeletes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column bdb.Column
+		Ok     bool
+	}{
+		{bdb.Column{Name: "deleted_at", Type: "null.Time", Nullable: true}, true},
+		{bdb.Column{Name: "deleted_at", Type: "uint8"}, true},
+		{bdb.Column{Name: "deleted_at", Type: "bool"}, true},
+		{bdb.Column{Name: "deleted_at", Type: "time.Time"}, false},
+		{bdb.Column{Name: "deleted_at", Type: "null.Uint8", Nullable: true}, false},
+		{bdb.Column{Name: "deleted_at", Type: "string"}, false},
+		{bdb.Column{Name: "removed", Type: "string"}, true},
+	}
+
+	for i, test := range tests {
+		tables := []bdb.Table{{Name: "users", Columns: []bdb.Column{test.Column}}}
+		err := checkSoftDeletes(tables, "deleted_at")
+		if ok := err == nil; ok != test.Ok {
+			t.Errorf("%d) want ok %t, got error %v", i, test.Ok, err)
+		}
+	}
+}
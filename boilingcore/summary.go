@@ -0,0 +1,192 @@
+package boilingcore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// TableSummary is what the generator sees of a table, see State.Summary
+type TableSummary struct {
+	Name       string
+	Model      string
+	IsView     bool
+	IsJoin     bool
+	PrimaryKey []string
+	Columns    []ColumnSummary
+}
+
+// ColumnSummary is what the generator sees of a column, DBType is the full
+// database type when the driver knows it
+type ColumnSummary struct {
+	Name     string
+	DBType   string
+	Type     string
+	Nullable bool
+	Unique   bool
+	Default  string
+	// DefaultIsExpression is set when Default is evaluated by the server
+	DefaultIsExpression bool
+}
+
+// Summary returns the tables the generator would generate, with the Go
+// types their columns resolved to, once replacements were applied.
+func (s *State) Summary() []TableSummary {
+	summaries := make([]TableSummary, len(s.Tables))
+
+	for i, t := range s.Tables {
+		summary := TableSummary{
+			Name:   t.Name,
+			Model:  strmangle.TitleCase(s.Aliases.Singular(t.Name)),
+			IsView: t.IsView,
+			IsJoin: t.IsJoinTable,
+		}
+		if t.PKey != nil {
+			summary.PrimaryKey = t.PKey.Columns
+		}
+
+		for _, c := range t.Columns {
+			dbType := c.FullDBType
+			if len(dbType) == 0 {
+				dbType = c.DBType
+			}
+
+			summary.Columns = append(summary.Columns, ColumnSummary{
+				Name:     c.Name,
+				DBType:   dbType,
+				Type:     c.Type,
+				Nullable: c.Nullable,
+				Unique:   c.Unique,
+				Default:  c.Default,
+
+				DefaultIsExpression: c.DefaultIsExpression,
+			})
+		}
+
+		summaries[i] = summary
+	}
+
+	return summaries
+}
+
+// WriteSummary writes the Summary of the tables to w in a human readable
+// form, one table after the other.
+func (s *State) WriteSummary(w io.Writer) error {
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 4, 2, ' ', 0)
+
+	for i, t := range s.Summary() {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+
+		var kinds []string
+		if t.IsView {
+			kinds = append(kinds, "view")
+		} else if len(t.PrimaryKey) == 0 {
+			kinds = append(kinds, "read-only")
+		}
+		if t.IsJoin {
+			kinds = append(kinds, "join table")
+		}
+		fmt.Fprintf(tw, "%s (%s)", t.Name, t.Model)
+		if len(kinds) != 0 {
+			fmt.Fprintf(tw, " [%s]", strings.Join(kinds, ", "))
+		}
+		fmt.Fprintln(tw)
+
+		if len(t.PrimaryKey) != 0 {
+			fmt.Fprintf(tw, "  primary key: %s\n", strings.Join(t.PrimaryKey, ", "))
+		}
+
+		for _, c := range t.Columns {
+			var flags []string
+			if c.Nullable {
+				flags = append(flags, "nullable")
+			}
+			if c.Unique {
+				flags = append(flags, "unique")
+			}
+			if c.DefaultIsExpression {
+				flags = append(flags, "default expression "+c.Default)
+			} else if len(c.Default) != 0 {
+				flags = append(flags, "default "+c.Default)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", c.Name, c.DBType, c.Type, strings.Join(flags, ", "))
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Columns without flags are padded up to the flags column
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		trimmed := strings.TrimRight(line, " \n")
+		if len(trimmed) == len(line) {
+			continue
+		}
+		if _, err := io.WriteString(w, trimmed+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnknownTypeColumn is a column of a database type the driver doesn't know,
+// its Go type is the fallback of the driver
+type UnknownTypeColumn struct {
+	Table  string
+	Column string
+	DBType string
+	Type   string
+}
+
+// UnknownTypeColumns returns the columns of a database type the driver
+// doesn't know, leaving out those a type replacement applies to.
+func (s *State) UnknownTypeColumns() []UnknownTypeColumn {
+	var columns []UnknownTypeColumn
+
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			if !c.UnknownType {
+				continue
+			}
+
+			dbType := c.FullDBType
+			if len(dbType) == 0 {
+				dbType = c.DBType
+			}
+			columns = append(columns, UnknownTypeColumn{
+				Table:  t.Name,
+				Column: c.Name,
+				DBType: dbType,
+				Type:   c.Type,
+			})
+		}
+	}
+
+	return columns
+}
+
+// WriteUnknownTypes warns about the UnknownTypeColumns on w, one column per
+// line, nothing is written when there are none.
+func (s *State) WriteUnknownTypes(w io.Writer) error {
+	columns := s.UnknownTypeColumns()
+	if len(columns) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "warning: %d column(s) of unknown database types fell back to a default Go type:\n", len(columns))
+	for _, c := range columns {
+		fmt.Fprintf(tw, "  %s.%s\t%s\t%s\n", c.Table, c.Column, c.DBType, c.Type)
+	}
+
+	return tw.Flush()
+}
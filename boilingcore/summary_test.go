@@ -0,0 +1,137 @@
+package boilingcore
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	tables, err := bdb.Tables(&drivers.MockDriver{}, "public", []string{"airports", "jets", "pilots", "languages", "pilot_languages"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replaceTypes(tables, map[string]TypeReplacement{"jets.cargo": {Type: "types.JSON"}}, mapImports{})
+
+	s := &State{Tables: tables}
+	summaries := s.Summary()
+	if len(summaries) != 5 {
+		t.Fatalf("want 5 tables, got %d", len(summaries))
+	}
+
+	byName := map[string]TableSummary{}
+	for _, summary := range summaries {
+		byName[summary.Name] = summary
+	}
+
+	airports := byName["airports"]
+	want := TableSummary{
+		Name:       "airports",
+		Model:      "Airport",
+		PrimaryKey: []string{"id"},
+		Columns: []ColumnSummary{
+			{Name: "id", DBType: "integer", Type: "int"},
+			{Name: "size", DBType: "integer", Type: "null.Int", Nullable: true},
+		},
+	}
+	if !reflect.DeepEqual(airports, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, airports)
+	}
+
+	jets := byName["jets"]
+	types := map[string]string{}
+	for _, c := range jets.Columns {
+		types[c.Name] = c.Type
+	}
+	if types["color"] != "null.String" || types["cargo"] != "types.JSON" {
+		t.Errorf("wrong type resolutions: %v", types)
+	}
+
+	if !byName["pilot_languages"].IsJoin {
+		t.Error("pilot_languages should be a join table")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := s.WriteSummary(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, line := range []string{
+		"airports (Airport)\n  primary key: id\n",
+		"  size  integer  null.Int  nullable\n",
+		"  color       character  null.String  nullable\n",
+		"  cargo       bytea      types.JSON\n",
+		"pilot_languages (PilotLanguage) [join table]\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("summary is missing %q:\n%s", line, out)
+		}
+	}
+}
+
+func TestUnknownTypeColumns(t *testing.T) {
+	t.Parallel()
+
+	ch := drivers.NewClickhouseDriver(drivers.ClickhouseDriverConfig{})
+	column := func(name, fullType string) bdb.Column {
+		return ch.TranslateColumnType(bdb.Column{Name: name, FullDBType: fullType})
+	}
+
+	tables := []bdb.Table{
+		{
+			Name: "events",
+			Columns: []bdb.Column{
+				column("id", "UInt64"),
+				column("retention", "IntervalDay"),
+				column("windows", "Array(Nullable(IntervalHour))"),
+				column("visitors", "AggregateFunction(uniq, UInt64)"),
+			},
+		},
+		{
+			Name: "users",
+			Columns: []bdb.Column{
+				column("id", "UInt64"),
+				column("session", "IntervalMinute"),
+				column("payload", "Object('json')"),
+			},
+		},
+	}
+	replaceTypes(tables, map[string]TypeReplacement{"users.session": {Type: "int64"}}, mapImports{})
+
+	s := &State{Tables: tables}
+	want := []UnknownTypeColumn{
+		{Table: "events", Column: "retention", DBType: "IntervalDay", Type: "[]byte"},
+		{Table: "events", Column: "windows", DBType: "Array(Nullable(IntervalHour))", Type: "[]null.Bytes"},
+	}
+	if got := s.UnknownTypeColumns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, got)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := s.WriteUnknownTypes(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"warning: 2 column(s) of unknown database types fell back to a default Go type:\n",
+		"  events.retention  IntervalDay                    []byte\n",
+		"  events.windows    Array(Nullable(IntervalHour))  []null.Bytes\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("report is missing %q:\n%s", line, out)
+		}
+	}
+
+	buf.Reset()
+	known := &State{Tables: tables[1:]}
+	if err := known.WriteUnknownTypes(buf); err != nil || buf.Len() != 0 {
+		t.Errorf("want nothing reported, got %q (%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,182 @@
+package boilingcore
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// tableGlobFilter wraps a driver to resolve the glob patterns of the table
+// whitelist and blacklist, such as events_* or *_archive, which the drivers
+// can't put in their queries. All the tables are fetched instead and a table
+// is kept when it matches a whitelist entry, if there are any, and none of
+// the blacklist entries.
+type tableGlobFilter struct {
+	driverWrapper
+
+	whitelist []string
+	blacklist []string
+
+	// caseInsensitive matches the entries regardless of case
+	caseInsensitive bool
+}
+
+// isTableGlob reports whether a whitelist or blacklist entry is a pattern
+// rather than a table name.
+func isTableGlob(entry string) bool {
+	return strings.ContainsAny(entry, "*?[")
+}
+
+// newTableGlobFilter returns a driver resolving the table rules when one of
+// them is a glob, no rules are then left for bdb.Tables. The driver and the
+// rules are returned as is otherwise.
+func newTableGlobFilter(driver bdb.Interface, whitelist, blacklist []string, caseInsensitive bool) (bdb.Interface, []string, []string, error) {
+	hasGlob := false
+	for _, list := range [][]string{whitelist, blacklist} {
+		for _, entry := range list {
+			if !isTableGlob(entry) {
+				continue
+			}
+			if _, err := path.Match(entry, ""); err != nil {
+				return nil, nil, nil, errors.Wrapf(err, "invalid table pattern %q", entry)
+			}
+			hasGlob = true
+		}
+	}
+
+	if !hasGlob {
+		return driver, whitelist, blacklist, nil
+	}
+
+	filter := tableGlobFilter{
+		driverWrapper:   driverWrapper{driver},
+		whitelist:       whitelist,
+		blacklist:       blacklist,
+		caseInsensitive: caseInsensitive,
+	}
+
+	return filter, nil, nil, nil
+}
+
+// expandColumnGlobs replaces the "table.column" entries of the whitelist and
+// blacklist whose table is a glob by an entry for each table it matches, so
+// the column filter is given table names. The tables are only listed when
+// there is such an entry, one matching no table is kept as is.
+func expandColumnGlobs(driver bdb.Interface, schema string, whitelist, blacklist []string, caseInsensitive bool) ([]string, []string, error) {
+	var names []string
+	listed := false
+
+	expand := func(list []string) ([]string, error) {
+		var expanded []string
+		for _, entry := range list {
+			dot := strings.IndexByte(entry, '.')
+			if dot <= 0 || dot == len(entry)-1 || !isTableGlob(entry[:dot]) {
+				expanded = append(expanded, entry)
+				continue
+			}
+
+			pattern, column := entry[:dot], entry[dot:]
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid table pattern %q", pattern)
+			}
+
+			if !listed {
+				var err error
+				if names, err = driver.TableNames(schema, nil, nil); err != nil {
+					return nil, errors.Wrap(err, "unable to list the tables to expand the column rules")
+				}
+				listed = true
+			}
+
+			matched := false
+			for _, name := range names {
+				if tableMatches(name, []string{pattern}, caseInsensitive) {
+					expanded = append(expanded, name+column)
+					matched = true
+				}
+			}
+			if !matched {
+				expanded = append(expanded, entry)
+			}
+		}
+
+		return expanded, nil
+	}
+
+	whitelist, err := expand(whitelist)
+	if err != nil {
+		return nil, nil, err
+	}
+	blacklist, err = expand(blacklist)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return whitelist, blacklist, nil
+}
+
+// tableMatches reports whether table is named or matched by one of the
+// entries, patterns are those of path.Match. Both sides are lower cased
+// first when caseInsensitive is set.
+func tableMatches(table string, entries []string, caseInsensitive bool) bool {
+	if caseInsensitive {
+		table = strings.ToLower(table)
+	}
+
+	for _, entry := range entries {
+		if caseInsensitive {
+			entry = strings.ToLower(entry)
+		}
+		if ok, _ := path.Match(entry, table); ok || entry == table {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TableNames returns the names of all the tables of the schema left after
+// the whitelist and blacklist
+func (g tableGlobFilter) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	names, err := g.Interface.TableNames(schema, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, name := range names {
+		if g.included(name) {
+			filtered = append(filtered, name)
+		}
+	}
+
+	return filtered, nil
+}
+
+// included reports whether table is kept by the whitelist and blacklist
+func (g tableGlobFilter) included(table string) bool {
+	if len(g.whitelist) != 0 && !tableMatches(table, g.whitelist, g.caseInsensitive) {
+		return false
+	}
+	return !tableMatches(table, g.blacklist, g.caseInsensitive)
+}
+
+// ForeignKeyInfo leaves out the foreign keys to the tables filtered out,
+// bdb.Tables can't since it's given no rules
+func (g tableGlobFilter) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	fkeys, err := g.Interface.ForeignKeyInfo(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []bdb.ForeignKey
+	for _, fkey := range fkeys {
+		if g.included(fkey.ForeignTable) {
+			filtered = append(filtered, fkey)
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,193 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+)
+
+func TestTableMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Table           string
+		Entries         []string
+		CaseInsensitive bool
+		Want            bool
+	}{
+		{"events_2018", []string{"events_*"}, false, true},
+		{"events", []string{"events_*"}, false, false},
+		{"orders_archive", []string{"*_archive"}, false, true},
+		{"orders_archives", []string{"*_archive"}, false, false},
+		{"log_1", []string{"log_?"}, false, true},
+		{"log_12", []string{"log_?"}, false, false},
+		{"pilots", []string{"jets", "pilots"}, false, true},
+		{"pilots", nil, false, false},
+		{"Events_2018", []string{"events_*"}, false, false},
+		{"Events_2018", []string{"events_*"}, true, true},
+		{"orders_archive", []string{"*_ARCHIVE"}, true, true},
+		{"Pilots", []string{"PILOTS"}, true, true},
+		{"Pilots", []string{"PILOTS"}, false, false},
+	}
+
+	for _, test := range tests {
+		if got := tableMatches(test.Table, test.Entries, test.CaseInsensitive); got != test.Want {
+			t.Errorf("%s %v %t: want %t, got %t", test.Table, test.Entries, test.CaseInsensitive, test.Want, got)
+		}
+	}
+}
+
+func TestTableGlobFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Whitelist []string
+		Blacklist []string
+		Want      []string
+	}{
+		{[]string{"pilot*"}, nil, []string{"pilots", "pilot_languages"}},
+		{nil, []string{"*s"}, []string{}},
+		{nil, []string{"*ts", "jets"}, []string{"licenses", "hangars", "languages", "pilot_languages"}},
+		{[]string{"*s"}, []string{"pilot_*", "?ets"}, []string{"pilots", "airports", "licenses", "hangars", "languages"}},
+		{[]string{"jets", "hangar?"}, nil, []string{"jets", "hangars"}},
+	}
+
+	for _, test := range tests {
+		driver, whitelist, blacklist, err := newTableGlobFilter(&drivers.MockDriver{}, test.Whitelist, test.Blacklist, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if whitelist != nil || blacklist != nil {
+			t.Errorf("%v %v: want the rules resolved by the filter, got %v %v", test.Whitelist, test.Blacklist, whitelist, blacklist)
+		}
+
+		names, err := driver.TableNames("public", whitelist, blacklist)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names == nil {
+			names = []string{}
+		}
+		if !reflect.DeepEqual(names, test.Want) {
+			t.Errorf("%v %v: want %v, got %v", test.Whitelist, test.Blacklist, test.Want, names)
+		}
+	}
+}
+
+func TestTableGlobFilterNoGlobs(t *testing.T) {
+	t.Parallel()
+
+	mock := &drivers.MockDriver{}
+	driver, whitelist, blacklist, err := newTableGlobFilter(mock, []string{"jets"}, []string{"pilots"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if driver != mock {
+		t.Errorf("want the driver untouched, got %#v", driver)
+	}
+	if !reflect.DeepEqual(whitelist, []string{"jets"}) || !reflect.DeepEqual(blacklist, []string{"pilots"}) {
+		t.Errorf("want the rules untouched, got %v %v", whitelist, blacklist)
+	}
+
+	if _, _, _, err := newTableGlobFilter(mock, []string{"jets["}, nil, false); err == nil {
+		t.Error("want an error for a malformed pattern")
+	}
+}
+
+func TestTableGlobFilterForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	// jets has foreign keys to pilots and airports, the latter is excluded
+	driver, whitelist, blacklist, err := newTableGlobFilter(&drivers.MockDriver{}, []string{"jet?", "pilot?"}, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := bdb.Tables(driver, "public", whitelist, blacklist)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := bdb.GetTable(tables, "jets")
+	if len(jets.FKeys) != 1 || jets.FKeys[0].ForeignTable != "pilots" {
+		t.Errorf("want only the foreign key to pilots, got %#v", jets.FKeys)
+	}
+	for _, rel := range jets.ToManyRelationships {
+		if rel.ForeignTable == "airports" {
+			t.Errorf("want no relationship to airports, got %#v", rel)
+		}
+	}
+}
+
+func TestTableGlobFilterCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	driver, whitelist, blacklist, err := newTableGlobFilter(&drivers.MockDriver{}, []string{"PILOT*"}, []string{"Pilot_Languages"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := driver.TableNames("public", whitelist, blacklist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pilots"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+}
+
+func TestExpandColumnGlobs(t *testing.T) {
+	t.Parallel()
+
+	whitelist, blacklist, err := expandColumnGlobs(&drivers.MockDriver{}, "public",
+		[]string{"airports", "pilot*.id", "hangars.name", "zeppelin?.name"},
+		[]string{"*ets.cargo", "LICENSE?.pilot_id"},
+		true,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"airports", "pilots.id", "pilot_languages.id", "hangars.name", "zeppelin?.name"}; !reflect.DeepEqual(whitelist, want) {
+		t.Errorf("want whitelist %v, got %v", want, whitelist)
+	}
+	if want := []string{"jets.cargo", "licenses.pilot_id"}; !reflect.DeepEqual(blacklist, want) {
+		t.Errorf("want blacklist %v, got %v", want, blacklist)
+	}
+
+	if _, _, err := expandColumnGlobs(&drivers.MockDriver{}, "public", []string{"jets[.name"}, nil, false); err == nil {
+		t.Error("want an error for a malformed pattern")
+	}
+}
+
+func TestColumnGlobsFilterColumns(t *testing.T) {
+	t.Parallel()
+
+	whitelist, blacklist, err := expandColumnGlobs(&drivers.MockDriver{}, "public", []string{"jet?", "pilot?"}, []string{"j*.cargo"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	driver, whitelist, blacklist := newColumnFilter(&drivers.MockDriver{}, whitelist, blacklist)
+	driver, whitelist, blacklist, err = newTableGlobFilter(driver, whitelist, blacklist, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := bdb.Tables(driver, "public", whitelist, blacklist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 2 {
+		t.Errorf("want jets and pilots, got %d tables", len(tables))
+	}
+
+	jets := bdb.GetTable(tables, "jets")
+	for _, c := range jets.Columns {
+		if c.Name == "cargo" {
+			t.Error("want the cargo column blacklisted through the glob")
+		}
+	}
+}
@@ -19,6 +19,10 @@ type templateData struct {
 	Tables []bdb.Table
 	Table  bdb.Table
 
+	// Aliases name the models of the tables, ex: {{$.Aliases.Singular
+	// .Table.Name | titleCase}} is the struct name of the table
+	Aliases tableAliases
+
 	// Controls what names are output
 	PkgName string
 	Schema  string
@@ -31,11 +35,31 @@ type templateData struct {
 	NoHooks          bool
 	NoAutoTimestamps bool
 
+	// AutoTimestampsExempt are the table and table.column entries the auto
+	// timestamps are off for
+	AutoTimestampsExempt []string
+
+	// AutoColumns are the names of the auto timestamp columns
+	AutoColumns AutoColumns
+
+	// ModelsOnly leaves the relationships out of the structs
+	ModelsOnly bool
+
+	// ContextOnly leaves out the methods having a context-taking variant
+	ContextOnly bool
+
+	// SoftDeletes turns Delete into setting the AutoColumns.Deleted column
+	SoftDeletes bool
+
 	// Tags control which
 	Tags []string
 
-	// Generate struct tags as camelCase or snake_case
+	// Generate struct tags as snake_case, camelCase, PascalCase or TitleCase
 	StructTagCasing string
+	// DBTagCasing and JSONTagCasing are the casings of the Tags and of the
+	// json tags, StructTagCasing unless overridden
+	DBTagCasing   string
+	JSONTagCasing string
 
 	// StringFuncs are usable in templates with stringMap
 	StringFuncs map[string]func(string) string
@@ -50,7 +74,68 @@ func (t templateData) Quotes(s string) string {
 	return fmt.Sprintf("%s%s%s", t.LQ, s, t.RQ)
 }
 
+// QuotesQualified quotes each part of a qualified identifier such as
+// db.table.column with the driver's quotes.
+func (t templateData) QuotesQualified(s string) string {
+	return strmangle.QuoteQualified(t.LQ, t.RQ, s)
+}
+
+// AutoTimestamp reports whether column of the table is set by the auto
+// timestamps, they're off when disabled or when the table or the column
+// are exempt.
+func (t templateData) AutoTimestamp(column string) bool {
+	if t.NoAutoTimestamps {
+		return false
+	}
+
+	for _, exempt := range t.AutoTimestampsExempt {
+		if exempt == t.Table.Name || exempt == t.Table.Name+"."+column {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SoftDeleteColumn returns the column the rows of the table are soft deleted
+// with, nil when soft deletes are off or the table doesn't have one.
+func (t templateData) SoftDeleteColumn() *bdb.Column {
+	if !t.SoftDeletes {
+		return nil
+	}
+
+	for i := range t.Table.Columns {
+		if t.Table.Columns[i].Name == t.AutoColumns.Deleted {
+			return &t.Table.Columns[i]
+		}
+	}
+
+	return nil
+}
+
+// SoftDeleteValue returns the Go expression the soft delete column is set to,
+// the current time for a timestamp or true or 1 for a flag.
+func (t templateData) SoftDeleteValue() string {
+	c := t.SoftDeleteColumn()
+	switch {
+	case c == nil:
+		return ""
+	case c.Nullable:
+		return "null.TimeFrom(time.Now().In(boil.GetLocation()))"
+	case c.Type == "bool":
+		return "true"
+	default:
+		return c.Type + "(1)"
+	}
+}
+
 func (t templateData) SchemaTable(table string) string {
+	for _, tbl := range t.Tables {
+		if tbl.Name == table && len(tbl.SchemaName) != 0 {
+			return tbl.SchemaName
+		}
+	}
+
 	return strmangle.SchemaTable(t.LQ, t.RQ, t.DriverName, t.Schema, table)
 }
 
@@ -201,6 +286,8 @@ var templateFunctions = template.FuncMap{
 	"stringMap":          strmangle.StringMap,
 	"prefixStringSlice":  strmangle.PrefixStringSlice,
 	"containsAny":        strmangle.ContainsAny,
+	"tagCase":            strmangle.TagCase,
+	"commentLine":        strmangle.CommentLine,
 	"generateTags":       strmangle.GenerateTags,
 	"generateIgnoreTags": strmangle.GenerateIgnoreTags,
 
@@ -231,6 +318,7 @@ var templateFunctions = template.FuncMap{
 	"filterColumnsByAuto":    bdb.FilterColumnsByAuto,
 	"filterColumnsByDefault": bdb.FilterColumnsByDefault,
 	"filterColumnsByEnum":    bdb.FilterColumnsByEnum,
+	"enumLabels":             bdb.EnumLabels,
 	"sqlColDefinitions":      bdb.SQLColDefinitions,
 	"columnNames":            bdb.ColumnNames,
 	"columnDBTypes":          bdb.ColumnDBTypes,
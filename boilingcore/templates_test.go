@@ -1,9 +1,15 @@
 package boilingcore
 
 import (
+	"bytes"
+	"go/parser"
+	"go/token"
 	"sort"
+	"strings"
 	"testing"
 	"text/template"
+
+	"github.com/volatiletech/sqlboiler/bdb"
 )
 
 func TestTemplateNameListSort(t *testing.T) {
@@ -66,3 +72,722 @@ func TestTemplateList_Templates(t *testing.T) {
 		t.Error("don't want not")
 	}
 }
+
+func TestTemplateDataSchemaTable(t *testing.T) {
+	t.Parallel()
+
+	data := templateData{
+		Tables: []bdb.Table{
+			{Name: "analytics_users", SchemaName: "`analytics`.`users`"},
+			{Name: "billing_users", SchemaName: "`billing`.`users`"},
+			{Name: "plain"},
+		},
+		DriverName: "clickhouse",
+		Schema:     "default",
+		LQ:         "`",
+		RQ:         "`",
+	}
+
+	tests := map[string]string{
+		"analytics_users": "`analytics`.`users`",
+		"billing_users":   "`billing`.`users`",
+		"plain":           "`plain`",
+	}
+
+	for table, want := range tests {
+		if got := data.SchemaTable(table); got != want {
+			t.Errorf("%s: want %s, got %s", table, want, got)
+		}
+	}
+}
+
+func TestStructTemplateColumnConstants(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates", "00_struct.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := templateData{
+		Table: bdb.Table{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "uint64"},
+				{Name: "EventDate", Type: "time.Time"},
+				{Name: "userID", Type: "string"},
+			},
+		},
+		DriverName:  "clickhouse",
+		LQ:          "`",
+		RQ:          "`",
+		StringFuncs: templateStringMappers,
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.ExecuteTemplate(buf, "00_struct.tpl", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	want := []string{
+		"EventColumnID = \"id\"",
+		"EventColumnIDQuoted = \"`id`\"",
+		"EventColumnEventDate = \"EventDate\"",
+		"EventColumnEventDateQuoted = \"`EventDate`\"",
+		"EventColumnUserID = \"userID\"",
+		"EventColumnUserIDQuoted = \"`userID`\"",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("want %s in:\n%s", w, out)
+		}
+	}
+}
+
+func TestStructTemplateColumnNotes(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates", "00_struct.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := templateData{
+		Table: bdb.Table{
+			Name: "visits",
+			Columns: []bdb.Column{
+				{Name: "day", Type: "time.Time", Default: "today()", DefaultIsExpression: true},
+				{Name: "hits", Type: "uint64", Default: "0"},
+				{Name: "visitors", Type: "[]byte", AggregateState: true},
+			},
+			Projections: []bdb.Projection{{Name: "by_day", Query: "SELECT day, count() GROUP BY day"}},
+		},
+		DriverName:  "clickhouse",
+		LQ:          "`",
+		RQ:          "`",
+		StringFuncs: templateStringMappers,
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.ExecuteTemplate(buf, "00_struct.tpl", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	want := []string{
+		"// Projection by_day: SELECT day, count() GROUP BY day\ntype Visit struct {",
+		"// Defaults to today() when left out of inserts.\n\tDay time.Time",
+		"// Aggregation state, finalize it with the -Merge combinator to read it,\n\t// it's left out of inserts and updates.\n\tVisitors []byte",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("want %s in:\n%s", w, out)
+		}
+	}
+	if strings.Contains(out, "Defaults to 0") {
+		t.Errorf("want no note for the literal default in:\n%s", out)
+	}
+}
+
+func TestStructTemplateTagCasings(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates", "00_struct.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{StructTagCasing: "title", DBTagCasing: "snake", JSONTagCasing: "camel"}
+	dbTagCasing, jsonTagCasing := config.tagCasings()
+
+	data := templateData{
+		Table: bdb.Table{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "event_date", Type: "time.Time"},
+			},
+		},
+		DriverName:      "clickhouse",
+		Tags:            []string{"db"},
+		StructTagCasing: config.StructTagCasing,
+		DBTagCasing:     dbTagCasing,
+		JSONTagCasing:   jsonTagCasing,
+		LQ:              "`",
+		RQ:              "`",
+		StringFuncs:     templateStringMappers,
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.ExecuteTemplate(buf, "00_struct.tpl", data); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "EventDate time.Time `db:\"event_date\" boil:\"event_date\" json:\"eventDate\" toml:\"EventDate\" yaml:\"EventDate\"`"
+	if out := buf.String(); !strings.Contains(out, want) {
+		t.Errorf("want %s in:\n%s", want, out)
+	}
+
+	// Without overrides both follow StructTagCasing
+	config = &Config{StructTagCasing: "camel"}
+	if db, json := config.tagCasings(); db != "camel" || json != "camel" {
+		t.Errorf("want both casings to default to camel, got %s and %s", db, json)
+	}
+}
+
+func TestTemplateDataAutoTimestamp(t *testing.T) {
+	t.Parallel()
+
+	data := templateData{
+		AutoTimestampsExempt: []string{"events", "users.updated_at"},
+	}
+
+	tests := []struct {
+		Table    string
+		Column   string
+		Disabled bool
+		Want     bool
+	}{
+		{"events", "created_at", false, false},
+		{"events", "updated_at", false, false},
+		{"users", "created_at", false, true},
+		{"users", "updated_at", false, false},
+		{"pilots", "updated_at", false, true},
+		{"pilots", "updated_at", true, false},
+	}
+
+	for i, test := range tests {
+		data.Table = bdb.Table{Name: test.Table}
+		data.NoAutoTimestamps = test.Disabled
+		if got := data.AutoTimestamp(test.Column); got != test.Want {
+			t.Errorf("%d) want %t, got %t", i, test.Want, got)
+		}
+	}
+}
+
+func TestAutoTimestampsTemplateExempt(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates", "21_auto_timestamps.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns := []bdb.Column{
+		{Name: "id", Type: "int"},
+		{Name: "created_at", Type: "time.Time"},
+		{Name: "updated_at", Type: "time.Time"},
+	}
+	exempt := []string{"events"}
+
+	tests := []struct {
+		Table string
+		Want  bool
+	}{
+		{"events", false},
+		{"users", true},
+	}
+
+	for _, test := range tests {
+		data := templateData{
+			Table:                bdb.Table{Name: test.Table, Columns: columns},
+			AutoTimestampsExempt: exempt,
+			AutoColumns:          AutoColumns{}.withDefaults(),
+		}
+
+		buf := &bytes.Buffer{}
+		if err := tpl.ExecuteTemplate(buf, "timestamp_insert_helper", data); err != nil {
+			t.Fatal(err)
+		}
+
+		got := strings.Contains(buf.String(), "o.CreatedAt = currTime") &&
+			strings.Contains(buf.String(), "o.UpdatedAt = currTime")
+		if got != test.Want {
+			t.Errorf("%s: want auto timestamps %t, got:\n%s", test.Table, test.Want, buf.String())
+		}
+	}
+}
+
+func TestAutoTimestampsTemplateColumnNames(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates", "21_auto_timestamps.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := templateData{
+		Table: bdb.Table{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int"},
+				{Name: "created_at", Type: "time.Time"},
+				{Name: "create_time", Type: "time.Time"},
+				{Name: "update_time", Type: "null.Time", Nullable: true},
+			},
+		},
+		AutoColumns: AutoColumns{Created: "create_time", Updated: "update_time"}.withDefaults(),
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.ExecuteTemplate(buf, "timestamp_upsert_helper", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{"o.CreateTime = currTime", "o.UpdateTime.Time = currTime"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("want %s in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "o.CreatedAt") {
+		t.Errorf("created_at isn't the created column anymore:\n%s", out)
+	}
+}
+
+func TestDeleteTemplateSoftDeletes(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := bdb.Table{
+		Name: "users",
+		Columns: []bdb.Column{
+			{Name: "id", Type: "int"},
+			{Name: "is_deleted", Type: "uint8"},
+		},
+		PKey: &bdb.PrimaryKey{Columns: []string{"id"}},
+	}
+
+	tests := []struct {
+		SoftDeletes bool
+		Want        []string
+		DontWant    []string
+	}{
+		{
+			SoftDeletes: false,
+			Want:        []string{"DELETE FROM `users`", "queries.SetDelete(q.Query)"},
+			DontWant:    []string{"UPDATE `users`", "UsersNotDeleted"},
+		},
+		{
+			SoftDeletes: true,
+			Want: []string{
+				"deleted := uint8(1)",
+				"UPDATE `users` SET `is_deleted` = ? WHERE `id`=?",
+				`queries.SetUpdate(q.Query, map[string]interface{}{"is_deleted": uint8(1)})`,
+				"o.IsDeleted = deleted",
+				"func UsersNotDeleted() qm.QueryMod",
+				"qm.Where(\"`users`.`is_deleted` = ?\", 0)",
+			},
+			DontWant: []string{"DELETE FROM `users`", "queries.SetDelete(q.Query)"},
+		},
+	}
+
+	for i, test := range tests {
+		data := templateData{
+			Table:       table,
+			PkgName:     "models",
+			DriverName:  "clickhouse",
+			Schema:      "default",
+			LQ:          "`",
+			RQ:          "`",
+			NoHooks:     true,
+			AutoColumns: AutoColumns{Deleted: "is_deleted"}.withDefaults(),
+			SoftDeletes: test.SoftDeletes,
+		}
+
+		buf := &bytes.Buffer{}
+		for _, name := range []string{"13_all.tpl", "18_delete.tpl"} {
+			if err := tpls.ExecuteTemplate(buf, name, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		out := buf.String()
+
+		for _, want := range test.Want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%d) want %s in:\n%s", i, want, out)
+			}
+		}
+		for _, dontWant := range test.DontWant {
+			if strings.Contains(out, dontWant) {
+				t.Errorf("%d) don't want %s in:\n%s", i, dontWant, out)
+			}
+		}
+	}
+}
+
+func TestInsertTemplateClickhouseInsertAll(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := bdb.Table{
+		Name: "events",
+		Columns: []bdb.Column{
+			{Name: "id", Type: "uint64"},
+			{Name: "name", Type: "string"},
+			{Name: "created_at", Type: "time.Time"},
+		},
+		PKey: &bdb.PrimaryKey{Columns: []string{"id"}},
+	}
+
+	tests := []struct {
+		DriverName string
+		Want       bool
+	}{
+		{"clickhouse", true},
+		{"postgres", false},
+	}
+
+	for _, test := range tests {
+		data := templateData{
+			Table:       table,
+			PkgName:     "models",
+			DriverName:  test.DriverName,
+			LQ:          "`",
+			RQ:          "`",
+			AutoColumns: AutoColumns{}.withDefaults(),
+		}
+
+		buf := &bytes.Buffer{}
+		if err := tpls.ExecuteTemplate(buf, "15_insert.tpl", data); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+
+		if got := strings.Contains(out, "func (o EventSlice) InsertAll(exec boil.Executor, whitelist ...string) error"); got != test.Want {
+			t.Errorf("%s: want InsertAll %t, got %t", test.DriverName, test.Want, got)
+		}
+		if got := strings.Contains(out, "func (o EventSlice) InsertAllContext(ctx context.Context, exec boil.ContextExecutor, whitelist ...string) error"); got != test.Want {
+			t.Errorf("%s: want InsertAllContext %t, got %t", test.DriverName, test.Want, got)
+		}
+		if !test.Want {
+			continue
+		}
+
+		if !strings.Contains(out, "return o.insertAll(context.Background(), exec, whitelist)") {
+			t.Errorf("want InsertAll to insert with the background context in:\n%s", out)
+		}
+
+		insertAll := out[strings.Index(out, "func (o EventSlice) insertAll("):]
+
+		// A single row INSERT is prepared in a transaction, executed for
+		// each record and sent on commit
+		want := []string{
+			"o.CreatedAt = currTime",
+			"fmt.Sprintf(\"INSERT INTO `events` (`%s`) VALUES (%s)\"",
+			"strings.Join(wl, \"`,`\")",
+			"strmangle.Placeholders(dialect.IndexPlaceholders, len(wl), 1, 1)",
+			"exec.(boil.ContextBeginner)",
+			"beginner.BeginTx(ctx, nil)",
+			"preparer.PrepareContext(ctx, query)",
+			"stmt.ExecContext(ctx, vals...)",
+			"tx.Commit()",
+		}
+		last := 0
+		for _, w := range want {
+			idx := strings.Index(insertAll, w)
+			if idx < last {
+				t.Errorf("want %s after the previous steps in:\n%s", w, insertAll)
+				continue
+			}
+			last = idx
+		}
+
+		execLoop := insertAll[strings.Index(insertAll, "for _, obj := range o {\n\t\tvals :="):]
+		if !strings.Contains(execLoop[:strings.Index(execLoop, "stmt.Close()")], "stmt.ExecContext(ctx, vals...)") {
+			t.Errorf("want a statement executed per record in:\n%s", insertAll)
+		}
+		if strings.Contains(insertAll, "exec.Exec(") {
+			t.Errorf("want no insert outside of the batch in:\n%s", insertAll)
+		}
+
+		// Clickhouse has no way to return generated values
+		if strings.Contains(insertAll, "RETURNING") || strings.Contains(insertAll, "LastInsertId") || strings.Contains(insertAll, "QueryRow") {
+			t.Errorf("InsertAll shouldn't read generated values back:\n%s", insertAll)
+		}
+	}
+}
+
+func TestInsertTemplateContextOnly(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := templateData{
+		Table: bdb.Table{
+			Name:    "events",
+			Columns: []bdb.Column{{Name: "id", Type: "uint64"}, {Name: "name", Type: "string"}},
+			PKey:    &bdb.PrimaryKey{Columns: []string{"id"}},
+		},
+		PkgName:     "models",
+		DriverName:  "clickhouse",
+		LQ:          "`",
+		RQ:          "`",
+		ContextOnly: true,
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpls.ExecuteTemplate(buf, "15_insert.tpl", data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, name := range []string{"InsertAll(", "InsertAllG("} {
+		if strings.Contains(out, "func (o EventSlice) "+name) {
+			t.Errorf("want no %s without a context in:\n%s", name, out)
+		}
+	}
+	if !strings.Contains(out, "func (o EventSlice) InsertAllContext(ctx context.Context, exec boil.ContextExecutor, whitelist ...string) error") {
+		t.Errorf("want InsertAllContext in:\n%s", out)
+	}
+}
+
+func TestTemplatesQuoteReservedIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables := []bdb.Table{
+		{
+			Name: "orders",
+			Columns: []bdb.Column{
+				{Name: "order", Type: "uint64"},
+				{Name: "select", Type: "string"},
+			},
+			PKey: &bdb.PrimaryKey{Columns: []string{"order"}},
+			ToOneRelationships: []bdb.ToOneRelationship{
+				{Table: "orders", Column: "order", ForeignTable: "items", ForeignColumn: "select", Unique: true},
+			},
+		},
+		{
+			Name: "items",
+			Columns: []bdb.Column{
+				{Name: "order", Type: "uint64"},
+				{Name: "select", Type: "uint64"},
+			},
+			PKey: &bdb.PrimaryKey{Columns: []string{"order"}},
+			FKeys: []bdb.ForeignKey{
+				{Table: "items", Name: "items_select_fkey", Column: "select", ForeignTable: "orders", ForeignColumn: "order"},
+			},
+		},
+	}
+
+	data := templateData{
+		Tables:      tables,
+		Table:       tables[1],
+		PkgName:     "models",
+		DriverName:  "clickhouse",
+		LQ:          "`",
+		RQ:          "`",
+		AutoColumns: AutoColumns{}.withDefaults(),
+		StringFuncs: templateStringMappers,
+	}
+
+	buf := &bytes.Buffer{}
+	for _, name := range tpls.Templates() {
+		if err := tpls.ExecuteTemplate(buf, name, data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	out := buf.String()
+
+	want := []string{
+		"ItemColumnOrderQuoted = \"`order`\"",
+		"qm.Where(\"`order`=?\", o.Select)",
+		"select %s from `items` where `order`=?",
+		"DELETE FROM `items` WHERE `order`=?",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("want %s in:\n%s", w, out)
+		}
+	}
+
+	for _, bad := range []string{" order=", "\"order=", " select=", "\"select="} {
+		if strings.Contains(out, bad) {
+			t.Errorf("unquoted identifier %q in:\n%s", bad, out)
+		}
+	}
+}
+
+func TestBoilTypesTemplateClickhouseEnumValidation(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := loadTemplate("../templates/singleton", "boil_types.tpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := templateData{
+		Tables: []bdb.Table{{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "uint64", DBType: "UInt64"},
+				{Name: "status", Type: "string", DBType: "Enum8", EnumValues: []bdb.EnumValue{
+					{Label: "active", Value: 1},
+					{Label: "on hold", Value: 2},
+					{Label: `say "hi"`, Value: 3},
+				}},
+			},
+		}},
+		PkgName: "models",
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.Execute(buf, data); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	want := []string{
+		`var eventsStatusLabels = []string{"active", "on hold", "say \"hi\""}`,
+		"func IsValidEventsStatus(s string) bool {\n\treturn strmangle.SetInclude(s, eventsStatusLabels)\n}",
+		"func EventsStatusValues() []string {\n\treturn append([]string(nil), eventsStatusLabels...)\n}",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("want %s in:\n%s", w, out)
+		}
+	}
+
+	if strings.Contains(out, "IsValidEventsId") {
+		t.Errorf("want no validation for non enum columns:\n%s", out)
+	}
+
+	src := "package models\n" + out
+	if _, err := parser.ParseFile(token.NewFileSet(), "boil_types.go", src, 0); err != nil {
+		t.Errorf("generated code doesn't parse: %v\n%s", err, src)
+	}
+}
+
+func TestTemplatesReadOnlyView(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view := bdb.Table{
+		Name:   "daily_visits",
+		IsView: true,
+		Columns: []bdb.Column{
+			{Name: "day", Type: "time.Time"},
+			{Name: "visits", Type: "uint64"},
+		},
+	}
+
+	data := templateData{
+		Tables:      []bdb.Table{view},
+		Table:       view,
+		PkgName:     "models",
+		DriverName:  "clickhouse",
+		LQ:          "`",
+		RQ:          "`",
+		AutoColumns: AutoColumns{}.withDefaults(),
+		StringFuncs: templateStringMappers,
+	}
+
+	buf := &bytes.Buffer{}
+	for _, name := range tpls.Templates() {
+		if err := tpls.ExecuteTemplate(buf, name, data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	out := buf.String()
+
+	want := []string{
+		"type DailyVisit struct {",
+		"dailyVisitPrimaryKeyColumns     = []string{}",
+		"func DailyVisits(exec boil.Executor, mods ...qm.QueryMod) dailyVisitQuery {",
+		"func (q dailyVisitQuery) All() (DailyVisitSlice, error) {",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("want %s in:\n%s", w, out)
+		}
+	}
+
+	for _, bad := range []string{"FindDailyVisit(", "DailyVisitExists(", ") Reload(", ") Insert(", ") Update(", ") Upsert(", ") Delete("} {
+		if strings.Contains(out, bad) {
+			t.Errorf("want no %s for a read-only view", bad)
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "daily_visits.go", "package models\n"+out, 0); err != nil {
+		t.Errorf("generated code doesn't parse: %v", err)
+	}
+}
+
+func TestTemplatesExistsClickhouse(t *testing.T) {
+	t.Parallel()
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := bdb.Table{
+		Name: "pilots",
+		Columns: []bdb.Column{
+			{Name: "id", Type: "uint64"},
+			{Name: "name", Type: "string"},
+		},
+		PKey: &bdb.PrimaryKey{Columns: []string{"id"}},
+	}
+
+	exists := func(driverName, quote string, indexPlaceholders bool) string {
+		data := templateData{
+			Tables:      []bdb.Table{table},
+			Table:       table,
+			Schema:      "public",
+			PkgName:     "models",
+			DriverName:  driverName,
+			LQ:          quote,
+			RQ:          quote,
+			AutoColumns: AutoColumns{}.withDefaults(),
+			StringFuncs: templateStringMappers,
+		}
+		data.Dialect.IndexPlaceholders = indexPlaceholders
+
+		buf := &bytes.Buffer{}
+		if err := tpls.ExecuteTemplate(buf, "20_exists.tpl", data); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+
+		if _, err := parser.ParseFile(token.NewFileSet(), "pilots.go", "package models\n"+out, 0); err != nil {
+			t.Errorf("%s: generated code doesn't parse: %v\n%s", driverName, err, out)
+		}
+		return out
+	}
+
+	clickhouse := exists("clickhouse", "`", false)
+	if want := "query := \"select 1 from `pilots` where `id`=? limit 1\""; !strings.Contains(clickhouse, want) {
+		t.Errorf("want %s in:\n%s", want, clickhouse)
+	}
+	for _, bad := range []string{"select exists(", "LastInsertId"} {
+		if strings.Contains(clickhouse, bad) {
+			t.Errorf("want no %s for clickhouse in:\n%s", bad, clickhouse)
+		}
+	}
+
+	postgres := exists("postgres", `\"`, true)
+	if want := `sql := "select exists(select 1 from \"pilots\" where \"id\"=$1 limit 1)"`; !strings.Contains(postgres, want) {
+		t.Errorf("want %s in:\n%s", want, postgres)
+	}
+}
@@ -36,20 +36,20 @@ type TxtToOne struct {
 	}
 }
 
-func txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtToOne {
+func txtsFromFKey(aliases tableAliases, tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtToOne {
 	r := TxtToOne{}
 
 	r.ForeignKey = fkey
 
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
+	r.LocalTable.NameGo = strmangle.TitleCase(aliases.Singular(table.Name))
 	r.LocalTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.Column))
 
-	r.ForeignTable.NameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignTable))
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(fkey.ForeignTable))
+	r.ForeignTable.NameGo = strmangle.TitleCase(aliases.Singular(fkey.ForeignTable))
+	r.ForeignTable.NamePluralGo = strmangle.TitleCase(aliases.Plural(fkey.ForeignTable))
 	r.ForeignTable.ColumnName = fkey.ForeignColumn
 	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignColumn))
 
-	r.Function.Name, r.Function.ForeignName = txtNameToOne(fkey)
+	r.Function.Name, r.Function.ForeignName = txtNameToOne(aliases, fkey)
 
 	if fkey.Nullable {
 		col := table.GetColumn(fkey.Column)
@@ -72,7 +72,7 @@ func txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtT
 	return r
 }
 
-func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRelationship) TxtToOne {
+func txtsFromOneToOne(aliases tableAliases, tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRelationship) TxtToOne {
 	fkey := bdb.ForeignKey{
 		Table:    oneToOne.Table,
 		Name:     "none",
@@ -86,7 +86,7 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 		ForeignColumnUnique:   oneToOne.ForeignColumnUnique,
 	}
 
-	rel := txtsFromFKey(tables, table, fkey)
+	rel := txtsFromFKey(aliases, tables, table, fkey)
 	col := table.GetColumn(oneToOne.Column)
 
 	// Reverse foreign key
@@ -95,7 +95,7 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 	rel.ForeignKey.Nullable, rel.ForeignKey.ForeignColumnNullable = rel.ForeignKey.ForeignColumnNullable, rel.ForeignKey.Nullable
 	rel.ForeignKey.Unique, rel.ForeignKey.ForeignColumnUnique = rel.ForeignKey.ForeignColumnUnique, rel.ForeignKey.Unique
 	rel.Function.UsesBytes = col.Type == "[]byte"
-	rel.Function.ForeignName, rel.Function.Name = txtNameToOne(bdb.ForeignKey{
+	rel.Function.ForeignName, rel.Function.Name = txtNameToOne(aliases, bdb.ForeignKey{
 		Table:         oneToOne.ForeignTable,
 		Column:        oneToOne.ForeignColumn,
 		Unique:        true,
@@ -133,19 +133,19 @@ type TxtToMany struct {
 
 // txtsFromToMany creates a struct that does a lot of the text
 // transformation in advance for a given relationship.
-func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelationship) TxtToMany {
+func txtsFromToMany(aliases tableAliases, tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelationship) TxtToMany {
 	r := TxtToMany{}
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
+	r.LocalTable.NameGo = strmangle.TitleCase(aliases.Singular(table.Name))
 	r.LocalTable.ColumnNameGo = strmangle.TitleCase(rel.Column)
 
-	foreignNameSingular := strmangle.Singular(rel.ForeignTable)
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(rel.ForeignTable))
+	foreignNameSingular := aliases.Singular(rel.ForeignTable)
+	r.ForeignTable.NamePluralGo = strmangle.TitleCase(aliases.Plural(rel.ForeignTable))
 	r.ForeignTable.NameGo = strmangle.TitleCase(foreignNameSingular)
 	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(rel.ForeignColumn)
 	r.ForeignTable.Slice = fmt.Sprintf("%sSlice", strmangle.TitleCase(foreignNameSingular))
 	r.ForeignTable.NameHumanReadable = strings.Replace(rel.ForeignTable, "_", " ", -1)
 
-	r.Function.Name, r.Function.ForeignName = txtNameToMany(rel)
+	r.Function.Name, r.Function.ForeignName = txtNameToMany(aliases, rel)
 
 	col := table.GetColumn(rel.Column)
 	if rel.Nullable {
@@ -195,7 +195,10 @@ func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelations
 //
 // fk == table = industry.Industry | industry.Industry
 // fk != table = industry.ParentIndustry | industry.Industry
-func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
+//
+// The fk is compared to the table name, the functions are named after the
+// model of the table.
+func txtNameToOne(aliases tableAliases, fk bdb.ForeignKey) (localFn, foreignFn string) {
 	localFn = strmangle.Singular(trimSuffixes(fk.Column))
 	fkeyIsTableName := localFn != strmangle.Singular(fk.ForeignTable)
 	localFn = strmangle.TitleCase(localFn)
@@ -204,9 +207,9 @@ func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
 		foreignFn = localFn
 	}
 
-	plurality := strmangle.Plural
+	plurality := aliases.Plural
 	if fk.Unique {
-		plurality = strmangle.Singular
+		plurality = aliases.Singular
 	}
 	foreignFn += strmangle.TitleCase(plurality(fk.Table))
 
@@ -233,7 +236,10 @@ func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
 //
 // fk == table = industry.Industries
 // fk != table = industry.MappedIndustryIndustry
-func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
+//
+// The fks are compared to the table names, the functions are named after the
+// models of the tables.
+func txtNameToMany(aliases tableAliases, toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 	if toMany.ToJoinTable {
 		localFkey := strmangle.Singular(trimSuffixes(toMany.JoinLocalColumn))
 		foreignFkey := strmangle.Singular(trimSuffixes(toMany.JoinForeignColumn))
@@ -241,12 +247,12 @@ func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 		if localFkey != strmangle.Singular(toMany.Table) {
 			foreignFn = strmangle.TitleCase(localFkey)
 		}
-		foreignFn += strmangle.TitleCase(strmangle.Plural(toMany.Table))
+		foreignFn += strmangle.TitleCase(aliases.Plural(toMany.Table))
 
 		if foreignFkey != strmangle.Singular(toMany.ForeignTable) {
 			localFn = strmangle.TitleCase(foreignFkey)
 		}
-		localFn += strmangle.TitleCase(strmangle.Plural(toMany.ForeignTable))
+		localFn += strmangle.TitleCase(aliases.Plural(toMany.ForeignTable))
 
 		return localFn, foreignFn
 	}
@@ -255,7 +261,7 @@ func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 	if fkeyName != strmangle.Singular(toMany.Table) {
 		localFn = strmangle.TitleCase(fkeyName)
 	}
-	localFn += strmangle.TitleCase(strmangle.Plural(toMany.ForeignTable))
+	localFn += strmangle.TitleCase(aliases.Plural(toMany.ForeignTable))
 	foreignFn = strmangle.TitleCase(strmangle.Singular(fkeyName))
 	return localFn, foreignFn
 }
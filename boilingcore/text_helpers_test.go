@@ -18,7 +18,7 @@ func TestTxtsFromOne(t *testing.T) {
 	}
 
 	jets := bdb.GetTable(tables, "jets")
-	texts := txtsFromFKey(tables, jets, jets.FKeys[0])
+	texts := txtsFromFKey(nil, tables, jets, jets.FKeys[0])
 	expect := TxtToOne{}
 
 	expect.ForeignKey = jets.FKeys[0]
@@ -41,7 +41,7 @@ func TestTxtsFromOne(t *testing.T) {
 		t.Errorf("Want:\n%s\nGot:\n%s\n", spew.Sdump(expect), spew.Sdump(texts))
 	}
 
-	texts = txtsFromFKey(tables, jets, jets.FKeys[1])
+	texts = txtsFromFKey(nil, tables, jets, jets.FKeys[1])
 	expect = TxtToOne{}
 	expect.ForeignKey = jets.FKeys[1]
 
@@ -77,7 +77,7 @@ func TestTxtsFromOneToOne(t *testing.T) {
 	}
 
 	pilots := bdb.GetTable(tables, "pilots")
-	texts := txtsFromOneToOne(tables, pilots, pilots.ToOneRelationships[0])
+	texts := txtsFromOneToOne(nil, tables, pilots, pilots.ToOneRelationships[0])
 	expect := TxtToOne{}
 
 	expect.ForeignKey = bdb.ForeignKey{
@@ -122,7 +122,7 @@ func TestTxtsFromMany(t *testing.T) {
 	}
 
 	pilots := bdb.GetTable(tables, "pilots")
-	texts := txtsFromToMany(tables, pilots, pilots.ToManyRelationships[0])
+	texts := txtsFromToMany(nil, tables, pilots, pilots.ToManyRelationships[0])
 	expect := TxtToMany{}
 	expect.LocalTable.NameGo = "Pilot"
 	expect.LocalTable.ColumnNameGo = "ID"
@@ -142,7 +142,7 @@ func TestTxtsFromMany(t *testing.T) {
 		t.Errorf("Want:\n%s\nGot:\n%s\n", spew.Sdump(expect), spew.Sdump(texts))
 	}
 
-	texts = txtsFromToMany(tables, pilots, pilots.ToManyRelationships[1])
+	texts = txtsFromToMany(nil, tables, pilots, pilots.ToManyRelationships[1])
 	expect = TxtToMany{}
 	expect.LocalTable.NameGo = "Pilot"
 	expect.LocalTable.ColumnNameGo = "ID"
@@ -200,7 +200,7 @@ func TestTxtNameToOne(t *testing.T) {
 			ForeignTable: test.ForeignTable, ForeignColumn: test.ForeignColumn, ForeignColumnUnique: test.ForeignColumnUnique,
 		}
 
-		local, foreign := txtNameToOne(fk)
+		local, foreign := txtNameToOne(nil, fk)
 		if local != test.LocalFn {
 			t.Error(i, "local wrong:", local, "want:", test.LocalFn)
 		}
@@ -251,7 +251,7 @@ func TestTxtNameToMany(t *testing.T) {
 			JoinLocalColumn: test.JoinLocalColumn, JoinForeignColumn: test.JoinForeignColumn,
 		}
 
-		local, foreign := txtNameToMany(fk)
+		local, foreign := txtNameToMany(nil, fk)
 		if local != test.LocalFn {
 			t.Error(i, "local wrong:", local, "want:", test.LocalFn)
 		}
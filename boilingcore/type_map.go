@@ -0,0 +1,31 @@
+package boilingcore
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+)
+
+// loadTypeMap reads the section of the driver from a TOML type map file,
+// which maps database types to the Go type and import replacing the ones
+// picked by the driver, ex:
+//
+//	[clickhouse]
+//	  UInt64 = {type = "types.BigUint", import = "github.com/org/types"}
+//
+// A file without a section for the driver maps nothing.
+func loadTypeMap(path, driverName string) (map[string]TypeReplacement, error) {
+	var drivers map[string]map[string]TypeReplacement
+	if _, err := toml.DecodeFile(path, &drivers); err != nil {
+		return nil, errors.Wrapf(err, "unable to read the type map %s", path)
+	}
+
+	for name, typeMap := range drivers {
+		for dbType, r := range typeMap {
+			if len(r.Type) == 0 {
+				return nil, errors.Errorf("type map %s: no type for %s.%s", path, name, dbType)
+			}
+		}
+	}
+
+	return drivers[driverName], nil
+}
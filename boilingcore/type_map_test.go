@@ -0,0 +1,101 @@
+package boilingcore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func TestTypeMap(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "boil_type_map")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "types.toml")
+	file := `
+[clickhouse]
+  UInt64 = {type = "types.BigUint", import = "github.com/org/types"}
+
+[postgres]
+  bigint = {type = "int"}
+`
+	if err := ioutil.WriteFile(path, []byte(file), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	typeMap, err := loadTypeMap(path, "clickhouse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]TypeReplacement{"UInt64": {Type: "types.BigUint", Import: "github.com/org/types"}}
+	if !reflect.DeepEqual(typeMap, want) {
+		t.Errorf("want %#v, got %#v", want, typeMap)
+	}
+
+	tables := []bdb.Table{
+		{
+			Name: "events",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "uint64", DBType: "UInt64", FullDBType: "UInt64"},
+				{Name: "count", Type: "uint32", DBType: "UInt32", FullDBType: "UInt32"},
+				{Name: "name", Type: "string", DBType: "String", FullDBType: "String"},
+			},
+		},
+	}
+
+	typeImports := mapImports{}
+	replaceTypes(tables, typeMap, typeImports)
+
+	var types []string
+	for _, c := range tables[0].Columns {
+		types = append(types, c.Type)
+	}
+	if want := []string{"types.BigUint", "uint32", "string"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("want types %v, got %v", want, types)
+	}
+	wantImports := mapImports{
+		"types.BigUint": imports{thirdParty: importList{`"github.com/org/types"`}},
+	}
+	if !reflect.DeepEqual(typeImports, wantImports) {
+		t.Errorf("want imports %#v, got %#v", wantImports, typeImports)
+	}
+
+	if typeMap, err := loadTypeMap(path, "mysql"); err != nil || len(typeMap) != 0 {
+		t.Errorf("want nothing mapped for mysql, got %#v, %v", typeMap, err)
+	}
+}
+
+func TestTypeMapErrors(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "boil_type_map")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := map[string]string{
+		"missing.toml": "",
+		"invalid.toml": "[clickhouse\n",
+		"no_type.toml": "[clickhouse]\n  UInt64 = {import = \"github.com/org/types\"}\n",
+	}
+	for name, content := range tests {
+		path := filepath.Join(dir, name)
+		if len(content) != 0 {
+			if err := ioutil.WriteFile(path, []byte(content), 0666); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := loadTypeMap(path, "clickhouse"); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
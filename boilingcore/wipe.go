@@ -0,0 +1,104 @@
+package boilingcore
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// wipeGenerated deletes the files generated in the folder, hand-written files
+// and sub folders are left alone. The files are those listed by the manifest
+// of the last run when there is one, the files starting with the generated
+// code disclaimer otherwise. A missing folder has nothing to wipe.
+func wipeGenerated(folder string) error {
+	listed, err := readManifest(folder)
+	if err != nil {
+		return err
+	}
+	if listed != nil {
+		return wipeManifest(folder, listed)
+	}
+
+	files, err := ioutil.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "unable to list the output folder %s", folder)
+	}
+
+	for _, f := range files {
+		if !f.Mode().IsRegular() || filepath.Ext(f.Name()) != ".go" {
+			continue
+		}
+
+		path := filepath.Join(folder, f.Name())
+		generated, err := isGenerated(path)
+		if err != nil {
+			return err
+		}
+		if !generated {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "unable to delete generated file %s", path)
+		}
+	}
+
+	return nil
+}
+
+// wipeManifest deletes the files listed by the manifest of the folder, then
+// the manifest itself. Listed files which are missing or were edited since
+// they were generated are left alone.
+func wipeManifest(folder string, listed []manifestEntry) error {
+	for _, entry := range listed {
+		if filepath.Base(entry.Name) != entry.Name {
+			continue
+		}
+
+		path := filepath.Join(folder, entry.Name)
+		unedited, err := isUnedited(path, entry)
+		if os.IsNotExist(errors.Cause(err)) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !unedited {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "unable to delete generated file %s", path)
+		}
+	}
+
+	path := filepath.Join(folder, manifestFileName)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to delete the manifest %s", path)
+	}
+
+	return nil
+}
+
+// isGenerated reports whether the file starts with the disclaimer written
+// at the top of every generated file.
+func isGenerated(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to open %s", path)
+	}
+	defer f.Close()
+
+	header := make([]byte, len(noEditDisclaimer))
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, errors.Wrapf(err, "unable to read %s", path)
+	}
+
+	return bytes.Equal(header[:n], noEditDisclaimer), nil
+}
@@ -0,0 +1,118 @@
+package boilingcore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWipeOnlyMatching(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_wipe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	generated := append(append([]byte{}, noEditDisclaimer...), "package models\n"...)
+	files := map[string][]byte{
+		"pilots.go":      generated,
+		"pilots_test.go": generated,
+		"boil_types.go":  generated,
+		"custom.go":      []byte("package models\n\n// Hand-written helpers\n"),
+		"short.go":       []byte("package models\n"),
+		"notes.txt":      generated,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(out, name), content, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(out, "extra"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &State{Config: &Config{OutFolder: out, Wipe: true, WipeOnlyMatching: true}}
+	if err := s.initOutFolder(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"pilots.go", "pilots_test.go", "boil_types.go"} {
+		if _, err := os.Stat(filepath.Join(out, name)); !os.IsNotExist(err) {
+			t.Errorf("want generated file %s deleted, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"custom.go", "short.go", "notes.txt", "extra"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("want %s kept: %v", name, err)
+		}
+	}
+}
+
+func TestWipeOnlyMatchingMissingFolder(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_wipe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	folder := filepath.Join(out, "models")
+	s := &State{Config: &Config{OutFolder: folder, Wipe: true, WipeOnlyMatching: true}}
+	if err := s.initOutFolder(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(folder); err != nil {
+		t.Errorf("want the output folder created: %v", err)
+	}
+}
+
+func TestWipeOnlyMatchingManifest(t *testing.T) {
+	t.Parallel()
+
+	out, err := ioutil.TempDir("", "boil_wipe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(out)
+
+	// copied.go carries the disclaimer but this generator didn't write it,
+	// edited.go was generated and had the disclaimer removed since
+	generated := append(append([]byte{}, noEditDisclaimer...), "package models\n"...)
+	files := map[string][]byte{
+		"pilots.go":      generated,
+		"pilots_test.go": generated,
+		"copied.go":      generated,
+		"edited.go":      []byte("package models\n"),
+		"custom.go":      []byte("package models\n\n// Hand-written helpers\n"),
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(out, name), content, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest := append(append([]byte{}, manifestHeader...), "edited.go\nmissing.go\npilots.go\npilots_test.go\n"...)
+	if err := ioutil.WriteFile(filepath.Join(out, manifestFileName), manifest, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &State{Config: &Config{OutFolder: out, Wipe: true, WipeOnlyMatching: true}}
+	if err := s.initOutFolder(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"pilots.go", "pilots_test.go", manifestFileName} {
+		if _, err := os.Stat(filepath.Join(out, name)); !os.IsNotExist(err) {
+			t.Errorf("want %s deleted, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"copied.go", "edited.go", "custom.go"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("want %s kept: %v", name, err)
+		}
+	}
+}
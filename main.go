@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/kat-co/vala"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/volatiletech/sqlboiler/bdb/drivers"
@@ -93,15 +92,30 @@ func main() {
 	rootCmd.PersistentFlags().StringSliceP("blacklist", "b", nil, "Do not include these tables in your generated package")
 	rootCmd.PersistentFlags().StringSliceP("whitelist", "w", nil, "Only include these tables in your generated package")
 	rootCmd.PersistentFlags().StringSliceP("tag", "t", nil, "Struct tags to be included on your models in addition to json, yaml, toml")
-	rootCmd.PersistentFlags().StringSliceP("replace", "", nil, "Replace templates by directory: relpath/to_file.tpl:relpath/to_replacement.tpl")
+	rootCmd.PersistentFlags().StringSliceP("replace", "", nil, "Replace templates by directory: relpath/to_file.tpl:relpath/to_replacement.tpl, or rename models: table=>StructName")
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Debug mode prints stack traces on error")
 	rootCmd.PersistentFlags().BoolP("no-tests", "", false, "Disable generated go test files")
 	rootCmd.PersistentFlags().BoolP("no-hooks", "", false, "Disable hooks feature for your models")
 	rootCmd.PersistentFlags().BoolP("no-auto-timestamps", "", false, "Disable automatic timestamps for created_at/updated_at")
+	rootCmd.PersistentFlags().StringSliceP("auto-timestamps-exempt", "", nil, "Disable automatic timestamps for these tables or table.column entries")
+	rootCmd.PersistentFlags().StringP("created-column", "", "created_at", "Name of the column automatically set on insert")
+	rootCmd.PersistentFlags().StringP("updated-column", "", "updated_at", "Name of the column automatically set on insert and update")
+	rootCmd.PersistentFlags().StringP("deleted-column", "", "deleted_at", "Name of the column marking soft deleted rows")
+	rootCmd.PersistentFlags().BoolP("soft-deletes", "", false, "Set the deleted column instead of deleting the rows of the tables having it")
+	rootCmd.PersistentFlags().BoolP("models-only", "", false, "Only generate the structs and column names of the tables, without query helpers")
+	rootCmd.PersistentFlags().BoolP("context-only", "", false, "Leave out the methods having a variant taking a context.Context")
 	rootCmd.PersistentFlags().BoolP("version", "", false, "Print the version")
 	rootCmd.PersistentFlags().BoolP("tinyint-as-bool", "", false, "Map MySQL tinyint(1) in Go to bool instead of int8")
+	rootCmd.PersistentFlags().StringP("type-map", "", "", "TOML file mapping the database types of each driver to Go types")
 	rootCmd.PersistentFlags().BoolP("wipe", "", false, "Delete the output folder (rm -rf) before generation to ensure sanity")
-	rootCmd.PersistentFlags().StringP("struct-tag-casing", "", "snake", "Decides the casing for go structure tag names. camel or snake (default snake)")
+	rootCmd.PersistentFlags().BoolP("wipe-only-matching", "", false, "With --wipe, only delete the generated files of the output folder")
+	rootCmd.PersistentFlags().IntP("concurrency", "", 4, "Number of tables introspected at once")
+	rootCmd.PersistentFlags().BoolP("dry-run", "", false, "Print the tables, columns and Go types seen in the database instead of generating")
+	rootCmd.PersistentFlags().BoolP("force-nullable", "", false, "Use null types for all the columns, even the NOT NULL ones")
+	rootCmd.PersistentFlags().StringP("placeholders", "", "", "Placeholder style of the generated queries, ? or $N, instead of the driver's")
+	rootCmd.PersistentFlags().StringP("struct-tag-casing", "", "snake", "Decides the casing for go structure tag names. snake, camel, pascal or title (default snake)")
+	rootCmd.PersistentFlags().StringP("db-tag-casing", "", "", "Casing of the tags added with --tag, ex: db, instead of the struct-tag-casing")
+	rootCmd.PersistentFlags().StringP("json-tag-casing", "", "", "Casing of the json tags instead of the struct-tag-casing")
 
 	// hide flags not recommended for use
 	rootCmd.PersistentFlags().MarkHidden("replace")
@@ -116,6 +130,8 @@ func main() {
 	viper.SetDefault("clickhouse.skip_verify", false)
 	viper.SetDefault("clickhouse.port", 9000)
 	viper.SetDefault("clickhouse.no_delay", true)
+	viper.SetDefault("clickhouse.ignore_table_prefixes", []string{".inner."})
+	viper.SetDefault("clickhouse.system_databases", []string{"system"})
 
 	viper.BindPFlags(rootCmd.PersistentFlags())
 	viper.AutomaticEnv()
@@ -160,7 +176,23 @@ func preRun(cmd *cobra.Command, args []string) error {
 		NoHooks:          viper.GetBool("no-hooks"),
 		NoAutoTimestamps: viper.GetBool("no-auto-timestamps"),
 		Wipe:             viper.GetBool("wipe"),
-		StructTagCasing:  strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake
+		TypeMapFile:      viper.GetString("type-map"),
+		WipeOnlyMatching: viper.GetBool("wipe-only-matching"),
+		DryRun:           viper.GetBool("dry-run"),
+		Concurrency:      viper.GetInt("concurrency"),
+		StructTagCasing:  strings.ToLower(viper.GetString("struct-tag-casing")), // snake | camel | pascal | title
+		DBTagCasing:      strings.ToLower(viper.GetString("db-tag-casing")),
+		JSONTagCasing:    strings.ToLower(viper.GetString("json-tag-casing")),
+		SoftDeletes:      viper.GetBool("soft-deletes"),
+		ModelsOnly:       viper.GetBool("models-only"),
+		ContextOnly:      viper.GetBool("context-only"),
+		Placeholders:     viper.GetString("placeholders"),
+		ForceNullable:    viper.GetBool("force-nullable"),
+		AutoColumns: boilingcore.AutoColumns{
+			Created: viper.GetString("created-column"),
+			Updated: viper.GetString("updated-column"),
+			Deleted: viper.GetString("deleted-column"),
+		},
 	}
 
 	// BUG: https://github.com/spf13/viper/issues/200
@@ -183,6 +215,14 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	cmdConfig.AutoTimestampsExempt = viper.GetStringSlice("auto-timestamps-exempt")
+	if len(cmdConfig.AutoTimestampsExempt) == 1 && strings.ContainsRune(cmdConfig.AutoTimestampsExempt[0], ',') {
+		cmdConfig.AutoTimestampsExempt, err = cmd.PersistentFlags().GetStringSlice("auto-timestamps-exempt")
+		if err != nil {
+			return err
+		}
+	}
+
 	cmdConfig.Tags = viper.GetStringSlice("tag")
 	if len(cmdConfig.Tags) == 1 && strings.ContainsRune(cmdConfig.Tags[0], ',') {
 		cmdConfig.Tags, err = cmd.PersistentFlags().GetStringSlice("tag")
@@ -199,6 +239,15 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err = viper.UnmarshalKey("type_replacements", &cmdConfig.TypeReplacements); err != nil {
+		return commandFailure(fmt.Sprintf("unable to read type_replacements: %v", err))
+	}
+	cmdConfig.ImportPaths = viper.GetStringMapString("import_paths")
+	cmdConfig.DriverConfig = viper.GetStringMap(driverName)
+	if err = viper.UnmarshalKey("table_name_transforms", &cmdConfig.TableNameTransforms); err != nil {
+		return commandFailure(fmt.Sprintf("unable to read table_name_transforms: %v", err))
+	}
+
 	if driverName == "postgres" {
 		cmdConfig.Postgres = boilingcore.PostgresConfig{
 			User:    viper.GetString("postgres.user"),
@@ -207,6 +256,10 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Port:    viper.GetInt("postgres.port"),
 			DBName:  viper.GetString("postgres.dbname"),
 			SSLMode: viper.GetString("postgres.sslmode"),
+
+			CockroachDB: viper.GetBool("postgres.cockroachdb"),
+			Schemas:     viper.GetStringSlice("postgres.schemas"),
+			SearchPath:  viper.GetString("postgres.search_path"),
 		}
 
 		// BUG: https://github.com/spf13/viper/issues/71
@@ -221,22 +274,6 @@ func preRun(cmd *cobra.Command, args []string) error {
 			cmdConfig.Postgres.Port = 5432
 			viper.Set("postgres.port", cmdConfig.Postgres.Port)
 		}
-
-		if len(cmdConfig.Schema) == 0 {
-			cmdConfig.Schema = "public"
-		}
-
-		err = vala.BeginValidation().Validate(
-			vala.StringNotEmpty(cmdConfig.Postgres.User, "postgres.user"),
-			vala.StringNotEmpty(cmdConfig.Postgres.Host, "postgres.host"),
-			vala.Not(vala.Equals(cmdConfig.Postgres.Port, 0, "postgres.port")),
-			vala.StringNotEmpty(cmdConfig.Postgres.DBName, "postgres.dbname"),
-			vala.StringNotEmpty(cmdConfig.Postgres.SSLMode, "postgres.sslmode"),
-		).Check()
-
-		if err != nil {
-			return commandFailure(err.Error())
-		}
 	}
 
 	if driverName == "mysql" {
@@ -247,14 +284,17 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Port:    viper.GetInt("mysql.port"),
 			DBName:  viper.GetString("mysql.dbname"),
 			SSLMode: viper.GetString("mysql.sslmode"),
+			TLS: boilingcore.MySQLTLSConfig{
+				CA:         viper.GetString("mysql.tls_ca"),
+				Cert:       viper.GetString("mysql.tls_cert"),
+				Key:        viper.GetString("mysql.tls_key"),
+				ServerName: viper.GetString("mysql.tls_server_name"),
+			},
 		}
 
 		// Set MySQL TinyintAsBool global var. This flag only applies to MySQL.
 		drivers.TinyintAsBool = viper.GetBool("tinyint-as-bool")
 
-		// MySQL doesn't have schemas, just databases
-		cmdConfig.Schema = cmdConfig.MySQL.DBName
-
 		// BUG: https://github.com/spf13/viper/issues/71
 		// Despite setting defaults, nested values don't get defaults
 		// Set them manually
@@ -267,18 +307,6 @@ func preRun(cmd *cobra.Command, args []string) error {
 			cmdConfig.MySQL.Port = 3306
 			viper.Set("mysql.port", cmdConfig.MySQL.Port)
 		}
-
-		err = vala.BeginValidation().Validate(
-			vala.StringNotEmpty(cmdConfig.MySQL.User, "mysql.user"),
-			vala.StringNotEmpty(cmdConfig.MySQL.Host, "mysql.host"),
-			vala.Not(vala.Equals(cmdConfig.MySQL.Port, 0, "mysql.port")),
-			vala.StringNotEmpty(cmdConfig.MySQL.DBName, "mysql.dbname"),
-			vala.StringNotEmpty(cmdConfig.MySQL.SSLMode, "mysql.sslmode"),
-		).Check()
-
-		if err != nil {
-			return commandFailure(err.Error())
-		}
 	}
 
 	if driverName == "mssql" {
@@ -303,26 +331,11 @@ func preRun(cmd *cobra.Command, args []string) error {
 			cmdConfig.MSSQL.Port = 1433
 			viper.Set("mssql.port", cmdConfig.MSSQL.Port)
 		}
-
-		if len(cmdConfig.Schema) == 0 {
-			cmdConfig.Schema = "dbo"
-		}
-
-		err = vala.BeginValidation().Validate(
-			vala.StringNotEmpty(cmdConfig.MSSQL.User, "mssql.user"),
-			vala.StringNotEmpty(cmdConfig.MSSQL.Host, "mssql.host"),
-			vala.Not(vala.Equals(cmdConfig.MSSQL.Port, 0, "mssql.port")),
-			vala.StringNotEmpty(cmdConfig.MSSQL.DBName, "mssql.dbname"),
-			vala.StringNotEmpty(cmdConfig.MSSQL.SSLMode, "mssql.sslmode"),
-		).Check()
-
-		if err != nil {
-			return commandFailure(err.Error())
-		}
 	}
 
 	if driverName == "clickhouse" {
 		cmdConfig.Clickhouse = boilingcore.ClickhouseConfig{
+			DSN:                    viper.GetString("clickhouse.dsn"),
 			Username:               viper.GetString("clickhouse.username"),
 			Password:               viper.GetString("clickhouse.password"),
 			Database:               viper.GetString("clickhouse.database"),
@@ -330,6 +343,7 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Port:                   viper.GetInt("clickhouse.port"),
 			ReadTimeout:            viper.GetInt("clickhouse.read_timeout"),
 			WriteTimeout:           viper.GetInt("clickhouse.write_timeout"),
+			DialTimeout:            viper.GetInt("clickhouse.dial_timeout"),
 			NoDelay:                viper.GetBool("clickhouse.no_delay"),
 			AltHosts:               viper.GetStringSlice("clickhouse.alt_hosts"),
 			ConnectionOpenStrategy: viper.GetString("clickhouse.connection_open_strategy"),
@@ -337,23 +351,30 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Debug:                  viper.GetBool("clickhouse.debug"),
 			Secure:                 viper.GetBool("clickhouse.secure"),
 			SkipVerify:             viper.GetBool("clickhouse.skip_verify"),
+			Compress:               viper.GetBool("clickhouse.compress"),
+			Databases:              viper.GetStringSlice("clickhouse.databases"),
+			IgnoreTablePrefixes:    viper.GetStringSlice("clickhouse.ignore_table_prefixes"),
+			SystemDatabases:        viper.GetStringSlice("clickhouse.system_databases"),
+			CaseInsensitiveTables:  viper.GetBool("clickhouse.case_insensitive_tables"),
+			Cluster:                viper.GetString("clickhouse.cluster"),
+			BulkIntrospection:      viper.GetBool("clickhouse.bulk_introspection"),
+			Settings:               viper.GetStringMapString("clickhouse.settings"),
+			RelationsFile:          viper.GetString("clickhouse.relations_file"),
+			EnumAsInt:              viper.GetBool("clickhouse.enum_as_int"),
+			UnknownType:            viper.GetString("clickhouse.unknown_type"),
 		}
+	}
 
-		// Clickhouse doesn't have schemas, just databases
-		cmdConfig.Schema = cmdConfig.Clickhouse.Database
-
-		err = vala.BeginValidation().Validate(
-			vala.StringNotEmpty(cmdConfig.Clickhouse.Host, "clickhouse.host"),
-			vala.Not(vala.Equals(cmdConfig.Clickhouse.Port, 0, "clickhouse.port")),
-			vala.StringNotEmpty(cmdConfig.Clickhouse.Database, "clickhouse.database"),
-		).Check()
-
-		if err != nil {
-			return commandFailure(err.Error())
+	if driverName == "sqlite" {
+		cmdConfig.SQLite = boilingcore.SQLiteConfig{
+			DBName: viper.GetString("sqlite.dbname"),
 		}
 	}
 
 	cmdState, err = boilingcore.New(cmdConfig)
+	if errs, ok := err.(boilingcore.ConfigErrors); ok {
+		return commandFailure(errs.Error())
+	}
 	return err
 }
 
@@ -3,9 +3,12 @@ package randomize
 
 import (
 	"database/sql"
+	"encoding/binary"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
+	"net"
 	"reflect"
 	"regexp"
 	"sort"
@@ -49,6 +52,12 @@ var (
 	typeFloat64Array = reflect.TypeOf(types.Float64Array{})
 	typeStringArray  = reflect.TypeOf(types.StringArray{})
 	typeHStore       = reflect.TypeOf(types.HStore{})
+	typeBigInt       = reflect.TypeOf(types.BigInt{})
+	typeNullBigInt   = reflect.TypeOf(types.NullBigInt{})
+	typeNullDecimal  = reflect.TypeOf(types.NullDecimal{})
+	typeNullUUID     = reflect.TypeOf(types.NullUUID{})
+	typeNullIP       = reflect.TypeOf(types.NullIP{})
+	typeNullFixed    = reflect.TypeOf(types.NullFixedString{})
 	rgxValidTime     = regexp.MustCompile(`[2-9]+`)
 
 	validatedTypes = []string{
@@ -489,6 +498,18 @@ func getStructNullValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(nil, false)
 	case typeNullByte:
 		return null.NewByte(byte(0), false)
+	case typeBigInt:
+		return types.BigInt{}
+	case typeNullBigInt:
+		return types.NullBigInt{}
+	case typeNullDecimal:
+		return types.NullDecimal{}
+	case typeNullUUID:
+		return types.NullUUID{}
+	case typeNullIP:
+		return types.NullIP{}
+	case typeNullFixed:
+		return types.NullFixedString{}
 	}
 
 	return nil
@@ -535,6 +556,18 @@ func getStructRandValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(randByteSlice(s, 1), true)
 	case typeNullByte:
 		return null.NewByte(byte(rand.Intn(125-65)+65), true)
+	case typeBigInt:
+		return randBigInt(s)
+	case typeNullBigInt:
+		return types.NullBigInt{BigInt: randBigInt(s), Valid: true}
+	case typeNullDecimal:
+		return types.NullDecimal{Decimal: randDecimal(s), Valid: true}
+	case typeNullUUID:
+		return types.NullUUID{UUID: randUUID(s), Valid: true}
+	case typeNullIP:
+		return types.NullIP{IP: randIP(s), Valid: true}
+	case typeNullFixed:
+		return types.NullFixedString{FixedString: types.FixedString(randStr(s, 1)), Valid: true}
 	}
 
 	return nil
@@ -546,6 +579,14 @@ func getVariableZeroValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	case "types.Byte":
 		// Decimal 65 is 'A'. 0 is not a valid UTF8, so cannot use a zero value here.
 		return types.Byte(65)
+	case "types.Point":
+		return types.Point{}
+	case "types.Decimal":
+		return types.Decimal("0")
+	case "types.UUID":
+		return types.UUID{}
+	case "types.FixedString":
+		return types.FixedString("")
 	}
 
 	switch kind {
@@ -591,6 +632,28 @@ func getVariableRandValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	switch typ.String() {
 	case "types.Byte":
 		return types.Byte(rand.Intn(125-65) + 65)
+	case "types.ClickhouseStringArray":
+		return types.ClickhouseStringArray{randStr(s, 4), randStr(s, 4)}
+	case "types.ClickhouseInt64Array":
+		return types.ClickhouseInt64Array{int64(s.nextInt()), int64(s.nextInt())}
+	case "types.ClickhouseFloat64Array":
+		return types.ClickhouseFloat64Array{float64(s.nextInt()), float64(s.nextInt())}
+	case "types.Point":
+		return randGeoPoint(s)
+	case "types.Ring":
+		return randGeoRing(s)
+	case "types.Polygon":
+		return types.Polygon{randGeoRing(s)}
+	case "types.MultiPolygon":
+		return types.MultiPolygon{{randGeoRing(s)}, {randGeoRing(s)}}
+	case "types.Decimal":
+		return randDecimal(s)
+	case "types.UUID":
+		return randUUID(s)
+	case "types.IP":
+		return randIP(s)
+	case "types.FixedString":
+		return types.FixedString(randStr(s, 1))
 	}
 
 	switch kind {
@@ -633,6 +696,41 @@ func getVariableRandValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	return nil
 }
 
+// randGeoPoint returns a point within the valid longitudes and latitudes
+func randGeoPoint(s *Seed) types.Point {
+	return types.Point{float64(s.nextInt()%360 - 180), float64(s.nextInt()%180 - 90)}
+}
+
+// randGeoRing returns a closed ring of three random points
+func randGeoRing(s *Seed) types.Ring {
+	first := randGeoPoint(s)
+	return types.Ring{first, randGeoPoint(s), randGeoPoint(s), first}
+}
+
+// randBigInt returns an integer wider than 64 bits, which fits the 128 and
+// 256 bits integers, signed or not
+func randBigInt(s *Seed) types.BigInt {
+	return types.NewBigInt(new(big.Int).Lsh(big.NewInt(int64(s.nextInt())), 64))
+}
+
+// randDecimal returns a single digit decimal, which fits any precision
+func randDecimal(s *Seed) types.Decimal {
+	return types.Decimal(strconv.Itoa(s.nextInt() % 10))
+}
+
+// randUUID returns a UUID made of the next seeds
+func randUUID(s *Seed) types.UUID {
+	var u types.UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(s.nextInt()))
+	binary.BigEndian.PutUint64(u[8:], uint64(s.nextInt()))
+	return u
+}
+
+// randIP returns a private IPv4 address, which IPv6 columns take as well
+func randIP(s *Seed) types.IP {
+	return types.IP(net.IPv4(10, byte(s.nextInt()), byte(s.nextInt()), byte(s.nextInt()%254+1)))
+}
+
 func randEnumValue(s *Seed, enum string) (string, error) {
 	vals := strmangle.ParseEnumVals(enum)
 	if vals == nil || len(vals) == 0 {
@@ -1,11 +1,14 @@
 package randomize
 
 import (
+	"net"
 	"reflect"
 	"testing"
 	"time"
 
 	null "gopkg.in/volatiletech/null.v6"
+
+	"github.com/volatiletech/sqlboiler/types"
 )
 
 func TestRandomizeStruct(t *testing.T) {
@@ -145,6 +148,93 @@ func TestRandomizeField(t *testing.T) {
 	}
 }
 
+func TestRandomizeFieldGeo(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	var geo struct {
+		Point        types.Point
+		Ring         types.Ring
+		Polygon      types.Polygon
+		MultiPolygon types.MultiPolygon
+	}
+	colTypes := map[string]string{
+		"Point":        "Point",
+		"Ring":         "Ring",
+		"Polygon":      "Polygon",
+		"MultiPolygon": "MultiPolygon",
+	}
+
+	if err := Struct(s, &geo, colTypes, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(geo.Ring) == 0 || geo.Ring[0] != geo.Ring[len(geo.Ring)-1] {
+		t.Errorf("want a closed ring, got %#v", geo.Ring)
+	}
+	if len(geo.Polygon) != 1 || len(geo.Polygon[0]) == 0 {
+		t.Errorf("want a polygon with an outer ring, got %#v", geo.Polygon)
+	}
+	if len(geo.MultiPolygon) == 0 {
+		t.Errorf("want polygons, got %#v", geo.MultiPolygon)
+	}
+	for _, p := range append(geo.Ring, geo.Point) {
+		if p[0] < -180 || p[0] > 180 || p[1] < -90 || p[1] > 90 {
+			t.Errorf("want a point within the coordinates, got %v", p)
+		}
+	}
+}
+
+func TestRandomizeFieldClickhouseTypes(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	for i := 0; i < 10; i++ {
+		var str struct {
+			BigInt      types.BigInt
+			Decimal     types.Decimal
+			UUID        types.UUID
+			IP          types.IP
+			FixedString types.FixedString
+
+			NullBigInt      types.NullBigInt
+			NullDecimal     types.NullDecimal
+			NullUUID        types.NullUUID
+			NullIP          types.NullIP
+			NullFixedString types.NullFixedString
+		}
+
+		// Null and zero values must be valid values of their types as well
+		if err := Struct(s, &str, nil, i%2 == 0); err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			continue
+		}
+
+		if str.BigInt.BitLen() <= 64 {
+			t.Errorf("want an integer wider than 64 bits, got %s", str.BigInt.String())
+		}
+		if _, err := str.Decimal.Value(); err != nil {
+			t.Errorf("want a valid decimal, got %q: %v", str.Decimal, err)
+		}
+		if str.UUID == (types.UUID{}) {
+			t.Error("want a random UUID")
+		}
+		if net.IP(str.IP).To4() == nil {
+			t.Errorf("want an IPv4 address, got %v", str.IP)
+		}
+		if len(str.FixedString) == 0 {
+			t.Error("want a random fixed string")
+		}
+		if !str.NullBigInt.Valid || !str.NullDecimal.Valid || !str.NullUUID.Valid || !str.NullIP.Valid || !str.NullFixedString.Valid {
+			t.Errorf("want valid null values, got %#v", str)
+		}
+	}
+}
+
 func TestRandEnumValue(t *testing.T) {
 	t.Parallel()
 
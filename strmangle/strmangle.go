@@ -15,7 +15,7 @@ import (
 
 var (
 	idAlphabet    = []byte("abcdefghijklmnopqrstuvwxyz")
-	smartQuoteRgx = regexp.MustCompile(`^(?i)"?[a-z_][_a-z0-9]*"?(\."?[_a-z][_a-z0-9]*"?)*(\.\*)?$`)
+	smartQuoteRgx = regexp.MustCompile(`^(?i)["\x60\[]?[a-z_][_a-z0-9]*["\x60\]]?(\.["\x60\[]?[_a-z][_a-z0-9]*["\x60\]]?)*(\.\*)?$`)
 
 	rgxEnum            = regexp.MustCompile(`^enum(\.[a-z0-9_]+)?\((,?'[^']+')+\)$`)
 	rgxEnumIsOK        = regexp.MustCompile(`^(?i)[a-z][a-z0-9_]*$`)
@@ -93,6 +93,22 @@ func SchemaTable(lq, rq string, driver string, schema string, table string) stri
 	return fmt.Sprintf(`%s%s%s`, lq, table, rq)
 }
 
+// QuoteQualified quotes every part of a qualified identifier such as
+// db.table.column, unlike IdentQuote it does so unconditionally so
+// reserved words and odd names are safe. Empty parts are skipped.
+func QuoteQualified(lq, rq string, ident string) string {
+	splits := strings.Split(ident, ".")
+	parts := make([]string, 0, len(splits))
+	for _, split := range splits {
+		if len(split) == 0 {
+			continue
+		}
+		parts = append(parts, lq+split+rq)
+	}
+
+	return strings.Join(parts, ".")
+}
+
 // IdentQuote attempts to quote simple identifiers in SQL statements
 func IdentQuote(lq byte, rq byte, s string) string {
 	if strings.ToLower(s) == "null" || s == "?" {
@@ -354,6 +370,23 @@ func CamelCase(name string) string {
 	return buf.String()
 }
 
+// TagCase converts a snake-case column name into the casing used for
+// generated struct tags:
+//
+// snake (default): column_name_id
+// camel: columnNameID
+// pascal, title: the Go field name, with TitleCase's acronyms: APIKeyID
+func TagCase(casing, name string) string {
+	switch casing {
+	case "camel":
+		return CamelCase(name)
+	case "pascal", "title":
+		return TitleCase(name)
+	default:
+		return name
+	}
+}
+
 // TitleCaseIdentifier splits on dots and then titlecases each fragment.
 // map titleCase (split c ".")
 func TitleCaseIdentifier(id string) string {
@@ -632,6 +665,12 @@ func GenerateIgnoreTags(tags []string) string {
 	return buf.String()
 }
 
+// CommentLine turns a database comment into a single line that can be
+// used in a Go // comment, newlines and runs of whitespace become a space.
+func CommentLine(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
+
 // ParseEnumVals returns the values from an enum string
 //
 // Postgres and MySQL drivers return different values
@@ -33,6 +33,44 @@ func TestIdentQuote(t *testing.T) {
 			t.Errorf("want: %s, got: %s", test.Out, got)
 		}
 	}
+
+	backtickTests := []struct {
+		In  string
+		Out string
+	}{
+		{In: "order", Out: "`order`"},
+		{In: "`order`", Out: "`order`"},
+		{In: "db.table.select", Out: "`db`.`table`.`select`"},
+		{In: "`t`.order", Out: "`t`.`order`"},
+		{In: "t.`order`", Out: "`t`.`order`"},
+	}
+
+	for _, test := range backtickTests {
+		if got := IdentQuote('`', '`', test.In); got != test.Out {
+			t.Errorf("want: %s, got: %s", test.Out, got)
+		}
+	}
+}
+
+func TestQuoteQualified(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out string
+	}{
+		{In: "order", Out: "`order`"},
+		{In: "db.order", Out: "`db`.`order`"},
+		{In: "db.table.select", Out: "`db`.`table`.`select`"},
+		{In: ".table.select", Out: "`table`.`select`"},
+		{In: "", Out: ""},
+	}
+
+	for _, test := range tests {
+		if got := QuoteQualified("`", "`", test.In); got != test.Out {
+			t.Errorf("want: %s, got: %s", test.Out, got)
+		}
+	}
 }
 
 func TestIdentQuoteSlice(t *testing.T) {
@@ -257,6 +295,44 @@ func TestCamelCase(t *testing.T) {
 	}
 }
 
+func TestTagCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In     string
+		Snake  string
+		Camel  string
+		Pascal string
+		Title  string
+	}{
+		{"api_v2_id", "api_v2_id", "apiV2ID", "APIV2ID", "APIV2ID"},
+		{"user_id", "user_id", "userID", "UserID", "UserID"},
+		{"id", "id", "id", "ID", "ID"},
+		{"json_api_url", "json_api_url", "jsonAPIURL", "JSONAPIURL", "JSONAPIURL"},
+		{"thing_uuid_2", "thing_uuid_2", "thingUUID2", "ThingUUID2", "ThingUUID2"},
+		{"address_line1", "address_line1", "addressLine1", "AddressLine1", "AddressLine1"},
+		{"ip_address", "ip_address", "ipAddress", "IPAddress", "IPAddress"},
+	}
+
+	for i, test := range tests {
+		if out := TagCase("snake", test.In); out != test.Snake {
+			t.Errorf("[%d] snake (%s): want %s, got %s", i, test.In, test.Snake, out)
+		}
+		if out := TagCase("", test.In); out != test.Snake {
+			t.Errorf("[%d] default (%s): want %s, got %s", i, test.In, test.Snake, out)
+		}
+		if out := TagCase("camel", test.In); out != test.Camel {
+			t.Errorf("[%d] camel (%s): want %s, got %s", i, test.In, test.Camel, out)
+		}
+		if out := TagCase("pascal", test.In); out != test.Pascal {
+			t.Errorf("[%d] pascal (%s): want %s, got %s", i, test.In, test.Pascal, out)
+		}
+		if out := TagCase("title", test.In); out != test.Title {
+			t.Errorf("[%d] title (%s): want %s, got %s", i, test.In, test.Title, out)
+		}
+	}
+}
+
 func TestTitleCaseIdentifier(t *testing.T) {
 	t.Parallel()
 
@@ -497,6 +573,27 @@ func TestGenerateTags(t *testing.T) {
 	}
 }
 
+func TestCommentLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"the user's email", "the user's email"},
+		{"first line\nsecond line", "first line second line"},
+		{"  windows\r\nline endings\tand tabs  ", "windows line endings and tabs"},
+	}
+
+	for i, test := range tests {
+		if out := CommentLine(test.In); out != test.Out {
+			t.Errorf("[%d] want %q, got %q", i, test.Out, out)
+		}
+	}
+}
+
 func TestGenerateIgnoreTags(t *testing.T) {
 	tags := GenerateIgnoreTags([]string{})
 	if tags != "" {
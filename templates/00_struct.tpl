@@ -2,20 +2,32 @@
 {{- end -}}
 
 {{- $dot := . -}}
-{{- $tableNameSingular := .Table.Name | singular -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name -}}
 {{- $modelName := $tableNameSingular | titleCase -}}
 {{- $modelNameCamel := $tableNameSingular | camelCase -}}
 
 // {{$modelName}} is an object representing the database table.
+{{- range .Table.Projections}}
+// Projection {{.Name}}: {{commentLine .Query}}
+{{- end}}
 type {{$modelName}} struct {
 	{{range $column := .Table.Columns }}
-	{{- if eq $dot.StructTagCasing "camel"}}
-	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name | camelCase}}" yaml:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}"`
-	{{- else -}}
-	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name}}" yaml:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{- $tagName := tagCase $dot.StructTagCasing $column.Name -}}
+	{{- $dbTagName := tagCase $dot.DBTagCasing $column.Name -}}
+	{{- $jsonTagName := tagCase $dot.JSONTagCasing $column.Name -}}
+	{{- with commentLine $column.Comment}}
+	// {{.}}
 	{{end -}}
+	{{- if $column.AggregateState}}
+	// Aggregation state, finalize it with the -Merge combinator to read it,
+	// it's left out of inserts and updates.
 	{{end -}}
-	{{- if .Table.IsJoinTable -}}
+	{{- if $column.DefaultIsExpression}}
+	// Defaults to {{commentLine $column.Default}} when left out of inserts.
+	{{end -}}
+	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $dbTagName}}boil:"{{$column.Name}}" json:"{{$jsonTagName}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$tagName}}" yaml:"{{$tagName}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{end -}}
+	{{- if or .Table.IsJoinTable .ModelsOnly -}}
 	{{- else}}
 	R *{{$modelNameCamel}}R `{{generateIgnoreTags $dot.Tags}}boil:"-" json:"-" toml:"-" yaml:"-"`
 	L {{$modelNameCamel}}L `{{generateIgnoreTags $dot.Tags}}boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -32,22 +44,31 @@ var {{$modelName}}Columns = struct {
 	{{end -}}
 }
 
-{{- if .Table.IsJoinTable -}}
+// Column names of the {{.Table.Name}} table, the Quoted ones are quoted for
+// the database and ready to be used in raw queries.
+const (
+	{{range $column := .Table.Columns -}}
+	{{$modelName}}Column{{titleCase $column.Name}} = "{{$column.Name}}"
+	{{$modelName}}Column{{titleCase $column.Name}}Quoted = "{{$dot.Quotes $column.Name}}"
+	{{end -}}
+)
+
+{{- if or .Table.IsJoinTable .ModelsOnly -}}
 {{- else}}
 // {{$modelNameCamel}}R is where relationships are stored.
 type {{$modelNameCamel}}R struct {
 	{{range .Table.FKeys -}}
-	{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
+	{{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $dot.Table . -}}
 	{{$txt.Function.Name}} *{{$txt.ForeignTable.NameGo}}
 	{{end -}}
 
 	{{range .Table.ToOneRelationships -}}
-	{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
+	{{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $dot.Table . -}}
 	{{$txt.Function.Name}} *{{$txt.ForeignTable.NameGo}}
 	{{end -}}
 
 	{{range .Table.ToManyRelationships -}}
-	{{- $txt := txtsFromToMany $dot.Tables $dot.Table . -}}
+	{{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $dot.Table . -}}
 	{{$txt.Function.Name}} {{$txt.ForeignTable.Slice}}
 	{{end -}}{{/* range tomany */}}
 }
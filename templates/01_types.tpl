@@ -1,15 +1,15 @@
 {{if .Table.IsJoinTable -}}
 {{else -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
 var (
 	{{$varNameSingular}}Columns               = []string{{"{"}}{{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
-	{{if eq .DriverName "mssql" -}}
+	{{if or (eq .DriverName "mssql") (eq .DriverName "clickhouse") -}}
 	{{$varNameSingular}}ColumnsWithAuto = []string{{"{"}}{{.Table.Columns | filterColumnsByAuto true | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
 	{{end -}}
 	{{$varNameSingular}}ColumnsWithoutDefault = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault false | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
 	{{$varNameSingular}}ColumnsWithDefault    = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault true | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
-	{{$varNameSingular}}PrimaryKeyColumns     = []string{{"{"}}{{.Table.PKey.Columns | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
+	{{$varNameSingular}}PrimaryKeyColumns     = []string{{"{"}}{{if .Table.PKey}}{{.Table.PKey.Columns | stringMap .StringFuncs.quoteWrap | join ", "}}{{end}}{{"}"}}
 )
 
 type (
@@ -44,5 +44,14 @@ var (
 	_ = time.Second
 	// Force bytes in case of primary key column that uses []byte (for relationship compares)
 	_ = bytes.MinRead
+	// Force fmt, strings and strmangle for the read-only tables, which have
+	// no mutation helpers
+	_ = fmt.Sprint
+	_ = strings.Join
+	_ = strmangle.SetComplement
+	{{- if eq .DriverName "clickhouse"}}
+	// Force context for the read-only tables, which have no InsertAllContext
+	_ = context.Background
+	{{- end}}
 )
 {{end -}}
@@ -2,8 +2,8 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
-		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $dot.Table . -}}
+		{{- $varNameSingular := $.Aliases.Singular .ForeignTable | camelCase}}
 // {{$txt.Function.Name}}G pointed to by the foreign key.
 func (o *{{$txt.LocalTable.NameGo}}) {{$txt.Function.Name}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	return o.{{$txt.Function.Name}}(boil.GetDB(), mods...)
@@ -12,7 +12,7 @@ func (o *{{$txt.LocalTable.NameGo}}) {{$txt.Function.Name}}G(mods ...qm.QueryMod
 // {{$txt.Function.Name}} pointed to by the foreign key.
 func (o *{{$txt.LocalTable.NameGo}}) {{$txt.Function.Name}}(exec boil.Executor, mods ...qm.QueryMod) ({{$varNameSingular}}Query) {
 	queryMods := []qm.QueryMod{
-		qm.Where("{{$txt.ForeignTable.ColumnName}}=?", o.{{$txt.LocalTable.ColumnNameGo}}),
+		qm.Where("{{$txt.ForeignTable.ColumnName | $dot.Quotes}}=?", o.{{$txt.LocalTable.ColumnNameGo}}),
 	}
 
 	queryMods = append(queryMods, mods...)
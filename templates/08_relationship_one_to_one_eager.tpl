@@ -2,8 +2,8 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
-		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := $dot.Table.Name | singular | camelCase -}}
+		{{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $dot.Table . -}}
+		{{- $varNameSingular := $.Aliases.Singular $dot.Table.Name | camelCase -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
 // Load{{$txt.Function.Name}} allows an eager lookup of values, cached into the
 // loaded structs of the objects.
@@ -2,8 +2,8 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.ToManyRelationships -}}
-		{{- $varNameSingular := $dot.Table.Name | singular | camelCase -}}
-		{{- $txt := txtsFromToMany $dot.Tables $dot.Table . -}}
+		{{- $varNameSingular := $.Aliases.Singular $dot.Table.Name | camelCase -}}
+		{{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $dot.Table . -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
 		{{- $schemaForeignTable := .ForeignTable | $dot.SchemaTable}}
 // Load{{$txt.Function.Name}} allows an eager lookup of values, cached into the
@@ -87,7 +87,7 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 	{{end}}
 
 	{{if not $dot.NoHooks -}}
-	if len({{.ForeignTable | singular | camelCase}}AfterSelectHooks) != 0 {
+	if len({{$.Aliases.Singular .ForeignTable | camelCase}}AfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(e); err != nil {
 				return err
@@ -1,12 +1,12 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .Table.IsReadOnly -}}
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
-		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
-		{{- $foreignNameSingular := .ForeignTable | singular | camelCase -}}
-		{{- $varNameSingular := .Table | singular | camelCase}}
+		{{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $dot.Table . -}}
+		{{- $foreignNameSingular := $.Aliases.Singular .ForeignTable | camelCase -}}
+		{{- $varNameSingular := $.Aliases.Singular .Table | camelCase}}
 		{{- $schemaTable := .Table | $dot.SchemaTable}}
-// Set{{$txt.Function.Name}}G of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}G of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Uses the global database handle.
@@ -14,7 +14,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}G(insert bool, rel
 	return o.Set{{$txt.Function.Name}}(boil.GetDB(), insert, related)
 }
 
-// Set{{$txt.Function.Name}}P of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}P of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Panics on error.
@@ -24,7 +24,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}P(exec boil.Execut
 	}
 }
 
-// Set{{$txt.Function.Name}}GP of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}GP of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Uses the global database handle and panics on error.
@@ -34,7 +34,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
-// Set{{$txt.Function.Name}} of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}} of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executor, insert bool, related *{{$txt.ForeignTable.NameGo}}) error {
@@ -1,13 +1,13 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .Table.IsReadOnly -}}
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
-		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := .Table | singular | camelCase -}}
-		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+		{{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $dot.Table . -}}
+		{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+		{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase -}}
 		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
-// Set{{$txt.Function.Name}}G of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}G of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Uses the global database handle.
@@ -15,7 +15,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}G(insert bool, rel
 	return o.Set{{$txt.Function.Name}}(boil.GetDB(), insert, related)
 }
 
-// Set{{$txt.Function.Name}}P of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}P of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Panics on error.
@@ -25,7 +25,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}P(exec boil.Execut
 	}
 }
 
-// Set{{$txt.Function.Name}}GP of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}}GP of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 // Uses the global database handle and panics on error.
@@ -35,7 +35,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
-// Set{{$txt.Function.Name}} of the {{.Table | singular}} to the related item.
+// Set{{$txt.Function.Name}} of the {{$.Aliases.Singular .Table}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
 func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executor, insert bool, related *{{$txt.ForeignTable.NameGo}}) error {
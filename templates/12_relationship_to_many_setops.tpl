@@ -1,15 +1,15 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .Table.IsReadOnly -}}
 {{- else -}}
 	{{- $dot := . -}}
 	{{- $table := .Table -}}
 	{{- range .Table.ToManyRelationships -}}
-		{{- $txt := txtsFromToMany $dot.Tables $table . -}}
-		{{- $varNameSingular := .Table | singular | camelCase -}}
-		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table . -}}
+		{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+		{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase}}
 		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
 // Add{{$txt.Function.Name}}G adds the given related objects to the existing relationships
-// of the {{$table.Name | singular}}, optionally inserting them as new records.
+// of the {{$.Aliases.Singular $table.Name}}, optionally inserting them as new records.
 // Appends related to o.R.{{$txt.Function.Name}}.
 // Sets related.R.{{$txt.Function.ForeignName}} appropriately.
 // Uses the global database handle.
@@ -18,7 +18,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}G(insert bool, rel
 }
 
 // Add{{$txt.Function.Name}}P adds the given related objects to the existing relationships
-// of the {{$table.Name | singular}}, optionally inserting them as new records.
+// of the {{$.Aliases.Singular $table.Name}}, optionally inserting them as new records.
 // Appends related to o.R.{{$txt.Function.Name}}.
 // Sets related.R.{{$txt.Function.ForeignName}} appropriately.
 // Panics on error.
@@ -29,7 +29,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}P(exec boil.Execut
 }
 
 // Add{{$txt.Function.Name}}GP adds the given related objects to the existing relationships
-// of the {{$table.Name | singular}}, optionally inserting them as new records.
+// of the {{$.Aliases.Singular $table.Name}}, optionally inserting them as new records.
 // Appends related to o.R.{{$txt.Function.Name}}.
 // Sets related.R.{{$txt.Function.ForeignName}} appropriately.
 // Uses the global database handle and panics on error.
@@ -40,7 +40,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}GP(insert bool, re
 }
 
 // Add{{$txt.Function.Name}} adds the given related objects to the existing relationships
-// of the {{$table.Name | singular}}, optionally inserting them as new records.
+// of the {{$.Aliases.Singular $table.Name}}, optionally inserting them as new records.
 // Appends related to o.R.{{$txt.Function.Name}}.
 // Sets related.R.{{$txt.Function.ForeignName}} appropriately.
 func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}(exec boil.Executor, insert bool, related ...*{{$txt.ForeignTable.NameGo}}) error {
@@ -133,7 +133,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}(exec boil.Executo
 
 			{{- if (or .ForeignColumnNullable .ToJoinTable)}}
 // Set{{$txt.Function.Name}}G removes all previously related items of the
-// {{$table.Name | singular}} replacing them completely with the passed
+// {{$.Aliases.Singular $table.Name}} replacing them completely with the passed
 // in related items, optionally inserting them as new records.
 // Sets o.R.{{$txt.Function.ForeignName}}'s {{$txt.Function.Name}} accordingly.
 // Replaces o.R.{{$txt.Function.Name}} with related.
@@ -144,7 +144,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}G(insert bool, rel
 }
 
 // Set{{$txt.Function.Name}}P removes all previously related items of the
-// {{$table.Name | singular}} replacing them completely with the passed
+// {{$.Aliases.Singular $table.Name}} replacing them completely with the passed
 // in related items, optionally inserting them as new records.
 // Sets o.R.{{$txt.Function.ForeignName}}'s {{$txt.Function.Name}} accordingly.
 // Replaces o.R.{{$txt.Function.Name}} with related.
@@ -157,7 +157,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}P(exec boil.Execut
 }
 
 // Set{{$txt.Function.Name}}GP removes all previously related items of the
-// {{$table.Name | singular}} replacing them completely with the passed
+// {{$.Aliases.Singular $table.Name}} replacing them completely with the passed
 // in related items, optionally inserting them as new records.
 // Sets o.R.{{$txt.Function.ForeignName}}'s {{$txt.Function.Name}} accordingly.
 // Replaces o.R.{{$txt.Function.Name}} with related.
@@ -170,7 +170,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 }
 
 // Set{{$txt.Function.Name}} removes all previously related items of the
-// {{$table.Name | singular}} replacing them completely with the passed
+// {{$.Aliases.Singular $table.Name}} replacing them completely with the passed
 // in related items, optionally inserting them as new records.
 // Sets o.R.{{$txt.Function.ForeignName}}'s {{$txt.Function.Name}} accordingly.
 // Replaces o.R.{{$txt.Function.Name}} with related.
@@ -1,5 +1,5 @@
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase}}
+{{- $tableNamePlural := $.Aliases.Plural .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase}}
 // {{$tableNamePlural}}G retrieves all records.
 func {{$tableNamePlural}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	return {{$tableNamePlural}}(boil.GetDB(), mods...)
@@ -10,3 +10,15 @@ func {{$tableNamePlural}}(exec boil.Executor, mods ...qm.QueryMod) {{$varNameSin
 	mods = append(mods, qm.From("{{.Table.Name | .SchemaTable}}"))
 	return {{$varNameSingular}}Query{NewQuery(exec, mods...)}
 }
+{{- with .SoftDeleteColumn}}
+
+// {{$tableNamePlural}}NotDeleted is a query mod leaving the soft deleted
+// {{$.Table.Name}} out.
+func {{$tableNamePlural}}NotDeleted() qm.QueryMod {
+	{{if .Nullable -}}
+	return qm.Where("{{$.Table.Name | $.SchemaTable}}.{{$.Quotes .Name}} IS NULL")
+	{{- else -}}
+	return qm.Where("{{$.Table.Name | $.SchemaTable}}.{{$.Quotes .Name}} = ?", {{if eq .Type "bool"}}false{{else}}0{{end}})
+	{{- end}}
+}
+{{- end}}
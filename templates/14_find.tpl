@@ -1,5 +1,6 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- if .Table.PKey -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
 {{- $pkNames := $colDefs.Names | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
 {{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", "}}
@@ -53,3 +54,4 @@ func Find{{$tableNameSingular}}P(exec boil.Executor, {{$pkArgs}}, selectCols ...
 
 	return retobj
 }
+{{- end -}}{{/* if PKey */}}
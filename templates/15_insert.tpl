@@ -1,5 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- if .Table.IsReadOnly -}}
+{{- else -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // InsertG a single record. See Insert for whitelist behavior description.
 func (o *{{$tableNameSingular}}) InsertG(whitelist ... string) error {
@@ -56,6 +58,9 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 			nzDefaults,
 			whitelist,
 		)
+		{{if eq .DriverName "clickhouse" -}}
+		wl = strmangle.SetComplement(wl, {{$varNameSingular}}ColumnsWithAuto)
+		{{end}}
 
 		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, wl)
 		if err != nil {
@@ -179,3 +184,137 @@ CacheNoHooks:
 	return nil
 	{{- end}}
 }
+{{- if eq .DriverName "clickhouse"}}
+{{- if not .ContextOnly}}
+
+// InsertAllG inserts all the records of the slice at once. See InsertAll.
+func (o {{$tableNameSingular}}Slice) InsertAllG(whitelist ...string) error {
+	return o.InsertAll(boil.GetDB(), whitelist...)
+}
+
+// InsertAll inserts all the records of the slice using an executor, see
+// InsertAllContext.
+func (o {{$tableNameSingular}}Slice) InsertAll(exec boil.Executor, whitelist ...string) error {
+	return o.insertAll(context.Background(), exec, whitelist)
+}
+{{- end}}
+
+// InsertAllContext inserts all the records of the slice using an executor,
+// in a single batch since Clickhouse favors them. Whitelist behavior is the
+// one of Insert, except that without a whitelist the columns with a default
+// are included as soon as one record has them non-zero. Generated values
+// aren't read back.
+//
+// The batch is a single row INSERT prepared in a transaction and executed
+// for each record, the rows are sent when the transaction commits. A
+// transaction is begun and committed when exec can begin one, the batch is
+// left to the transaction otherwise. The batch is cancelled along with ctx.
+func (o {{$tableNameSingular}}Slice) InsertAllContext(ctx context.Context, exec boil.ContextExecutor, whitelist ...string) error {
+	return o.insertAll(ctx, exec, whitelist)
+}
+
+func (o {{$tableNameSingular}}Slice) insertAll(ctx context.Context, exec boil.Executor, whitelist []string) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	prepare := func(o *{{$tableNameSingular}}) error {
+		{{- template "timestamp_insert_helper" . }}
+		{{if not .NoHooks -}}
+		return o.doBeforeInsertHooks(exec)
+		{{- else -}}
+		return nil
+		{{- end}}
+	}
+
+	var nzDefaults []string
+	for _, obj := range o {
+		if err := prepare(obj); err != nil {
+			return err
+		}
+		nzDefaults = strmangle.SetMerge(nzDefaults, queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, obj))
+	}
+
+	wl, _ := strmangle.InsertColumnSet(
+		{{$varNameSingular}}Columns,
+		{{$varNameSingular}}ColumnsWithDefault,
+		{{$varNameSingular}}ColumnsWithoutDefault,
+		nzDefaults,
+		whitelist,
+	)
+	wl = strmangle.SetComplement(wl, {{$varNameSingular}}ColumnsWithAuto)
+	if len(wl) == 0 {
+		return errors.New("{{.PkgName}}: unable to insert all into {{.Table.Name}}, could not build whitelist")
+	}
+
+	valueMapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, wl)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) VALUES (%s)",
+		strings.Join(wl, "{{.RQ}},{{.LQ}}"),
+		strmangle.Placeholders(dialect.IndexPlaceholders, len(wl), 1, 1),
+	)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+	}
+
+	batch := exec
+	var tx boil.Transactor
+	if beginner, ok := exec.(boil.ContextBeginner); ok {
+		if tx, err = beginner.BeginTx(ctx, nil); err != nil {
+			return errors.Wrap(err, "{{.PkgName}}: unable to begin the insert all into {{.Table.Name}}")
+		}
+		batch = tx
+	}
+	rollback := func(err error) error {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+		return errors.Wrap(err, "{{.PkgName}}: unable to insert all into {{.Table.Name}}")
+	}
+
+	preparer, ok := batch.(boil.ContextPreparer)
+	if !ok {
+		return rollback(errors.New("the executor can't prepare statements"))
+	}
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return rollback(err)
+	}
+
+	for _, obj := range o {
+		vals := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), valueMapping)
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, vals)
+		}
+
+		if _, err = stmt.ExecContext(ctx, vals...); err != nil {
+			_ = stmt.Close()
+			return rollback(err)
+		}
+	}
+
+	if err = stmt.Close(); err != nil {
+		return rollback(err)
+	}
+	if tx != nil {
+		if err = tx.Commit(); err != nil {
+			return errors.Wrap(err, "{{.PkgName}}: unable to commit the insert all into {{.Table.Name}}")
+		}
+	}
+
+	{{if not .NoHooks -}}
+	for _, obj := range o {
+		if err := obj.doAfterInsertHooks(exec); err != nil {
+			return err
+		}
+	}
+	{{- end}}
+
+	return nil
+}
+{{- end}}
+{{- end -}}{{/* if IsReadOnly */}}
@@ -1,5 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- if .Table.IsReadOnly -}}
+{{- else -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // UpdateG a single {{$tableNameSingular}} record. See Update for
 // whitelist behavior description.
@@ -53,12 +55,12 @@ func (o *{{$tableNameSingular}}) Update(exec boil.Executor, whitelist ... string
 			{{$varNameSingular}}PrimaryKeyColumns,
 			whitelist,
 		)
-		{{if eq .DriverName "mssql"}}
+		{{if or (eq .DriverName "mssql") (eq .DriverName "clickhouse")}}
 		wl = strmangle.SetComplement(wl, {{$varNameSingular}}ColumnsWithAuto)
 		{{end}}
-		{{if not .NoAutoTimestamps}}
+		{{if .AutoTimestamp .AutoColumns.Created}}
 		if len(whitelist) == 0 {
-			wl = strmangle.SetComplement(wl, []string{"created_at"})
+			wl = strmangle.SetComplement(wl, []string{"{{.AutoColumns.Created}}"})
 		}
 		{{end -}}
 		if len(wl) == 0 {
@@ -181,3 +183,4 @@ func (o {{$tableNameSingular}}Slice) UpdateAll(exec boil.Executor, cols M) error
 
 	return nil
 }
+{{- end -}}{{/* if IsReadOnly */}}
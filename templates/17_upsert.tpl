@@ -1,5 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- if .Table.IsReadOnly -}}
+{{- else -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // UpsertG attempts an insert, and does an update or ignore on conflict.
 func (o *{{$tableNameSingular}}) UpsertG({{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string,	whitelist ...string) error {
@@ -79,6 +81,9 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 			nzDefaults,
 			whitelist,
 		)
+		{{if eq .DriverName "clickhouse" -}}
+		insert = strmangle.SetComplement(insert, {{$varNameSingular}}ColumnsWithAuto)
+		{{end -}}
 		{{if eq .DriverName "mssql" -}}
 		insert = strmangle.SetComplement(insert, {{$varNameSingular}}ColumnsWithAuto)
 		for i, v := range insert {
@@ -99,7 +104,7 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 			{{$varNameSingular}}PrimaryKeyColumns,
 			updateColumns,
 		)
-		{{if eq .DriverName "mssql" -}}
+		{{if or (eq .DriverName "mssql") (eq .DriverName "clickhouse") -}}
 		update = strmangle.SetComplement(update, {{$varNameSingular}}ColumnsWithAuto)
 		{{end -}}
 
@@ -117,7 +122,7 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 		{{else if eq .DriverName "mysql"}}
 		cache.query = queries.BuildUpsertQueryMySQL(dialect, "{{.Table.Name}}", update, insert)
 		cache.retQuery = fmt.Sprintf(
-			"SELECT %s FROM {{.LQ}}{{.Table.Name}}{{.RQ}} WHERE {{whereClause .LQ .RQ 0 .Table.PKey.Columns}}",
+			"SELECT %s FROM {{$schemaTable}} WHERE {{whereClause .LQ .RQ 0 .Table.PKey.Columns}}",
 			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
 		)
 		{{else if eq .DriverName "mssql"}}
@@ -230,3 +235,4 @@ CacheNoHooks:
 	return nil
 	{{- end}}
 }
+{{- end -}}{{/* if IsReadOnly */}}
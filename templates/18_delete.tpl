@@ -1,6 +1,11 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
-{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- if .Table.IsReadOnly -}}
+{{- else -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- $softDelete := .SoftDeleteColumn -}}
+{{- $softDeleteGo := .AutoColumns.Deleted | titleCase -}}
+{{- $softDeleteValue := .SoftDeleteValue}}
 // DeleteP deletes a single {{$tableNameSingular}} record with an executor.
 // DeleteP will match against the primary key column to find the record to delete.
 // Panics on error.
@@ -31,6 +36,9 @@ func (o *{{$tableNameSingular}}) DeleteGP() {
 
 // Delete deletes a single {{$tableNameSingular}} record with an executor.
 // Delete will match against the primary key column to find the record to delete.
+{{- if $softDelete}}
+// The record is soft deleted, its {{$softDelete.Name}} column is set instead.
+{{- end}}
 func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	if o == nil {
 	return errors.New("{{.PkgName}}: no {{$tableNameSingular}} provided for delete")
@@ -42,8 +50,14 @@ func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	}
 	{{- end}}
 
+	{{if $softDelete -}}
+	deleted := {{$softDeleteValue}}
+	args := append([]interface{}{deleted}, queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}PrimaryKeyMapping)...)
+	sql := "UPDATE {{$schemaTable}} SET {{.Quotes $softDelete.Name}} = {{if .Dialect.IndexPlaceholders}}$1{{else}}?{{end}} WHERE {{if .Dialect.IndexPlaceholders}}{{whereClause .LQ .RQ 2 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}"
+	{{- else -}}
 	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}PrimaryKeyMapping)
 	sql := "DELETE FROM {{$schemaTable}} WHERE {{if .Dialect.IndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}"
+	{{- end}}
 
 	if boil.DebugMode {
 	fmt.Fprintln(boil.DebugWriter, sql)
@@ -54,6 +68,10 @@ func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	if err != nil {
 	return errors.Wrap(err, "{{.PkgName}}: unable to delete from {{.Table.Name}}")
 	}
+	{{- if $softDelete}}
+
+	o.{{$softDeleteGo}} = deleted
+	{{- end}}
 
 	{{if not .NoHooks -}}
 	if err := o.doAfterDeleteHooks(exec); err != nil {
@@ -77,7 +95,11 @@ func (q {{$varNameSingular}}Query) DeleteAll() error {
 	return errors.New("{{.PkgName}}: no {{$varNameSingular}}Query provided for delete all")
 	}
 
+	{{if $softDelete -}}
+	queries.SetUpdate(q.Query, map[string]interface{}{"{{$softDelete.Name}}": {{$softDeleteValue}}})
+	{{- else -}}
 	queries.SetDelete(q.Query)
+	{{- end}}
 
 	_, err := q.Query.Exec()
 	if err != nil {
@@ -129,14 +151,24 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 	}
 	{{- end}}
 
+	{{if $softDelete -}}
+	deleted := {{$softDeleteValue}}
+	args := []interface{}{deleted}
+	{{- else -}}
 	var args []interface{}
+	{{- end}}
 	for _, obj := range o {
 		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$varNameSingular}}PrimaryKeyMapping)
 		args = append(args, pkeyArgs...)
 	}
 
+	{{if $softDelete -}}
+	sql := "UPDATE {{$schemaTable}} SET {{.Quotes $softDelete.Name}} = {{if .Dialect.IndexPlaceholders}}$1{{else}}?{{end}} WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.IndexPlaceholders}}2{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns, len(o))
+	{{- else -}}
 	sql := "DELETE FROM {{$schemaTable}} WHERE " +
 		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns, len(o))
+	{{- end}}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -147,6 +179,12 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 	if err != nil {
 		return errors.Wrap(err, "{{.PkgName}}: unable to delete all from {{$varNameSingular}} slice")
 	}
+	{{- if $softDelete}}
+
+	for _, obj := range o {
+		obj.{{$softDeleteGo}} = deleted
+	}
+	{{- end}}
 
 	{{if not .NoHooks -}}
 	if len({{$varNameSingular}}AfterDeleteHooks) != 0 {
@@ -160,3 +198,4 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 
 	return nil
 }
+{{- end -}}{{/* if IsReadOnly */}}
@@ -1,6 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
+{{- if .Table.PKey -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
+{{- $varNamePlural := $.Aliases.Plural .Table.Name | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // ReloadGP refetches the object from the database and panics on error.
 func (o *{{$tableNameSingular}}) ReloadGP() {
@@ -93,3 +94,4 @@ func (o *{{$tableNameSingular}}Slice) ReloadAll(exec boil.Executor) error {
 
 	return nil
 }
+{{- end -}}{{/* if PKey */}}
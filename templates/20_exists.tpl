@@ -1,10 +1,32 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- if .Table.PKey -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
 {{- $pkNames := $colDefs.Names | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
 {{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", " -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // {{$tableNameSingular}}Exists checks if the {{$tableNameSingular}} row exists.
 func {{$tableNameSingular}}Exists(exec boil.Executor, {{$pkArgs}}) (bool, error) {
+	{{if eq .DriverName "clickhouse" -}}
+	// Clickhouse has no cheap existence check, the first row matching the
+	// primary key is fetched instead
+	query := "select 1 from {{$schemaTable}} where {{whereClause .LQ .RQ 0 .Table.PKey.Columns}} limit 1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, {{$pkNames | join ", "}})
+	}
+
+	var one uint8
+	err := exec.QueryRow(query, {{$pkNames | join ", "}}).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "{{.PkgName}}: unable to check if {{.Table.Name}} exists")
+	}
+
+	return true, nil
+	{{- else -}}
 	var exists bool
 	{{if eq .DriverName "mssql" -}}
 	sql := "select case when exists(select top(1) 1 from {{$schemaTable}} where {{if .Dialect.IndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}) then 1 else 0 end"
@@ -25,6 +47,7 @@ func {{$tableNameSingular}}Exists(exec boil.Executor, {{$pkArgs}}) (bool, error)
 	}
 
 	return exists, nil
+	{{- end}}
 }
 
 // {{$tableNameSingular}}ExistsG checks if the {{$tableNameSingular}} row exists.
@@ -51,3 +74,4 @@ func {{$tableNameSingular}}ExistsP(exec boil.Executor, {{$pkArgs}}) bool {
 
 	return e
 }
+{{- end -}}{{/* if PKey */}}
@@ -1,82 +1,91 @@
 {{- define "timestamp_insert_helper" -}}
-	{{- if not .NoAutoTimestamps -}}
 	{{- $colNames := .Table.Columns | columnNames -}}
-	{{if containsAny $colNames "created_at" "updated_at"}}
+	{{- $created := .AutoColumns.Created -}}
+	{{- $updated := .AutoColumns.Updated -}}
+	{{- $createdGo := titleCase $created -}}
+	{{- $updatedGo := titleCase $updated -}}
+	{{- $hasCreated := and (.AutoTimestamp $created) (setInclude $created $colNames) -}}
+	{{- $hasUpdated := and (.AutoTimestamp $updated) (setInclude $updated $colNames) -}}
+	{{if or $hasCreated $hasUpdated}}
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
-			{{- if eq $col.Name "created_at" -}}
+			{{- if and $hasCreated (eq $col.Name $created) -}}
 				{{- if $col.Nullable}}
-	if o.CreatedAt.Time.IsZero() {
-		o.CreatedAt.Time = currTime
-		o.CreatedAt.Valid = true
+	if o.{{$createdGo}}.Time.IsZero() {
+		o.{{$createdGo}}.Time = currTime
+		o.{{$createdGo}}.Valid = true
 	}
 				{{- else}}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+	if o.{{$createdGo}}.IsZero() {
+		o.{{$createdGo}} = currTime
 	}
 				{{- end -}}
 			{{- end -}}
-			{{- if eq $col.Name "updated_at" -}}
+			{{- if and $hasUpdated (eq $col.Name $updated) -}}
 				{{- if $col.Nullable}}
-	if o.UpdatedAt.Time.IsZero() {
-		o.UpdatedAt.Time = currTime
-		o.UpdatedAt.Valid = true
+	if o.{{$updatedGo}}.Time.IsZero() {
+		o.{{$updatedGo}}.Time = currTime
+		o.{{$updatedGo}}.Valid = true
 	}
 				{{- else}}
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
+	if o.{{$updatedGo}}.IsZero() {
+		o.{{$updatedGo}} = currTime
 	}
 				{{- end -}}
 			{{- end -}}
 		{{end}}
 	{{end}}
-	{{- end}}
 {{- end -}}
 {{- define "timestamp_update_helper" -}}
-	{{- if not .NoAutoTimestamps -}}
 	{{- $colNames := .Table.Columns | columnNames -}}
-	{{if containsAny $colNames "updated_at"}}
+	{{- $updated := .AutoColumns.Updated -}}
+	{{- $updatedGo := titleCase $updated -}}
+	{{- $hasUpdated := and (.AutoTimestamp $updated) (setInclude $updated $colNames) -}}
+	{{if $hasUpdated}}
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
-			{{- if eq $col.Name "updated_at" -}}
+			{{- if eq $col.Name $updated -}}
 				{{- if $col.Nullable}}
-	o.UpdatedAt.Time = currTime
-	o.UpdatedAt.Valid = true
+	o.{{$updatedGo}}.Time = currTime
+	o.{{$updatedGo}}.Valid = true
 				{{- else}}
-	o.UpdatedAt = currTime
+	o.{{$updatedGo}} = currTime
 				{{- end -}}
 			{{- end -}}
 		{{end}}
 	{{end}}
-	{{- end}}
 {{end -}}
 {{- define "timestamp_upsert_helper" -}}
-	{{- if not .NoAutoTimestamps -}}
 	{{- $colNames := .Table.Columns | columnNames -}}
-	{{if containsAny $colNames "created_at" "updated_at"}}
+	{{- $created := .AutoColumns.Created -}}
+	{{- $updated := .AutoColumns.Updated -}}
+	{{- $createdGo := titleCase $created -}}
+	{{- $updatedGo := titleCase $updated -}}
+	{{- $hasCreated := and (.AutoTimestamp $created) (setInclude $created $colNames) -}}
+	{{- $hasUpdated := and (.AutoTimestamp $updated) (setInclude $updated $colNames) -}}
+	{{if or $hasCreated $hasUpdated}}
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
-			{{- if eq $col.Name "created_at" -}}
+			{{- if and $hasCreated (eq $col.Name $created) -}}
 				{{- if $col.Nullable}}
-	if o.CreatedAt.Time.IsZero() {
-		o.CreatedAt.Time = currTime
-		o.CreatedAt.Valid = true
+	if o.{{$createdGo}}.Time.IsZero() {
+		o.{{$createdGo}}.Time = currTime
+		o.{{$createdGo}}.Valid = true
 	}
 				{{- else}}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+	if o.{{$createdGo}}.IsZero() {
+		o.{{$createdGo}} = currTime
 	}
 				{{- end -}}
 			{{- end -}}
-			{{- if eq $col.Name "updated_at" -}}
+			{{- if and $hasUpdated (eq $col.Name $updated) -}}
 				{{- if $col.Nullable}}
-	o.UpdatedAt.Time = currTime
-	o.UpdatedAt.Valid = true
+	o.{{$updatedGo}}.Time = currTime
+	o.{{$updatedGo}}.Valid = true
 				{{- else}}
-	o.UpdatedAt = currTime
+	o.{{$updatedGo}} = currTime
 				{{- end -}}
 			{{- end -}}
 		{{end}}
 	{{end}}
-	{{- end}}
 {{end -}}
@@ -84,3 +84,45 @@ const (
 		{{- end -}}
 	{{- end -}}
 {{- end -}}
+
+{{/*
+Clickhouse enums carry an explicit value for every label, they're parsed by the
+driver into Column.EnumValues. Depending on the Go type of the column the
+constants hold either the label or the value.
+
+Clickhouse output looks like: TableNameColNameEnumValue = "enumvalue", along
+with IsValidTableNameColName and TableNameColNameValues to check the labels
+before writing them.
+*/}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns -}}
+		{{- if $col.EnumValues -}}
+{{- if isEnumNormal (enumLabels $col.EnumValues)}}
+// Enum values for {{$table.Name}}.{{$col.Name}}
+const (
+	{{- range $val := $col.EnumValues}}
+	{{titleCase $table.Name}}{{titleCase $col.Name -}}
+	{{if shouldTitleCaseEnum $val.Label}}{{titleCase $val.Label}}{{else}}{{$val.Label}}{{end}} = {{if eq $col.Type "string" "null.String"}}"{{$val.Label}}"{{else}}{{$val.Value}}{{end}}
+	{{- end}}
+)
+{{- else}}
+// Enum values for {{$table.Name}}.{{$col.Name}} are not proper Go identifiers, cannot emit constants
+{{- end}}
+
+var {{camelCase $table.Name}}{{titleCase $col.Name}}Labels = []string{
+	{{- range $i, $val := $col.EnumValues}}{{if $i}}, {{end}}{{printf "%q" $val.Label}}{{end -}}
+}
+
+// IsValid{{titleCase $table.Name}}{{titleCase $col.Name}} reports whether s is a label of
+// {{$table.Name}}.{{$col.Name}}, Clickhouse rejects any other at write time.
+func IsValid{{titleCase $table.Name}}{{titleCase $col.Name}}(s string) bool {
+	return strmangle.SetInclude(s, {{camelCase $table.Name}}{{titleCase $col.Name}}Labels)
+}
+
+// {{titleCase $table.Name}}{{titleCase $col.Name}}Values returns the labels of {{$table.Name}}.{{$col.Name}}
+func {{titleCase $table.Name}}{{titleCase $col.Name}}Values() []string {
+	return append([]string(nil), {{camelCase $table.Name}}{{titleCase $col.Name}}Labels...)
+}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
@@ -1,7 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $tableNamePlural := $.Aliases.Plural .Table.Name | titleCase -}}
+{{- $varNamePlural := $.Aliases.Plural .Table.Name | camelCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 func test{{$tableNamePlural}}Delete(t *testing.T) {
 	t.Parallel()
 
@@ -22,7 +22,7 @@ func test{{$tableNamePlural}}Delete(t *testing.T) {
 		t.Error(err)
 	}
 
-	count, err := {{$tableNamePlural}}(tx).Count()
+	count, err := {{$tableNamePlural}}(tx{{if $.SoftDeleteColumn}}, {{$tableNamePlural}}NotDeleted(){{end}}).Count()
 	if err != nil {
 		t.Error(err)
 	}
@@ -52,7 +52,7 @@ func test{{$tableNamePlural}}QueryDeleteAll(t *testing.T) {
 		t.Error(err)
 	}
 
-	count, err := {{$tableNamePlural}}(tx).Count()
+	count, err := {{$tableNamePlural}}(tx{{if $.SoftDeleteColumn}}, {{$tableNamePlural}}NotDeleted(){{end}}).Count()
 	if err != nil {
 		t.Error(err)
 	}
@@ -84,7 +84,7 @@ func test{{$tableNamePlural}}SliceDeleteAll(t *testing.T) {
 		t.Error(err)
 	}
 
-	count, err := {{$tableNamePlural}}(tx).Count()
+	count, err := {{$tableNamePlural}}(tx{{if $.SoftDeleteColumn}}, {{$tableNamePlural}}NotDeleted(){{end}}).Count()
 	if err != nil {
 		t.Error(err)
 	}
@@ -1,7 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := $.Aliases.Singular .Table.Name | titleCase -}}
+{{- $tableNamePlural := $.Aliases.Plural .Table.Name | titleCase -}}
+{{- $varNamePlural := $.Aliases.Plural .Table.Name | camelCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 func test{{$tableNamePlural}}Bind(t *testing.T) {
 	t.Parallel()
 
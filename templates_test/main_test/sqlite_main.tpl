@@ -0,0 +1,85 @@
+type sqliteTester struct {
+	dbConn *sql.DB
+
+	dbName     string
+	testDBName string
+}
+
+func init() {
+	dbMain = &sqliteTester{}
+}
+
+// setup creates the test database in a temporary file, with the schema of
+// the database the models were generated from
+func (s *sqliteTester) setup() error {
+	var err error
+
+	s.dbName = viper.GetString("sqlite.dbname")
+
+	tmp, err := ioutil.TempFile("", "sqlboiler_sqlite")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the test database file")
+	}
+	s.testDBName = tmp.Name()
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	source, err := sql.Open("sqlite3", s.dbName)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the database")
+	}
+	defer source.Close()
+
+	rows, err := source.Query(`select sql from sqlite_master where sql is not null and name not like 'sqlite_%'`)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the schema")
+	}
+	defer rows.Close()
+
+	var schema []string
+	for rows.Next() {
+		var stmt string
+		if err = rows.Scan(&stmt); err != nil {
+			return errors.Wrap(err, "failed to read the schema")
+		}
+		schema = append(schema, stmt)
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to read the schema")
+	}
+
+	conn, err := s.conn()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range schema {
+		if _, err = conn.Exec(stmt); err != nil {
+			return errors.Wrapf(err, "failed to create the test schema: %s", stmt)
+		}
+	}
+
+	return nil
+}
+
+func (s *sqliteTester) teardown() error {
+	if s.dbConn != nil {
+		s.dbConn.Close()
+	}
+
+	return os.Remove(s.testDBName)
+}
+
+func (s *sqliteTester) conn() (*sql.DB, error) {
+	if s.dbConn != nil {
+		return s.dbConn, nil
+	}
+
+	var err error
+	s.dbConn, err = sql.Open("sqlite3", s.testDBName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dbConn, nil
+}
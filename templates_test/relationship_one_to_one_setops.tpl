@@ -2,9 +2,9 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
-		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table .}}
-{{- $varNameSingular := .Table | singular | camelCase -}}
-{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+		{{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $dot.Table .}}
+{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase -}}
 {{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns}}
 func test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	var err error
@@ -3,9 +3,9 @@
 	{{- $dot := . }}
 	{{- $table := .Table }}
 	{{- range .Table.ToManyRelationships -}}
-	{{- $txt := txtsFromToMany $dot.Tables $table .}}
-	{{- $varNameSingular := .Table | singular | camelCase -}}
-	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+	{{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table .}}
+	{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+	{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase -}}
 func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 	tx := MustTx(boil.Begin())
@@ -54,7 +54,7 @@ func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 	}
 	{{end}}
 
-	{{$varname := .ForeignTable | singular | camelCase -}}
+	{{$varname := $.Aliases.Singular .ForeignTable | camelCase -}}
 	{{$varname}}, err := a.{{$txt.Function.Name}}(tx).All()
 	if err != nil {
 		t.Fatal(err)
@@ -3,9 +3,9 @@
 	{{- $dot := . -}}
 	{{- $table := .Table -}}
 	{{- range .Table.ToManyRelationships -}}
-	{{- $varNameSingular := .Table | singular | camelCase -}}
-	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
-	{{- $txt := txtsFromToMany $dot.Tables $table .}}
+	{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+	{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase -}}
+	{{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table .}}
 func test{{$txt.LocalTable.NameGo}}ToManyAddOp{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 
@@ -2,9 +2,9 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
-		{{- $txt := txtsFromFKey $dot.Tables $dot.Table .}}
-{{- $varNameSingular := .Table | singular | camelCase -}}
-{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $dot.Table .}}
+{{- $varNameSingular := $.Aliases.Singular .Table | camelCase -}}
+{{- $foreignVarNameSingular := $.Aliases.Singular .ForeignTable | camelCase}}
 func test{{$txt.LocalTable.NameGo}}ToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 
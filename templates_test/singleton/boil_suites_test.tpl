@@ -7,9 +7,9 @@
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}})
   {{end -}}
   {{- end -}}
@@ -17,9 +17,9 @@ func TestParent(t *testing.T) {
 
 func TestDelete(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Delete)
   {{end -}}
   {{- end -}}
@@ -27,9 +27,9 @@ func TestDelete(t *testing.T) {
 
 func TestQueryDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}QueryDeleteAll)
   {{end -}}
   {{- end -}}
@@ -37,9 +37,9 @@ func TestQueryDeleteAll(t *testing.T) {
 
 func TestSliceDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceDeleteAll)
   {{end -}}
   {{- end -}}
@@ -47,9 +47,9 @@ func TestSliceDeleteAll(t *testing.T) {
 
 func TestExists(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Exists)
   {{end -}}
   {{- end -}}
@@ -57,9 +57,9 @@ func TestExists(t *testing.T) {
 
 func TestFind(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Find)
   {{end -}}
   {{- end -}}
@@ -67,9 +67,9 @@ func TestFind(t *testing.T) {
 
 func TestBind(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Bind)
   {{end -}}
   {{- end -}}
@@ -77,9 +77,9 @@ func TestBind(t *testing.T) {
 
 func TestOne(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}One)
   {{end -}}
   {{- end -}}
@@ -87,9 +87,9 @@ func TestOne(t *testing.T) {
 
 func TestAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}All)
   {{end -}}
   {{- end -}}
@@ -97,9 +97,9 @@ func TestAll(t *testing.T) {
 
 func TestCount(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Count)
   {{end -}}
   {{- end -}}
@@ -108,9 +108,9 @@ func TestCount(t *testing.T) {
 {{if not .NoHooks -}}
 func TestHooks(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Hooks)
   {{end -}}
   {{- end -}}
@@ -119,9 +119,9 @@ func TestHooks(t *testing.T) {
 
 func TestInsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Insert)
   t.Run("{{$tableName}}", test{{$tableName}}InsertWhitelist)
   {{end -}}
@@ -132,10 +132,10 @@ func TestInsert(t *testing.T) {
 // or deadlocks can occur.
 func TestToOne(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
     {{- range $table.FKeys -}}
-      {{- $txt := txtsFromFKey $dot.Tables $table . -}}
+      {{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $table . -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToOne{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
     {{end -}}{{- /* fkey range */ -}}
   {{- end -}}{{- /* if join table */ -}}
@@ -146,10 +146,10 @@ func TestToOne(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsReadOnly -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
-		{{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
+		{{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $table . -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}OneToOne{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
 	  {{end -}}{{- /* range */ -}}
 	{{- end -}}{{- /* outer if join table */ -}}
@@ -160,10 +160,10 @@ func TestOneToOne(t *testing.T) {
 // or deadlocks can occur.
 func TestToMany(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsReadOnly -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
-        {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+        {{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table . -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}})
       {{end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
@@ -174,10 +174,10 @@ func TestToMany(t *testing.T) {
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
     {{- range $table.FKeys -}}
-      {{- $txt := txtsFromFKey $dot.Tables $table . -}}
+      {{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $table . -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
     {{end -}}{{- /* fkey range */ -}}
   {{- end -}}{{- /* if join table */ -}}
@@ -188,10 +188,10 @@ func TestToOneSet(t *testing.T) {
 // or deadlocks can occur.
 func TestToOneRemove(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
     {{- range $table.FKeys -}}
-      {{- $txt := txtsFromFKey $dot.Tables $table . -}}
+      {{- $txt := txtsFromFKey $dot.Aliases $dot.Tables $table . -}}
       {{- if $txt.ForeignKey.Nullable -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToOneRemoveOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
       {{end -}}{{- /* if foreign key nullable */ -}}
@@ -204,10 +204,10 @@ func TestToOneRemove(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsReadOnly -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
-		  {{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
+		  {{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $table . -}}
 	t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
 	  {{end -}}{{- /* range to one relationships */ -}}
 	{{- end -}}{{- /* outer if join table */ -}}
@@ -218,11 +218,11 @@ func TestOneToOneSet(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOneRemove(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsReadOnly -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
 		{{- if .ForeignColumnNullable -}}
-		  {{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
+		  {{- $txt := txtsFromOneToOne $dot.Aliases $dot.Tables $table . -}}
 	t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}OneToOneRemoveOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
 		{{end -}}{{- /* if foreign column nullable */ -}}
 	  {{- end -}}{{- /* range */ -}}
@@ -234,10 +234,10 @@ func TestOneToOneRemove(t *testing.T) {
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsReadOnly -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
-        {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+        {{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table . -}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManyAddOp{{$txt.Function.Name}})
       {{end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
@@ -248,12 +248,12 @@ func TestToManyAdd(t *testing.T) {
 // or deadlocks can occur.
 func TestToManySet(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsReadOnly -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- if not (or .ForeignColumnNullable .ToJoinTable)}}
         {{- else -}}
-          {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+          {{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table . -}}
     t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManySetOp{{$txt.Function.Name}})
         {{end -}}{{- /* if foreign column nullable */ -}}
       {{- end -}}{{- /* range */ -}}
@@ -265,12 +265,12 @@ func TestToManySet(t *testing.T) {
 // or deadlocks can occur.
 func TestToManyRemove(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsReadOnly -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- if not (or .ForeignColumnNullable .ToJoinTable)}}
         {{- else -}}
-          {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+          {{- $txt := txtsFromToMany $dot.Aliases $dot.Tables $table . -}}
     t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManyRemoveOp{{$txt.Function.Name}})
         {{end -}}{{- /* if foreign column nullable */ -}}
       {{- end -}}{{- /* range */ -}}
@@ -280,9 +280,9 @@ func TestToManyRemove(t *testing.T) {
 
 func TestReload(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Reload)
   {{end -}}
   {{- end -}}
@@ -290,9 +290,9 @@ func TestReload(t *testing.T) {
 
 func TestReloadAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}ReloadAll)
   {{end -}}
   {{- end -}}
@@ -300,9 +300,9 @@ func TestReloadAll(t *testing.T) {
 
 func TestSelect(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Select)
   {{end -}}
   {{- end -}}
@@ -310,9 +310,9 @@ func TestSelect(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Update)
   {{end -}}
   {{- end -}}
@@ -320,9 +320,9 @@ func TestUpdate(t *testing.T) {
 
 func TestSliceUpdateAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceUpdateAll)
   {{end -}}
   {{- end -}}
@@ -330,9 +330,9 @@ func TestSliceUpdateAll(t *testing.T) {
 
 func TestUpsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsReadOnly -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $.Aliases.Plural $table.Name | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Upsert)
   {{end -}}
   {{- end -}}
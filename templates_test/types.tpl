@@ -1,4 +1,4 @@
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $varNameSingular := $.Aliases.Singular .Table.Name | camelCase -}}
 var (
 	{{$varNameSingular}}DBTypes = map[string]string{{"{"}}{{.Table.Columns | columnDBTypes | makeStringMap}}{{"}"}}
 	_ = bytes.MinRead
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// BigInt is an integer of any size, such as clickhouse's Int128, UInt128,
+// Int256 and UInt256 types. The zero value is the number 0.
+type BigInt struct {
+	big.Int
+}
+
+// NewBigInt returns a BigInt set to x.
+func NewBigInt(x *big.Int) BigInt {
+	var b BigInt
+	b.Set(x)
+	return b
+}
+
+// String outputs the base 10 form of the integer.
+func (b BigInt) String() string {
+	return b.Int.String()
+}
+
+// Value returns b as a value, in base 10 since it may not fit an int64.
+func (b BigInt) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Scan stores the src in *b. It accepts a *big.Int, integers and the
+// base 10 form of the integer.
+func (b *BigInt) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case *big.Int:
+		if src == nil {
+			return errors.New("incompatible type for big int")
+		}
+		b.Set(src)
+	case big.Int:
+		b.Set(&src)
+	case int64:
+		b.SetInt64(src)
+	case uint64:
+		b.SetUint64(src)
+	case string:
+		return b.parse(src)
+	case []byte:
+		return b.parse(string(src))
+	default:
+		return errors.New("incompatible type for big int")
+	}
+
+	return nil
+}
+
+func (b *BigInt) parse(str string) error {
+	if _, ok := b.SetString(str, 10); !ok {
+		return fmt.Errorf("invalid big int: %q", str)
+	}
+	return nil
+}
+
+// MarshalJSON returns b as a JSON number, keeping every digit.
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalJSON sets *b from a JSON number or a quoted one.
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	if b == nil {
+		return errors.New("big int: UnmarshalJSON on nil pointer")
+	}
+
+	str := string(data)
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+
+	return b.parse(str)
+}
+
+// NullBigInt is a nullable BigInt.
+type NullBigInt struct {
+	BigInt BigInt
+	Valid  bool
+}
+
+// Value returns b as a value, or nil when it's NULL.
+func (b NullBigInt) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+
+	return b.BigInt.Value()
+}
+
+// Scan stores the src in *b, a nil src sets it to NULL.
+func (b *NullBigInt) Scan(src interface{}) error {
+	if src == nil {
+		b.BigInt, b.Valid = BigInt{}, false
+		return nil
+	}
+
+	if err := b.BigInt.Scan(src); err != nil {
+		return err
+	}
+
+	b.Valid = true
+	return nil
+}
+
+// MarshalJSON returns the integer as a JSON number, or null when it's NULL.
+func (b NullBigInt) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return []byte("null"), nil
+	}
+
+	return b.BigInt.MarshalJSON()
+}
+
+// UnmarshalJSON sets *b from a JSON number or null.
+func (b *NullBigInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		b.BigInt, b.Valid = BigInt{}, false
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &b.BigInt); err != nil {
+		return err
+	}
+
+	b.Valid = true
+	return nil
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestBigIntScanValue(t *testing.T) {
+	t.Parallel()
+
+	// The bounds of Int128, UInt128, Int256 and UInt256
+	bounds := []string{
+		"-170141183460469231731687303715884105728",
+		"170141183460469231731687303715884105727",
+		"340282366920938463463374607431768211455",
+		"-57896044618658097711785492504343953926634992332820282019728792003956564819968",
+		"57896044618658097711785492504343953926634992332820282019728792003956564819967",
+		"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+		"0",
+	}
+
+	for _, bound := range bounds {
+		want, _ := new(big.Int).SetString(bound, 10)
+
+		for _, src := range []interface{}{bound, []byte(bound), want} {
+			var b BigInt
+			if err := b.Scan(src); err != nil {
+				t.Errorf("%T %s: %v", src, bound, err)
+				continue
+			}
+			if b.Cmp(want) != 0 {
+				t.Errorf("%T: want %s, got %s", src, bound, b.String())
+			}
+
+			v, err := b.Value()
+			if err != nil {
+				t.Error(err)
+			}
+			if v != bound {
+				t.Errorf("want value %s, got %v", bound, v)
+			}
+
+			// The value scans back to the same integer
+			var back BigInt
+			if err := back.Scan(v); err != nil || back.Cmp(want) != 0 {
+				t.Errorf("want %s back, got %s (%v)", bound, back.String(), err)
+			}
+		}
+	}
+
+	var b BigInt
+	if err := b.Scan(int64(-42)); err != nil || b.Int64() != -42 {
+		t.Errorf("want -42, got %s (%v)", b.String(), err)
+	}
+	if err := b.Scan(uint64(18446744073709551615)); err != nil || b.String() != "18446744073709551615" {
+		t.Errorf("want the max uint64, got %s (%v)", b.String(), err)
+	}
+
+	for _, bad := range []interface{}{"1.5", "12a", 1.5, nil} {
+		if err := b.Scan(bad); err == nil {
+			t.Errorf("expected an error scanning %#v", bad)
+		}
+	}
+
+	if zero := (BigInt{}).String(); zero != "0" {
+		t.Errorf("want the zero value to be 0, got %s", zero)
+	}
+}
+
+func TestBigIntJSON(t *testing.T) {
+	t.Parallel()
+
+	const max = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	want, _ := new(big.Int).SetString(max, 10)
+
+	b, err := json.Marshal(NewBigInt(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != max {
+		t.Errorf("want %s, got %s", max, b)
+	}
+
+	for _, data := range []string{max, `"` + max + `"`} {
+		var back BigInt
+		if err := json.Unmarshal([]byte(data), &back); err != nil {
+			t.Fatal(err)
+		}
+		if back.Cmp(want) != 0 {
+			t.Errorf("want %s, got %s", max, back.String())
+		}
+	}
+}
+
+func TestNullBigInt(t *testing.T) {
+	t.Parallel()
+
+	const min = "-170141183460469231731687303715884105728"
+
+	var b NullBigInt
+	if err := b.Scan(nil); err != nil || b.Valid {
+		t.Errorf("want NULL, got %#v (%v)", b, err)
+	}
+	if v, err := b.Value(); v != nil || err != nil {
+		t.Errorf("want a nil value, got %v (%v)", v, err)
+	}
+	if data, _ := json.Marshal(b); string(data) != "null" {
+		t.Errorf("want null, got %s", data)
+	}
+
+	if err := b.Scan(min); err != nil || !b.Valid {
+		t.Fatalf("want %s, got %#v (%v)", min, b, err)
+	}
+	if v, err := b.Value(); v != min || err != nil {
+		t.Errorf("want value %s, got %v (%v)", min, v, err)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil || string(data) != min {
+		t.Errorf("want %s, got %s (%v)", min, data, err)
+	}
+
+	var back NullBigInt
+	if err := json.Unmarshal(data, &back); err != nil || !back.Valid || back.BigInt.String() != min {
+		t.Errorf("want %s back, got %#v (%v)", min, back, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &back); err != nil || back.Valid {
+		t.Errorf("want NULL back, got %#v (%v)", back, err)
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// The Postgres StringArray, Int64Array and Float64Array types encode their
+// values as array literals, which clickhouse doesn't understand. The types
+// below are their clickhouse counterparts for Array(String), Array(Int64)
+// and Array(Float64) columns: the driver scans those as Go slices and takes
+// Go slices as values.
+
+// ClickhouseStringArray is a clickhouse Array(String).
+type ClickhouseStringArray []string
+
+// Value returns a as a []string.
+func (a ClickhouseStringArray) Value() (driver.Value, error) {
+	return []string(a), nil
+}
+
+// Scan stores the src in *a, src is either a []string or nil.
+func (a *ClickhouseStringArray) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []string:
+		*a = ClickhouseStringArray(src)
+		return nil
+	case nil:
+		*a = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to ClickhouseStringArray", src)
+}
+
+// ClickhouseInt64Array is a clickhouse Array(Int64).
+type ClickhouseInt64Array []int64
+
+// Value returns a as a []int64.
+func (a ClickhouseInt64Array) Value() (driver.Value, error) {
+	return []int64(a), nil
+}
+
+// Scan stores the src in *a, src is either a []int64 or nil.
+func (a *ClickhouseInt64Array) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []int64:
+		*a = ClickhouseInt64Array(src)
+		return nil
+	case nil:
+		*a = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to ClickhouseInt64Array", src)
+}
+
+// ClickhouseFloat64Array is a clickhouse Array(Float64).
+type ClickhouseFloat64Array []float64
+
+// Value returns a as a []float64.
+func (a ClickhouseFloat64Array) Value() (driver.Value, error) {
+	return []float64(a), nil
+}
+
+// Scan stores the src in *a, src is either a []float64 or nil.
+func (a *ClickhouseFloat64Array) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []float64:
+		*a = ClickhouseFloat64Array(src)
+		return nil
+	case nil:
+		*a = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to ClickhouseFloat64Array", src)
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClickhouseStringArray(t *testing.T) {
+	t.Parallel()
+
+	a := ClickhouseStringArray{"a", "b,c", "'d'"}
+	value, err := a.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([]string); !ok {
+		t.Fatalf("want a []string value, got %T", value)
+	}
+
+	var b ClickhouseStringArray
+	if err := b.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("want %#v, got %#v", a, b)
+	}
+
+	if err := b.Scan(nil); err != nil || b != nil {
+		t.Errorf("want nil, got %#v, %v", b, err)
+	}
+	if err := b.Scan([]int64{1}); err == nil {
+		t.Error("expected an error scanning a []int64")
+	}
+}
+
+func TestClickhouseInt64Array(t *testing.T) {
+	t.Parallel()
+
+	a := ClickhouseInt64Array{1, -2, 1 << 62}
+	value, err := a.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([]int64); !ok {
+		t.Fatalf("want a []int64 value, got %T", value)
+	}
+
+	var b ClickhouseInt64Array
+	if err := b.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("want %#v, got %#v", a, b)
+	}
+
+	if err := b.Scan(nil); err != nil || b != nil {
+		t.Errorf("want nil, got %#v, %v", b, err)
+	}
+	if err := b.Scan("{1,2}"); err == nil {
+		t.Error("expected an error scanning a string")
+	}
+}
+
+func TestClickhouseFloat64Array(t *testing.T) {
+	t.Parallel()
+
+	a := ClickhouseFloat64Array{1.5, -2.25, 0}
+	value, err := a.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([]float64); !ok {
+		t.Fatalf("want a []float64 value, got %T", value)
+	}
+
+	var b ClickhouseFloat64Array
+	if err := b.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("want %#v, got %#v", a, b)
+	}
+
+	if err := b.Scan(nil); err != nil || b != nil {
+		t.Errorf("want nil, got %#v, %v", b, err)
+	}
+	if err := b.Scan([]string{"1"}); err == nil {
+		t.Error("expected an error scanning a []string")
+	}
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var rgxDecimal = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// Decimal is a fixed precision number, such as clickhouse's Decimal(P,S).
+// It's kept in its textual form to avoid losing precision, the zero value
+// is the number 0.
+type Decimal string
+
+// String output your decimal.
+func (d Decimal) String() string {
+	if len(d) == 0 {
+		return "0"
+	}
+	return string(d)
+}
+
+// Value returns d as a value.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.valid() {
+		return nil, fmt.Errorf("invalid decimal: %q", string(d))
+	}
+	return d.String(), nil
+}
+
+// Scan stores the src in *d.
+func (d *Decimal) Scan(src interface{}) error {
+	var dec Decimal
+	switch src := src.(type) {
+	case string:
+		dec = Decimal(src)
+	case []byte:
+		dec = Decimal(src)
+	case int64:
+		dec = Decimal(strconv.FormatInt(src, 10))
+	case uint64:
+		dec = Decimal(strconv.FormatUint(src, 10))
+	case float64:
+		dec = Decimal(strconv.FormatFloat(src, 'f', -1, 64))
+	case float32:
+		dec = Decimal(strconv.FormatFloat(float64(src), 'f', -1, 32))
+	default:
+		return errors.New("incompatible type for decimal")
+	}
+
+	if !dec.valid() {
+		return fmt.Errorf("invalid decimal: %q", string(dec))
+	}
+
+	*d = dec
+	return nil
+}
+
+// MarshalJSON returns d as a JSON number, keeping every digit.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.valid() {
+		return nil, fmt.Errorf("invalid decimal: %q", string(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON sets *d to a copy of data, which may either be a JSON
+// number or a quoted one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if d == nil {
+		return errors.New("decimal: UnmarshalJSON on nil pointer")
+	}
+
+	str := string(data)
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+
+	dec := Decimal(str)
+	if !dec.valid() {
+		return fmt.Errorf("invalid decimal: %s", data)
+	}
+
+	*d = dec
+	return nil
+}
+
+func (d Decimal) valid() bool {
+	return len(d) == 0 || rgxDecimal.MatchString(string(d))
+}
+
+// NullDecimal is a nullable Decimal.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// Value returns d as a value, or nil when it's NULL.
+func (d NullDecimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+
+	return d.Decimal.Value()
+}
+
+// Scan stores the src in *d, a nil src sets it to NULL.
+func (d *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		d.Decimal, d.Valid = "", false
+		return nil
+	}
+
+	if err := d.Decimal.Scan(src); err != nil {
+		return err
+	}
+
+	d.Valid = true
+	return nil
+}
+
+// MarshalJSON returns the decimal as a JSON number, or null when it's NULL.
+func (d NullDecimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+
+	return d.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON sets *d from a JSON number, a quoted one or null.
+func (d *NullDecimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		d.Decimal, d.Valid = "", false
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &d.Decimal); err != nil {
+		return err
+	}
+
+	d.Valid = true
+	return nil
+}
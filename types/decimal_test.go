@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimalScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In   interface{}
+		Want string
+	}{
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{[]byte("-0.000000000000000001"), "-0.000000000000000001"},
+		{"1.500", "1.500"},
+		{int64(-42), "-42"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+		{float64(2.25), "2.25"},
+		{float64(1e21), "1000000000000000000000"},
+	}
+
+	for i, test := range tests {
+		var d Decimal
+		if err := d.Scan(test.In); err != nil {
+			t.Errorf("%d) %v", i, err)
+			continue
+		}
+		if d.String() != test.Want {
+			t.Errorf("%d) want %s, got %s", i, test.Want, d.String())
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan("1e5"); err == nil {
+		t.Error("expected an error scanning an exponent")
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("expected an error scanning a bool")
+	}
+}
+
+func TestDecimalValue(t *testing.T) {
+	t.Parallel()
+
+	val, err := Decimal("-98765432109876543210.0100").Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val.(string) != "-98765432109876543210.0100" {
+		t.Errorf("wrong value: %v", val)
+	}
+
+	if val, _ = Decimal("").Value(); val.(string) != "0" {
+		t.Errorf("want the zero value to be 0, got %v", val)
+	}
+
+	if _, err = Decimal("abc").Value(); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	t.Parallel()
+
+	type withDecimal struct {
+		Amount Decimal `json:"amount"`
+	}
+
+	b, err := json.Marshal(withDecimal{Amount: "-12345678901234567890.1200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"amount":-12345678901234567890.1200}` {
+		t.Errorf("wrong json: %s", b)
+	}
+
+	var w withDecimal
+	if err = json.Unmarshal(b, &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Amount != "-12345678901234567890.1200" {
+		t.Errorf("wrong decimal: %s", w.Amount)
+	}
+
+	if err = json.Unmarshal([]byte(`{"amount":"3.140"}`), &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Amount != "3.140" {
+		t.Errorf("wrong decimal: %s", w.Amount)
+	}
+
+	if err = json.Unmarshal([]byte(`{"amount":"pi"}`), &w); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+}
+
+func TestNullDecimalValueScan(t *testing.T) {
+	t.Parallel()
+
+	var d NullDecimal
+	if err := d.Scan(nil); err != nil {
+		t.Error(err)
+	}
+	if d.Valid {
+		t.Error("expected NULL")
+	}
+
+	v, err := d.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != nil {
+		t.Errorf("expected nil, got %v", v)
+	}
+
+	if err = d.Scan([]byte("12.340")); err != nil {
+		t.Error(err)
+	}
+	if !d.Valid || d.Decimal != "12.340" {
+		t.Errorf("wrong value: %#v", d)
+	}
+
+	v, err = d.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != "12.340" {
+		t.Errorf("expected 12.340, got %v", v)
+	}
+
+	if err = d.Scan("abc"); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+}
+
+func TestNullDecimalJSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(NullDecimal{})
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != "null" {
+		t.Errorf("expected null, got %s", b)
+	}
+
+	var d NullDecimal
+	if err = json.Unmarshal([]byte(`"-1.5"`), &d); err != nil {
+		t.Error(err)
+	}
+	if !d.Valid || d.Decimal != "-1.5" {
+		t.Errorf("wrong value: %#v", d)
+	}
+
+	b, err = json.Marshal(d)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != "-1.5" {
+		t.Errorf("wrong json: %s", b)
+	}
+
+	if err = json.Unmarshal([]byte("null"), &d); err != nil {
+		t.Error(err)
+	}
+	if d.Valid {
+		t.Errorf("expected NULL, got %#v", d)
+	}
+}
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -38,3 +41,133 @@ func (str *FixedString) Scan(src interface{}) error {
 
 	return nil
 }
+
+// MarshalText returns the trimmed string.
+func (str FixedString) MarshalText() ([]byte, error) {
+	return []byte(str.String()), nil
+}
+
+// UnmarshalText sets *str to the trimmed text.
+func (str *FixedString) UnmarshalText(text []byte) error {
+	*str = FixedString(text).trimZero()
+	return nil
+}
+
+// MarshalJSON returns the trimmed string.
+func (str FixedString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(str.String())
+}
+
+// UnmarshalJSON sets *str from a JSON string, trimmed.
+func (str *FixedString) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*str = FixedString(s).trimZero()
+	return nil
+}
+
+// MaxLen returns an error when the trimmed str doesn't fit in a
+// FixedString(n) column, that is when it's longer than n bytes.
+func (str FixedString) MaxLen(n int) error {
+	if l := len(str.trimZero()); l > n {
+		return fmt.Errorf("fixed string is %d bytes long, more than %d", l, n)
+	}
+
+	return nil
+}
+
+// FixedStringRaw is a clickhouse FixedString holding binary data, unlike
+// FixedString its zero bytes are kept as they are, padding included.
+type FixedStringRaw []byte
+
+// Value returns raw as a value.
+func (raw FixedStringRaw) Value() (driver.Value, error) {
+	return []byte(raw), nil
+}
+
+// Scan stores a copy of the src bytes in *raw.
+func (raw *FixedStringRaw) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		*raw = FixedStringRaw(src)
+	case []byte:
+		*raw = append(FixedStringRaw(nil), src...)
+	default:
+		return errors.New("incompatible type for FixedStringRaw")
+	}
+
+	return nil
+}
+
+// NullFixedString is a nullable clickhouse FixedString.
+type NullFixedString struct {
+	FixedString
+	Valid bool
+}
+
+// Value returns str as a value, or nil when it's NULL.
+func (str NullFixedString) Value() (driver.Value, error) {
+	if !str.Valid {
+		return nil, nil
+	}
+
+	return str.FixedString.Value()
+}
+
+// Scan stores the src in *str, a nil src sets it to NULL.
+func (str *NullFixedString) Scan(src interface{}) error {
+	if src == nil {
+		str.FixedString, str.Valid = "", false
+		return nil
+	}
+
+	if err := str.FixedString.Scan(src); err != nil {
+		return err
+	}
+
+	str.Valid = true
+	return nil
+}
+
+// MarshalText returns the trimmed string, or no text when it's NULL.
+func (str NullFixedString) MarshalText() ([]byte, error) {
+	if !str.Valid {
+		return []byte{}, nil
+	}
+
+	return str.FixedString.MarshalText()
+}
+
+// UnmarshalText sets *str to the trimmed text, which is never NULL.
+func (str *NullFixedString) UnmarshalText(text []byte) error {
+	str.Valid = true
+	return str.FixedString.UnmarshalText(text)
+}
+
+// MarshalJSON returns the trimmed string, or null when it's NULL.
+func (str NullFixedString) MarshalJSON() ([]byte, error) {
+	if !str.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(str.String())
+}
+
+// UnmarshalJSON sets *str from a JSON string or null.
+func (str *NullFixedString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		str.FixedString, str.Valid = "", false
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	str.FixedString, str.Valid = FixedString(s).trimZero(), true
+	return nil
+}
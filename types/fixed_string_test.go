@@ -0,0 +1,242 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestNullFixedStringScan(t *testing.T) {
+	t.Parallel()
+
+	var str NullFixedString
+
+	if err := str.Scan(nil); err != nil {
+		t.Error(err)
+	}
+	if str.Valid || str.String() != "" {
+		t.Errorf("expected invalid empty value, got: %#v", str)
+	}
+
+	if err := str.Scan(""); err != nil {
+		t.Error(err)
+	}
+	if !str.Valid || str.String() != "" {
+		t.Errorf("expected valid empty value, got: %#v", str)
+	}
+
+	if err := str.Scan("abc\x00\x00\x00"); err != nil {
+		t.Error(err)
+	}
+	if !str.Valid || str.FixedString != "abc" {
+		t.Errorf("expected valid trimmed value, got: %#v", str)
+	}
+
+	if err := str.Scan(5); err == nil {
+		t.Error("expected an error scanning an int")
+	}
+}
+
+func TestNullFixedStringValue(t *testing.T) {
+	t.Parallel()
+
+	v, err := NullFixedString{}.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != nil {
+		t.Errorf("expected nil, got: %#v", v)
+	}
+
+	v, err = NullFixedString{FixedString: "abc\x00\x00", Valid: true}.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != "abc" {
+		t.Errorf("expected abc, got: %#v", v)
+	}
+}
+
+func TestNullFixedStringJSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(NullFixedString{})
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != "null" {
+		t.Errorf("expected null, got: %s", b)
+	}
+
+	b, err = json.Marshal(NullFixedString{FixedString: "abc\x00\x00", Valid: true})
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != `"abc"` {
+		t.Errorf("expected \"abc\", got: %s", b)
+	}
+
+	var str NullFixedString
+	if err = json.Unmarshal([]byte(`"abc\u0000"`), &str); err != nil {
+		t.Error(err)
+	}
+	if !str.Valid || str.FixedString != "abc" {
+		t.Errorf("expected valid trimmed value, got: %#v", str)
+	}
+
+	if err = json.Unmarshal([]byte(`null`), &str); err != nil {
+		t.Error(err)
+	}
+	if str.Valid || str.FixedString != "" {
+		t.Errorf("expected invalid empty value, got: %#v", str)
+	}
+
+	if err = json.Unmarshal([]byte(`""`), &str); err != nil {
+		t.Error(err)
+	}
+	if !str.Valid || str.FixedString != "" {
+		t.Errorf("expected valid empty value, got: %#v", str)
+	}
+}
+
+func TestFixedStringMaxLen(t *testing.T) {
+	t.Parallel()
+
+	if err := FixedString("abcd").MaxLen(4); err != nil {
+		t.Error(err)
+	}
+	if err := FixedString("ab\x00\x00\x00").MaxLen(2); err != nil {
+		t.Errorf("the zero padding should be ignored: %v", err)
+	}
+	if err := FixedString("abcde").MaxLen(4); err == nil {
+		t.Error("expected an error for a string longer than 4 bytes")
+	}
+}
+
+func TestFixedStringRawScan(t *testing.T) {
+	t.Parallel()
+
+	padded := "ab\x00c\x00\x00"
+
+	var str FixedString
+	if err := str.Scan(padded); err != nil {
+		t.Fatal(err)
+	}
+	if str != "ab\x00c" {
+		t.Errorf("expected the zero padding trimmed, got: %q", str)
+	}
+
+	var raw FixedStringRaw
+	if err := raw.Scan(padded); err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != padded {
+		t.Errorf("expected the zero padding kept, got: %q", raw)
+	}
+
+	src := []byte{0, 1, 0, 0}
+	if err := raw.Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	src[1] = 2
+	if !bytes.Equal(raw, []byte{0, 1, 0, 0}) {
+		t.Errorf("expected a copy of the bytes, got: %v", []byte(raw))
+	}
+
+	val, err := raw.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := val.([]byte); !ok || !bytes.Equal(b, []byte{0, 1, 0, 0}) {
+		t.Errorf("expected the raw bytes as value, got: %#v", val)
+	}
+
+	if err := raw.Scan(5); err == nil {
+		t.Error("expected an error for an int")
+	}
+}
+
+func TestFixedStringText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In   FixedString
+		Text string
+	}{
+		{"abc", "abc"},
+		{"abc\x00\x00", "abc"},
+		{"", ""},
+		{"\x00\x00", ""},
+	}
+
+	for _, test := range tests {
+		text, err := test.In.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != test.Text {
+			t.Errorf("%q: expected text %q, got %q", test.In, test.Text, text)
+		}
+
+		var str FixedString
+		if err := str.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if str != FixedString(test.Text) {
+			t.Errorf("%q: expected %q back, got %q", test.In, test.Text, str)
+		}
+	}
+
+	var str FixedString
+	if err := str.UnmarshalText([]byte("xyz\x00")); err != nil {
+		t.Fatal(err)
+	}
+	if str != "xyz" {
+		t.Errorf("expected the zero padding trimmed, got %q", str)
+	}
+
+	var null NullFixedString
+	if text, err := null.MarshalText(); err != nil || len(text) != 0 {
+		t.Errorf("expected no text for NULL, got %q, %v", text, err)
+	}
+	if err := null.UnmarshalText([]byte("")); err != nil {
+		t.Fatal(err)
+	}
+	if !null.Valid || null.FixedString != "" {
+		t.Errorf("expected a valid empty value, got %#v", null)
+	}
+}
+
+func TestFixedStringJSON(t *testing.T) {
+	t.Parallel()
+
+	var str FixedString
+	if err := json.Unmarshal([]byte(`"abc\u0000\u0000"`), &str); err != nil {
+		t.Fatal(err)
+	}
+	if str != "abc" {
+		t.Errorf("expected the zero padding trimmed, got %q", str)
+	}
+
+	b, err := json.Marshal(FixedString("abc\x00\x00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"abc"` {
+		t.Errorf("expected no NULs, got %s", b)
+	}
+
+	b, err = json.Marshal(struct {
+		Code FixedString `json:"code"`
+	}{"de\x00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"code":"de"}` {
+		t.Errorf("expected no NULs in the field, got %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`12`), &str); err == nil {
+		t.Error("expected an error for a number")
+	}
+}
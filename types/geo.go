@@ -0,0 +1,160 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// The clickhouse geo types are built from points, each level of nesting
+// being an array of the previous one. The driver scans them as nested Go
+// slices of [2]float64 points and takes those as values. LineString shares
+// the structure of Ring, and MultiLineString the one of Polygon.
+
+// Point is a clickhouse Point, its X and Y coordinates.
+type Point [2]float64
+
+// Value returns p as a [2]float64.
+func (p Point) Value() (driver.Value, error) {
+	return [2]float64(p), nil
+}
+
+// Scan stores the src in *p, src is a [2]float64.
+func (p *Point) Scan(src interface{}) error {
+	if src, ok := src.([2]float64); ok {
+		*p = Point(src)
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to Point", src)
+}
+
+// Ring is a clickhouse Ring or LineString, a list of points.
+type Ring []Point
+
+// Value returns r as a [][2]float64.
+func (r Ring) Value() (driver.Value, error) {
+	return r.points(), nil
+}
+
+// Scan stores the src in *r, src is either a [][2]float64 or nil.
+func (r *Ring) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case [][2]float64:
+		*r = newRing(src)
+		return nil
+	case nil:
+		*r = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to Ring", src)
+}
+
+func (r Ring) points() [][2]float64 {
+	if r == nil {
+		return nil
+	}
+
+	points := make([][2]float64, len(r))
+	for i, p := range r {
+		points[i] = [2]float64(p)
+	}
+	return points
+}
+
+func newRing(points [][2]float64) Ring {
+	if points == nil {
+		return nil
+	}
+
+	r := make(Ring, len(points))
+	for i, p := range points {
+		r[i] = Point(p)
+	}
+	return r
+}
+
+// Polygon is a clickhouse Polygon or MultiLineString, a list of rings, the
+// first one being the outer ring and the others the holes.
+type Polygon []Ring
+
+// Value returns p as a [][][2]float64.
+func (p Polygon) Value() (driver.Value, error) {
+	return p.rings(), nil
+}
+
+// Scan stores the src in *p, src is either a [][][2]float64 or nil.
+func (p *Polygon) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case [][][2]float64:
+		*p = newPolygon(src)
+		return nil
+	case nil:
+		*p = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to Polygon", src)
+}
+
+func (p Polygon) rings() [][][2]float64 {
+	if p == nil {
+		return nil
+	}
+
+	rings := make([][][2]float64, len(p))
+	for i, r := range p {
+		rings[i] = r.points()
+	}
+	return rings
+}
+
+func newPolygon(rings [][][2]float64) Polygon {
+	if rings == nil {
+		return nil
+	}
+
+	p := make(Polygon, len(rings))
+	for i, r := range rings {
+		p[i] = newRing(r)
+	}
+	return p
+}
+
+// MultiPolygon is a clickhouse MultiPolygon, a list of polygons.
+type MultiPolygon []Polygon
+
+// Value returns m as a [][][][2]float64.
+func (m MultiPolygon) Value() (driver.Value, error) {
+	if m == nil {
+		return [][][][2]float64(nil), nil
+	}
+
+	polygons := make([][][][2]float64, len(m))
+	for i, p := range m {
+		polygons[i] = p.rings()
+	}
+	return polygons, nil
+}
+
+// Scan stores the src in *m, src is either a [][][][2]float64 or nil.
+func (m *MultiPolygon) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case [][][][2]float64:
+		if src == nil {
+			*m = nil
+			return nil
+		}
+		polygons := make(MultiPolygon, len(src))
+		for i, p := range src {
+			polygons[i] = newPolygon(p)
+		}
+		*m = polygons
+		return nil
+	case nil:
+		*m = nil
+		return nil
+	}
+
+	return fmt.Errorf("boil: cannot convert %T to MultiPolygon", src)
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPoint(t *testing.T) {
+	t.Parallel()
+
+	p := Point{1.5, -2}
+	value, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([2]float64); !ok {
+		t.Fatalf("want a [2]float64 value, got %T", value)
+	}
+
+	var q Point
+	if err := q.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if q != p {
+		t.Errorf("want %#v, got %#v", p, q)
+	}
+
+	if err := q.Scan(nil); err == nil {
+		t.Error("expected an error scanning nil")
+	}
+}
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	r := Ring{{0, 0}, {10, 0}, {10, 10}}
+	value, err := r.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]float64{{0, 0}, {10, 0}, {10, 10}}; !reflect.DeepEqual(value, want) {
+		t.Fatalf("want %#v, got %#v", want, value)
+	}
+
+	var s Ring
+	if err := s.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, s) {
+		t.Errorf("want %#v, got %#v", r, s)
+	}
+
+	if err := s.Scan(nil); err != nil || s != nil {
+		t.Errorf("want nil, got %#v, %v", s, err)
+	}
+	if err := s.Scan([]float64{1}); err == nil {
+		t.Error("expected an error scanning a []float64")
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	t.Parallel()
+
+	p := Polygon{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{4, 4}, {6, 4}, {6, 6}},
+	}
+	value, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([][][2]float64); !ok {
+		t.Fatalf("want a [][][2]float64 value, got %T", value)
+	}
+
+	var q Polygon
+	if err := q.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, q) {
+		t.Errorf("want %#v, got %#v", p, q)
+	}
+
+	if err := q.Scan(nil); err != nil || q != nil {
+		t.Errorf("want nil, got %#v, %v", q, err)
+	}
+	if err := q.Scan([][2]float64{{1, 2}}); err == nil {
+		t.Error("expected an error scanning a [][2]float64")
+	}
+}
+
+func TestMultiPolygon(t *testing.T) {
+	t.Parallel()
+
+	m := MultiPolygon{
+		{{{0, 0}, {1, 0}, {1, 1}}},
+		{{{5, 5}, {6, 5}, {6, 6}}, {{5.2, 5.2}, {5.4, 5.2}, {5.4, 5.4}}},
+	}
+	value, err := m.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := value.([][][][2]float64); !ok {
+		t.Fatalf("want a [][][][2]float64 value, got %T", value)
+	}
+
+	var n MultiPolygon
+	if err := n.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, n) {
+		t.Errorf("want %#v, got %#v", m, n)
+	}
+
+	if err := n.Scan(nil); err != nil || n != nil {
+		t.Errorf("want nil, got %#v, %v", n, err)
+	}
+	if err := n.Scan("POLYGON"); err == nil {
+		t.Error("expected an error scanning a string")
+	}
+}
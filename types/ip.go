@@ -0,0 +1,119 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// IP is an IP address, such as clickhouse's IPv4 and IPv6 types.
+type IP net.IP
+
+// String outputs the textual form of the IP address.
+func (ip IP) String() string {
+	return net.IP(ip).String()
+}
+
+// MarshalText returns the textual form of the IP address.
+func (ip IP) MarshalText() ([]byte, error) {
+	return net.IP(ip).MarshalText()
+}
+
+// UnmarshalText parses the textual form of an IP address into *ip.
+func (ip *IP) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*ip = nil
+		return nil
+	}
+
+	parsed := net.ParseIP(string(text))
+	if parsed == nil {
+		return fmt.Errorf("invalid ip address: %q", text)
+	}
+
+	*ip = IP(parsed)
+	return nil
+}
+
+// Value returns ip as a value.
+func (ip IP) Value() (driver.Value, error) {
+	return ip.String(), nil
+}
+
+// Scan stores the src in *ip. It accepts a net.IP, the textual form,
+// or the raw 4 or 16 bytes of the address.
+func (ip *IP) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case net.IP:
+		*ip = IP(append(net.IP(nil), src...))
+	case string:
+		return ip.UnmarshalText([]byte(src))
+	case []byte:
+		if len(src) == net.IPv4len || len(src) == net.IPv6len {
+			*ip = IP(append(net.IP(nil), src...))
+			return nil
+		}
+		return ip.UnmarshalText(src)
+	default:
+		return errors.New("incompatible type for ip")
+	}
+
+	return nil
+}
+
+// NullIP is a nullable IP.
+type NullIP struct {
+	IP    IP
+	Valid bool
+}
+
+// Value returns ip as a value, or nil when it's NULL.
+func (ip NullIP) Value() (driver.Value, error) {
+	if !ip.Valid {
+		return nil, nil
+	}
+
+	return ip.IP.Value()
+}
+
+// Scan stores the src in *ip, a nil src sets it to NULL.
+func (ip *NullIP) Scan(src interface{}) error {
+	if src == nil {
+		ip.IP, ip.Valid = nil, false
+		return nil
+	}
+
+	if err := ip.IP.Scan(src); err != nil {
+		return err
+	}
+
+	ip.Valid = true
+	return nil
+}
+
+// MarshalJSON returns the IP as a JSON string, or null when it's NULL.
+func (ip NullIP) MarshalJSON() ([]byte, error) {
+	if !ip.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(ip.IP)
+}
+
+// UnmarshalJSON sets *ip from a JSON string or null.
+func (ip *NullIP) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		ip.IP, ip.Valid = nil, false
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &ip.IP); err != nil {
+		return err
+	}
+
+	ip.Valid = true
+	return nil
+}
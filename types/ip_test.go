@@ -0,0 +1,106 @@
+package types
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+func TestIPScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Src    interface{}
+		Expect net.IP
+	}{
+		{"127.0.0.1", net.ParseIP("127.0.0.1")},
+		{"192.168.10.254", net.ParseIP("192.168.10.254")},
+		{"2001:db8::68", net.ParseIP("2001:db8::68")},
+		{"::ffff:10.0.0.1", net.ParseIP("10.0.0.1")},
+		{[]byte("10.0.0.1"), net.ParseIP("10.0.0.1")},
+		{[]byte{10, 0, 0, 1}, net.IPv4(10, 0, 0, 1)},
+		{net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::1")},
+	}
+
+	for i, test := range tests {
+		var ip IP
+		if err := ip.Scan(test.Src); err != nil {
+			t.Errorf("%d) %v", i, err)
+			continue
+		}
+
+		if !net.IP(ip).Equal(test.Expect) {
+			t.Errorf("%d) expected %s, got %s", i, test.Expect, ip)
+		}
+	}
+
+	var ip IP
+	for _, bad := range []interface{}{5, "10.0.0", "not an ip"} {
+		if err := ip.Scan(bad); err == nil {
+			t.Errorf("expected an error scanning %#v", bad)
+		}
+	}
+}
+
+func TestIPValue(t *testing.T) {
+	t.Parallel()
+
+	v, err := IP(net.ParseIP("2001:db8::68")).Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != "2001:db8::68" {
+		t.Errorf("wrong value: %v", v)
+	}
+
+	v, err = IP(net.ParseIP("10.0.0.1")).Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != "10.0.0.1" {
+		t.Errorf("wrong value: %v", v)
+	}
+}
+
+func TestNullIP(t *testing.T) {
+	t.Parallel()
+
+	var ip NullIP
+	if err := ip.Scan(nil); err != nil {
+		t.Error(err)
+	}
+	if ip.Valid {
+		t.Error("expected NULL")
+	}
+
+	b, err := json.Marshal(ip)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != "null" {
+		t.Errorf("expected null, got %s", b)
+	}
+
+	if err = ip.Scan("10.0.0.1"); err != nil {
+		t.Error(err)
+	}
+	if !ip.Valid || ip.IP.String() != "10.0.0.1" {
+		t.Errorf("wrong value: %#v", ip)
+	}
+
+	b, err = json.Marshal(ip)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != `"10.0.0.1"` {
+		t.Errorf("wrong json: %s", b)
+	}
+
+	var back NullIP
+	if err = json.Unmarshal(b, &back); err != nil {
+		t.Error(err)
+	}
+	if !back.Valid || back.IP.String() != "10.0.0.1" {
+		t.Errorf("wrong value: %#v", back)
+	}
+}
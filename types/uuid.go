@@ -0,0 +1,140 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// UUID is a 16 byte universally unique identifier, such as clickhouse's UUID.
+type UUID [16]byte
+
+// ParseUUID parses the canonical textual form of a UUID, for example:
+// 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid uuid: %q", s)
+	}
+
+	b, err := hex.DecodeString(s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	if err != nil {
+		return u, fmt.Errorf("invalid uuid: %q", s)
+	}
+
+	copy(u[:], b)
+	return u, nil
+}
+
+// String outputs the canonical textual form of the UUID.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf)
+}
+
+// MarshalText returns the canonical textual form of the UUID.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses the canonical textual form of a UUID into *u.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
+
+// Value returns u as a value.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Scan stores the src in *u. It accepts the textual form, or the
+// raw 16 bytes.
+func (u *UUID) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return u.UnmarshalText([]byte(src))
+	case []byte:
+		if len(src) == len(u) {
+			copy(u[:], src)
+			return nil
+		}
+		return u.UnmarshalText(src)
+	default:
+		return errors.New("incompatible type for uuid")
+	}
+}
+
+// NullUUID is a nullable UUID.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool
+}
+
+// Value returns u as a value, or nil when it's NULL.
+func (u NullUUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+
+	return u.UUID.Value()
+}
+
+// Scan stores the src in *u, a nil src sets it to NULL.
+func (u *NullUUID) Scan(src interface{}) error {
+	if src == nil {
+		u.UUID, u.Valid = UUID{}, false
+		return nil
+	}
+
+	if err := u.UUID.Scan(src); err != nil {
+		return err
+	}
+
+	u.Valid = true
+	return nil
+}
+
+// MarshalJSON returns the UUID as a JSON string, or null when it's NULL.
+func (u NullUUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(u.UUID)
+}
+
+// UnmarshalJSON sets *u from a JSON string or null.
+func (u *NullUUID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		u.UUID, u.Valid = UUID{}, false
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &u.UUID); err != nil {
+		return err
+	}
+
+	u.Valid = true
+	return nil
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+func TestUUIDValueScan(t *testing.T) {
+	t.Parallel()
+
+	var u UUID
+	if err := u.Scan(testUUID); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := u.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != testUUID {
+		t.Errorf("expected %s, got %v", testUUID, v)
+	}
+
+	var raw UUID
+	if err = raw.Scan(u[:]); err != nil {
+		t.Error(err)
+	}
+	if raw != u {
+		t.Errorf("expected %s, got %s", u, raw)
+	}
+
+	var text UUID
+	if err = text.Scan([]byte(testUUID)); err != nil {
+		t.Error(err)
+	}
+	if text != u {
+		t.Errorf("expected %s, got %s", u, text)
+	}
+
+	bad := []interface{}{5, "6ba7b810", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", "6ba7b810x9dad-11d1-80b4-00c04fd430c8"}
+	for _, b := range bad {
+		if err = u.Scan(b); err == nil {
+			t.Errorf("expected an error scanning %#v", b)
+		}
+	}
+}
+
+func TestUUIDJSON(t *testing.T) {
+	t.Parallel()
+
+	u, err := ParseUUID(testUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != `"`+testUUID+`"` {
+		t.Errorf("wrong json: %s", b)
+	}
+
+	var back UUID
+	if err = json.Unmarshal(b, &back); err != nil {
+		t.Error(err)
+	}
+	if back != u {
+		t.Errorf("expected %s, got %s", u, back)
+	}
+}
+
+func TestNullUUIDValueScan(t *testing.T) {
+	t.Parallel()
+
+	var u NullUUID
+	if err := u.Scan(nil); err != nil {
+		t.Error(err)
+	}
+	if u.Valid {
+		t.Error("expected NULL")
+	}
+
+	v, err := u.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != nil {
+		t.Errorf("expected nil, got %v", v)
+	}
+
+	if err = u.Scan(testUUID); err != nil {
+		t.Error(err)
+	}
+	if !u.Valid || u.UUID.String() != testUUID {
+		t.Errorf("wrong value: %#v", u)
+	}
+
+	v, err = u.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != testUUID {
+		t.Errorf("expected %s, got %v", testUUID, v)
+	}
+}
+
+func TestNullUUIDJSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(NullUUID{})
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != "null" {
+		t.Errorf("expected null, got %s", b)
+	}
+
+	var u NullUUID
+	if err = json.Unmarshal([]byte(`"`+testUUID+`"`), &u); err != nil {
+		t.Error(err)
+	}
+	if !u.Valid || u.UUID.String() != testUUID {
+		t.Errorf("wrong value: %#v", u)
+	}
+
+	b, err = json.Marshal(u)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(b) != `"`+testUUID+`"` {
+		t.Errorf("wrong json: %s", b)
+	}
+
+	if err = json.Unmarshal([]byte("null"), &u); err != nil {
+		t.Error(err)
+	}
+	if u.Valid {
+		t.Error("expected NULL")
+	}
+}